@@ -20,8 +20,11 @@ import (
 	"github.com/futurehomeno/fimpgo/fimptype"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/futurehomeno/edge-easee-adapter/internal/config"
+	"github.com/futurehomeno/edge-easee-adapter/internal/db"
+	"github.com/futurehomeno/edge-easee-adapter/internal/easee"
 	"github.com/futurehomeno/edge-easee-adapter/internal/model"
 	"github.com/futurehomeno/edge-easee-adapter/internal/test"
 	"github.com/futurehomeno/edge-easee-adapter/internal/test/mocks"
@@ -36,6 +39,9 @@ const (
 func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 	mqttAddr := test.SetupMQTTContainer(t)
 	testContainer := newTestContainer(t)
+	departureAt := time.Now().Add(10 * time.Hour).Truncate(time.Second)
+	solarMeterTopic := "pt:j1/mt:evt/rt:dev/rn:han/ad:1/sv:meter_elec/ad:1"
+	mainMeterTopic := "pt:j1/mt:evt/rt:dev/rn:han/ad:1/sv:meter_elec/ad:2"
 
 	s := &suite.Suite{
 		Config: suite.Config{
@@ -45,9 +51,9 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 			{
 				Name: "Adapter is capable of reacting to incoming observations",
 				Setup: serviceSetup(testContainer, "configured", mqttAddr, func(client *mocks.APIClient) {
-					client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{}, nil)
-					client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{}, nil)
-					client.On("Ping").Return(nil)
+					client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{}, nil)
+					client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{}, nil)
+					client.On("Ping", mock.Anything).Return(nil)
 				}, signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 					s.MockObservations(0, []model.Observation{
 						{
@@ -145,9 +151,9 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{}, nil)
-						client.On("Ping").Return(nil)
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{}, nil)
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{}, nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -205,9 +211,9 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{}, nil)
-						client.On("Ping").Return(nil)
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{}, nil)
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{}, nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup("localhost:1111", nil)),
 				TearDown: []suite.Callback{tearDown("configured"), testContainer.TearDown()},
@@ -228,9 +234,9 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{}, nil)
-						client.On("Ping").Return(nil)
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{}, nil)
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{}, nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -287,11 +293,11 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{}, nil)
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -330,9 +336,9 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{}, nil)
-						client.On("Ping").Return(nil)
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{}, nil)
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{}, nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, nil)),
 				TearDown: []suite.Callback{tearDown("configured"), testContainer.TearDown()},
@@ -353,9 +359,9 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{}, nil)
-						client.On("Ping").Return(nil)
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{}, nil)
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{}, nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -434,14 +440,15 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
 							DetectedPowerGridType: model.GridTypeTN3Phase,
-							PhaseMode:             1, // results in NL1, NL2, NL3
+							PhaseMode:             1, // locked to a single phase, but still switchable to NL1L2L3
+							OfflineCurrent:        model.OfflineCurrent{Phase1: 16, Phase2: 16, Phase3: 16},
 						}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -480,6 +487,7 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 								chargepoint.PropertyPhases:              float64(3),
 								chargepoint.PropertyGridType:            "TN",
 								chargepoint.PropertySupportedPhaseModes: []interface{}{"NL1", "NL2", "NL3", "NL1L2L3"},
+								model.PropertyOfflineMaxCurrent:         []interface{}{float64(16), float64(16), float64(16)},
 							}, nil),
 						},
 					},
@@ -492,14 +500,14 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
 							DetectedPowerGridType: model.GridTypeTN3Phase,
 							PhaseMode:             2,
 						}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -541,14 +549,14 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
 							DetectedPowerGridType: model.GridTypeTN3Phase,
 							PhaseMode:             1,
 						}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -606,14 +614,14 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
 							DetectedPowerGridType: model.GridTypeTN3Phase,
 							PhaseMode:             2,
 						}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -666,14 +674,14 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
 							DetectedPowerGridType: model.GridTypeTN3Phase,
 							PhaseMode:             1,
 						}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -719,6 +727,66 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					},
 				},
 			},
+			{
+				Name: "Set phase mode",
+				Setup: serviceSetup(
+					testContainer,
+					"configured",
+					mqttAddr,
+					func(client *mocks.APIClient) {
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
+							DetectedPowerGridType: model.GridTypeTN3Phase,
+							PhaseMode:             model.PhaseModeLockedToSinglePhase,
+						}, nil)
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
+							RatedCurrent: 32,
+						}, nil)
+						client.On("UpdatePhaseMode", mock.Anything, "XX12345", model.PhaseModeAuto).Return(nil).Once()
+						client.On("Ping", mock.Anything).Return(nil)
+					},
+					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
+						s.MockObservations(0, []model.Observation{
+							{
+								ChargerID: test.ChargerID,
+								DataType:  model.ObservationDataTypeInteger,
+								Timestamp: time.Now(),
+								ID:        model.ChargerOPState,
+								Value:     strconv.Itoa(int(model.ChargerStateAwaitingStart)),
+							},
+							{
+								ChargerID: test.ChargerID,
+								DataType:  model.ObservationDataTypeInteger,
+								Timestamp: time.Now(),
+								ID:        model.DetectedPowerGridType,
+								Value:     strconv.Itoa(int(model.GridTypeTN3Phase)),
+							},
+							{
+								ChargerID: test.ChargerID,
+								DataType:  model.ObservationDataTypeInteger,
+								Timestamp: time.Now(),
+								ID:        model.OutputPhase,
+								Value:     strconv.Itoa(int(model.P3T2T3T4T5TN)),
+							},
+						})
+					})),
+				TearDown: []suite.Callback{tearDown("configured"), testContainer.TearDown()},
+				Nodes: []*suite.Node{
+					suite.SleepNode(300 * time.Millisecond),
+					{
+						InitCallbacks: []suite.Callback{waitForRunning()},
+						Command:       suite.StringMessage(cmdDeviceChargepointTopic, "cmd.phase_mode.set", "chargepoint", "NL1L2L3"),
+						Expectations: []*suite.Expectation{
+							suite.ExpectString(evtDeviceChargepointTopic, "evt.phase_mode.report", "chargepoint", "NL1L2L3"),
+						},
+					},
+					{
+						Command: suite.StringMessage(cmdDeviceChargepointTopic, "cmd.phase_mode.set", "chargepoint", "NL1L2"),
+						Expectations: []*suite.Expectation{
+							suite.ExpectError(evtDeviceChargepointTopic, "chargepoint"),
+						},
+					},
+				},
+			},
 			{
 				Name: "Grid Type not supported",
 				Setup: serviceSetup(
@@ -726,14 +794,14 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
 							DetectedPowerGridType: model.GridTypeUnknown,
 							PhaseMode:             1,
 						}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -769,14 +837,14 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
 							DetectedPowerGridType: model.GridTypeUnknown,
 							PhaseMode:             1,
 						}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -821,14 +889,14 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
 							DetectedPowerGridType: model.GridTypeUnknown,
 							PhaseMode:             1,
 						}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -873,14 +941,14 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
 							DetectedPowerGridType: model.GridTypeUnknown,
 							PhaseMode:             1,
 						}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -913,25 +981,7 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 						InitCallbacks: []suite.Callback{waitForRunning()},
 						Command:       suite.NullMessage("pt:j1/mt:cmd/rt:dev/rn:easee/ad:1/sv:parameters/ad:1", "cmd.sup_params.get_report", "parameters"),
 						Expectations: []*suite.Expectation{
-							suite.ExpectObject("pt:j1/mt:evt/rt:dev/rn:easee/ad:1/sv:parameters/ad:1", "evt.sup_params.report", "parameters", []parameters.ParameterSpecification{{
-								ID:          "cable_always_locked",
-								Name:        "Cable always locked",
-								Description: "Maintains locked cable at all times.",
-								ValueType:   "bool",
-								WidgetType:  "select",
-								Options: parameters.SelectOptions{
-									parameters.SelectOption{
-										Label: "Yes",
-										Value: true,
-									},
-									parameters.SelectOption{
-										Label: "No",
-										Value: false,
-									},
-								},
-								DefaultValue: false,
-								ReadOnly:     false,
-							}}),
+							suite.ExpectObject("pt:j1/mt:evt/rt:dev/rn:easee/ad:1/sv:parameters/ad:1", "evt.sup_params.report", "parameters", supportedParameterSpecifications()),
 						},
 					},
 				},
@@ -943,14 +993,14 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
 							DetectedPowerGridType: model.GridTypeUnknown,
 							PhaseMode:             1,
 						}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -997,14 +1047,14 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
 							DetectedPowerGridType: model.GridTypeUnknown,
 							PhaseMode:             1,
 						}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -1049,14 +1099,14 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
 							DetectedPowerGridType: model.GridTypeUnknown,
 							PhaseMode:             1,
 						}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -1089,25 +1139,7 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 						InitCallbacks: []suite.Callback{waitForRunning()},
 						Command:       suite.StringMessage("pt:j1/mt:cmd/rt:ad/rn:easee/ad:1", "cmd.thing.get_inclusion_report", "easee", "1"),
 						Expectations: []*suite.Expectation{
-							suite.ExpectObject("pt:j1/mt:evt/rt:dev/rn:easee/ad:1/sv:parameters/ad:1", "evt.sup_params.report", "parameters", []parameters.ParameterSpecification{{
-								ID:          "cable_always_locked",
-								Name:        "Cable always locked",
-								Description: "Maintains locked cable at all times.",
-								ValueType:   "bool",
-								WidgetType:  "select",
-								Options: parameters.SelectOptions{
-									parameters.SelectOption{
-										Label: "Yes",
-										Value: true,
-									},
-									parameters.SelectOption{
-										Label: "No",
-										Value: false,
-									},
-								},
-								DefaultValue: false,
-								ReadOnly:     false,
-							}}),
+							suite.ExpectObject("pt:j1/mt:evt/rt:dev/rn:easee/ad:1/sv:parameters/ad:1", "evt.sup_params.report", "parameters", supportedParameterSpecifications()),
 						},
 					},
 				},
@@ -1119,14 +1151,14 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
 							DetectedPowerGridType: model.GridTypeUnknown,
 							PhaseMode:             1,
 						}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -1172,14 +1204,14 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
 							DetectedPowerGridType: model.GridTypeUnknown,
 							PhaseMode:             1,
 						}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -1227,6 +1259,536 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					},
 				},
 			},
+			{
+				Name: "Get session history report",
+				Setup: serviceSetup(
+					testContainer,
+					"configured",
+					mqttAddr,
+					func(client *mocks.APIClient) {
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
+							DetectedPowerGridType: model.GridTypeUnknown,
+							PhaseMode:             1,
+						}, nil)
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
+							RatedCurrent: 32,
+						}, nil)
+						client.On("Ping", mock.Anything).Return(nil)
+					},
+					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
+						s.MockObservations(0, []model.Observation{
+							{
+								ChargerID: test.ChargerID,
+								DataType:  model.ObservationDataTypeString,
+								Timestamp: time.Now(),
+								ID:        model.ChargingSessionStop,
+								Value: `{
+										  "Auth": "",
+										  "AuthReason": 0,
+										  "EnergyKwh": 0.411273,
+										  "Id": 435,
+										  "MeterValueStart": 1277.872637,
+										  "MeterValueStop": 1278.28391,
+										  "Start": "2025-01-22T12:51:47.000Z",
+										  "Stop": "2025-01-22T13:05:38.000Z"
+										}`,
+							},
+						})
+					})),
+				TearDown: []suite.Callback{tearDown("configured"), testContainer.TearDown()},
+				Nodes: []*suite.Node{
+					{
+						InitCallbacks: []suite.Callback{
+							waitForRunning(),
+							func(_ *testing.T) {
+								time.Sleep(10 * time.Millisecond)
+							},
+						},
+						Command: suite.StringMapMessage(cmdDeviceChargepointTopic, "cmd.session_history.get_report", "chargepoint", map[string]string{
+							"from": "2025-01-22T00:00:00Z",
+							"to":   "2025-01-23T00:00:00Z",
+						}),
+						Expectations: []*suite.Expectation{
+							suite.ExpectObject(evtDeviceChargepointTopic, "evt.session_history.report", "chargepoint", easee.SessionHistoryReport{
+								{
+									ID:              435,
+									StartedAt:       time.Date(2025, time.January, 22, 12, 51, 47, 0, time.UTC),
+									FinishedAt:      time.Date(2025, time.January, 22, 13, 5, 38, 0, time.UTC),
+									Energy:          0.411273,
+									MeterValueStart: 1277.872637,
+									MeterValueStop:  1278.28391,
+								},
+							}),
+						},
+					},
+					{
+						Name: "Sessions out of the requested time range are skipped",
+						Command: suite.StringMapMessage(cmdDeviceChargepointTopic, "cmd.session_history.get_report", "chargepoint", map[string]string{
+							"from": "2025-01-23T00:00:00Z",
+							"to":   "2025-01-24T00:00:00Z",
+						}),
+						Expectations: []*suite.Expectation{
+							suite.ExpectObject(evtDeviceChargepointTopic, "evt.session_history.report", "chargepoint", easee.SessionHistoryReport{}),
+						},
+					},
+					{
+						Name: "Invalid query",
+						Command: suite.StringMapMessage(cmdDeviceChargepointTopic, "cmd.session_history.get_report", "chargepoint", map[string]string{
+							"limit": "-1",
+						}),
+						Expectations: []*suite.Expectation{
+							suite.ExpectError(evtDeviceChargepointTopic, "chargepoint"),
+						},
+					},
+				},
+			},
+			{
+				Name: "Missed charging sessions are reconciled after connecting",
+				Setup: serviceSetup(
+					testContainer,
+					"configured",
+					mqttAddr,
+					func(client *mocks.APIClient) {
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
+							DetectedPowerGridType: model.GridTypeUnknown,
+							PhaseMode:             1,
+						}, nil)
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
+							RatedCurrent: 32,
+						}, nil)
+						client.On("Ping", mock.Anything).Return(nil)
+						client.On("ChargerSessions", mock.Anything, "XX12345", 10, 0).Return([]model.ChargerSession{
+							{
+								ID:              435,
+								CarConnected:    time.Date(2025, time.January, 22, 12, 51, 47, 0, time.UTC),
+								CarDisconnected: time.Date(2025, time.January, 22, 13, 5, 38, 0, time.UTC),
+								KiloWattHours:   0.411273,
+							},
+							{
+								ID:              434,
+								CarConnected:    time.Date(2025, time.January, 21, 12, 0, 0, 0, time.UTC),
+								CarDisconnected: time.Date(2025, time.January, 21, 13, 0, 0, 0, time.UTC),
+								KiloWattHours:   1,
+							},
+						}, nil)
+					},
+					signalRSetup(test.DefaultSignalRAddr, nil),
+					// Only sessions newer than the latest one known locally are reconciled.
+					sessionStorageSetup(func(storage db.ChargingSessionStorage) {
+						_ = storage.RegisterSessionStart("XX12345", model.StartChargingSession{
+							ID:    434,
+							Start: time.Date(2025, time.January, 21, 12, 0, 0, 0, time.UTC),
+						})
+						_ = storage.RegisterSessionStop("XX12345", model.StopChargingSession{
+							ID:     434,
+							Start:  time.Date(2025, time.January, 21, 12, 0, 0, 0, time.UTC),
+							Stop:   time.Date(2025, time.January, 21, 13, 0, 0, 0, time.UTC),
+							Energy: 1,
+						})
+					})),
+				TearDown: []suite.Callback{tearDown("configured"), testContainer.TearDown()},
+				Nodes: []*suite.Node{
+					{
+						InitCallbacks: []suite.Callback{
+							waitForRunning(),
+							func(_ *testing.T) {
+								time.Sleep(50 * time.Millisecond)
+							},
+						},
+						Command: suite.StringMapMessage(cmdDeviceChargepointTopic, "cmd.session_history.get_report", "chargepoint", map[string]string{
+							"from": "2025-01-22T00:00:00Z",
+							"to":   "2025-01-23T00:00:00Z",
+						}),
+						Expectations: []*suite.Expectation{
+							suite.ExpectObject(evtDeviceChargepointTopic, "evt.session_history.report", "chargepoint", easee.SessionHistoryReport{
+								{
+									ID:         435,
+									StartedAt:  time.Date(2025, time.January, 22, 12, 51, 47, 0, time.UTC),
+									FinishedAt: time.Date(2025, time.January, 22, 13, 5, 38, 0, time.UTC),
+									Energy:     0.411273,
+								},
+							}),
+						},
+					},
+				},
+			},
+			{
+				Name: "Cost of charging sessions is calculated with the configured tariff",
+				Setup: serviceSetup(
+					testContainer,
+					"configured",
+					mqttAddr,
+					func(client *mocks.APIClient) {
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
+							DetectedPowerGridType: model.GridTypeUnknown,
+							PhaseMode:             1,
+						}, nil)
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
+							RatedCurrent: 32,
+						}, nil)
+						client.On("Ping", mock.Anything).Return(nil)
+					},
+					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
+						s.MockObservations(500*time.Millisecond, []model.Observation{
+							{
+								ChargerID: test.ChargerID,
+								DataType:  model.ObservationDataTypeString,
+								Timestamp: time.Now(),
+								ID:        model.ChargingSessionStart,
+								Value:     `{ "Auth": "", "AuthReason": 0, "Id": 435, "MeterValue": 1000, "Start": "2025-01-22T12:51:47.000Z"}`,
+							},
+							{
+								ChargerID: test.ChargerID,
+								DataType:  model.ObservationDataTypeString,
+								Timestamp: time.Now(),
+								ID:        model.ChargingSessionStop,
+								Value: `{
+										  "Auth": "",
+										  "AuthReason": 0,
+										  "EnergyKwh": 2.5,
+										  "Id": 435,
+										  "MeterValueStart": 1000,
+										  "MeterValueStop": 1002.5,
+										  "Start": "2025-01-22T12:51:47.000Z",
+										  "Stop": "2025-01-22T13:05:38.000Z"
+										}`,
+							},
+						})
+					})),
+				TearDown: []suite.Callback{tearDown("configured"), testContainer.TearDown()},
+				Nodes: []*suite.Node{
+					{
+						InitCallbacks: []suite.Callback{waitForRunning()},
+						Command: suite.ObjectMessage("pt:j1/mt:cmd/rt:ad/rn:easee/ad:1", "cmd.config.set_tariff", "easee", model.Tariff{
+							Type:     model.TariffTypeFixed,
+							Currency: "NOK",
+							Price:    2,
+						}),
+						Expectations: []*suite.Expectation{
+							suite.ExpectMessage("pt:j1/mt:evt/rt:ad/rn:easee/ad:1", "evt.config.tariff_report", "easee"),
+						},
+					},
+					{
+						InitCallbacks: []suite.Callback{
+							func(_ *testing.T) {
+								time.Sleep(time.Second)
+							},
+						},
+						Command: suite.NullMessage(cmdDeviceChargepointTopic, "cmd.current_session.get_report", "chargepoint"),
+						Expectations: []*suite.Expectation{
+							suite.ExpectFloat(evtDeviceChargepointTopic, "evt.current_session.report", "chargepoint", 0).
+								ExpectProperty("cost", "5.00").
+								ExpectProperty("currency", "NOK"),
+						},
+					},
+					{
+						Command: suite.StringMapMessage(cmdDeviceChargepointTopic, "cmd.session_history.get_report", "chargepoint", map[string]string{
+							"from": "2025-01-22T00:00:00Z",
+							"to":   "2025-01-23T00:00:00Z",
+						}),
+						Expectations: []*suite.Expectation{
+							suite.ExpectObject(evtDeviceChargepointTopic, "evt.session_history.report", "chargepoint", easee.SessionHistoryReport{
+								{
+									ID:              435,
+									StartedAt:       time.Date(2025, time.January, 22, 12, 51, 47, 0, time.UTC),
+									FinishedAt:      time.Date(2025, time.January, 22, 13, 5, 38, 0, time.UTC),
+									Energy:          2.5,
+									MeterValueStart: 1000,
+									MeterValueStop:  1002.5,
+									Cost:            5,
+								},
+							}),
+						},
+					},
+				},
+			},
+			{
+				Name: "Charging schedule",
+				Setup: serviceSetup(
+					testContainer,
+					"configured",
+					mqttAddr,
+					func(client *mocks.APIClient) {
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
+							DetectedPowerGridType: model.GridTypeUnknown,
+							PhaseMode:             1,
+						}, nil)
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
+							RatedCurrent: 32,
+						}, nil)
+						client.On("Ping", mock.Anything).Return(nil)
+					},
+					signalRSetup(test.DefaultSignalRAddr, nil)),
+				TearDown: []suite.Callback{tearDown("configured"), testContainer.TearDown()},
+				Nodes: []*suite.Node{
+					{
+						Name:          "Empty schedule is reported by default",
+						InitCallbacks: []suite.Callback{waitForRunning()},
+						Command:       suite.NullMessage(cmdDeviceChargepointTopic, "cmd.schedule.get_report", "chargepoint"),
+						Expectations: []*suite.Expectation{
+							suite.ExpectObject(evtDeviceChargepointTopic, "evt.schedule.report", "chargepoint", model.ChargingSchedule{}),
+						},
+					},
+					{
+						Name: "Set schedule",
+						Command: suite.ObjectMessage(cmdDeviceChargepointTopic, "cmd.schedule.set", "chargepoint", model.ChargingSchedule{
+							{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "01:00", End: "06:00", Current: 16},
+						}),
+						Expectations: []*suite.Expectation{
+							suite.ExpectObject(evtDeviceChargepointTopic, "evt.schedule.report", "chargepoint", model.ChargingSchedule{
+								{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "01:00", End: "06:00", Current: 16},
+							}),
+						},
+					},
+					{
+						Name: "Invalid schedule",
+						Command: suite.ObjectMessage(cmdDeviceChargepointTopic, "cmd.schedule.set", "chargepoint", model.ChargingSchedule{
+							{Days: []string{"monday"}, Start: "01:00", End: "06:00"},
+						}),
+						Expectations: []*suite.Expectation{
+							suite.ExpectError(evtDeviceChargepointTopic, "chargepoint"),
+						},
+					},
+					{
+						Name:    "Get schedule",
+						Command: suite.NullMessage(cmdDeviceChargepointTopic, "cmd.schedule.get_report", "chargepoint"),
+						Expectations: []*suite.Expectation{
+							suite.ExpectObject(evtDeviceChargepointTopic, "evt.schedule.report", "chargepoint", model.ChargingSchedule{
+								{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "01:00", End: "06:00", Current: 16},
+							}),
+						},
+					},
+					{
+						Name:    "Clear schedule",
+						Command: suite.NullMessage(cmdDeviceChargepointTopic, "cmd.schedule.clear", "chargepoint"),
+						Expectations: []*suite.Expectation{
+							suite.ExpectObject(evtDeviceChargepointTopic, "evt.schedule.report", "chargepoint", model.ChargingSchedule{}),
+						},
+					},
+				},
+			},
+			{
+				Name: "Departure target",
+				Setup: serviceSetup(
+					testContainer,
+					"configured",
+					mqttAddr,
+					func(client *mocks.APIClient) {
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
+							DetectedPowerGridType: model.GridTypeTN3Phase,
+							PhaseMode:             2,
+						}, nil)
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
+							RatedCurrent: 32,
+						}, nil)
+						client.On("Ping", mock.Anything).Return(nil)
+						client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", mock.Anything).Return(nil).Twice()
+					},
+					signalRSetup(test.DefaultSignalRAddr, nil)),
+				TearDown: []suite.Callback{tearDown("configured"), testContainer.TearDown()},
+				Nodes: []*suite.Node{
+					{
+						Name:          "Departure charging requires a target",
+						InitCallbacks: []suite.Callback{waitForRunning()},
+						Command:       chargeStartMessage(model.ChargingModeDeparture),
+						Expectations: []*suite.Expectation{
+							suite.ExpectError(evtDeviceChargepointTopic, "chargepoint"),
+						},
+					},
+					{
+						Name: "Departure target in the past is rejected",
+						Command: suite.ObjectMessage(cmdDeviceChargepointTopic, "cmd.departure_target.set", "chargepoint", model.DepartureTarget{
+							Energy:      30,
+							DepartureAt: departureAt.Add(-24 * time.Hour),
+						}),
+						Expectations: []*suite.Expectation{
+							suite.ExpectError(evtDeviceChargepointTopic, "chargepoint"),
+						},
+					},
+					{
+						Name: "Set departure target",
+						Command: suite.ObjectMessage(cmdDeviceChargepointTopic, "cmd.departure_target.set", "chargepoint", model.DepartureTarget{
+							Energy:      30,
+							DepartureAt: departureAt,
+						}),
+						Expectations: []*suite.Expectation{
+							suite.ExpectObject(evtDeviceChargepointTopic, "evt.departure_target.report", "chargepoint", model.DepartureTarget{
+								Energy:      30,
+								DepartureAt: departureAt,
+							}),
+						},
+					},
+					{
+						Name:    "Start departure charging",
+						Command: chargeStartMessage(model.ChargingModeDeparture),
+						Expectations: []*suite.Expectation{
+							suite.ExpectMessage(evtDeviceChargepointTopic, "evt.state.report", "chargepoint"),
+						},
+					},
+					{
+						Name:    "Cheapest charging requires a tariff",
+						Command: chargeStartMessage(model.ChargingModeCheapest),
+						Expectations: []*suite.Expectation{
+							suite.ExpectError(evtDeviceChargepointTopic, "chargepoint"),
+						},
+					},
+					{
+						Name: "Set tariff for cheapest charging",
+						Command: suite.ObjectMessage("pt:j1/mt:cmd/rt:ad/rn:easee/ad:1", "cmd.config.set_tariff", "easee", model.Tariff{
+							Type:     model.TariffTypeFixed,
+							Currency: "NOK",
+							Price:    1,
+						}),
+						Expectations: []*suite.Expectation{
+							suite.ExpectMessage("pt:j1/mt:evt/rt:ad/rn:easee/ad:1", "evt.config.tariff_report", "easee"),
+						},
+					},
+					{
+						Name:    "Start cheapest charging",
+						Command: chargeStartMessage(model.ChargingModeCheapest),
+						Expectations: []*suite.Expectation{
+							suite.ExpectMessage(evtDeviceChargepointTopic, "evt.state.report", "chargepoint"),
+						},
+					},
+				},
+			},
+			{
+				Name: "Solar charging",
+				Setup: serviceSetup(
+					testContainer,
+					"configured",
+					mqttAddr,
+					func(client *mocks.APIClient) {
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
+							DetectedPowerGridType: model.GridTypeTN3Phase,
+							PhaseMode:             2,
+						}, nil)
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
+							RatedCurrent: 32,
+						}, nil)
+						client.On("Ping", mock.Anything).Return(nil)
+						client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(8)).Return(nil).Once()
+					},
+					signalRSetup(test.DefaultSignalRAddr, nil)),
+				TearDown: []suite.Callback{tearDown("configured"), testContainer.TearDown()},
+				Nodes: []*suite.Node{
+					{
+						Name:          "Solar charging requires a meter topic",
+						InitCallbacks: []suite.Callback{waitForRunning()},
+						Command:       chargeStartMessage(model.ChargingModeSolar),
+						Expectations: []*suite.Expectation{
+							suite.ExpectError(evtDeviceChargepointTopic, "chargepoint"),
+						},
+					},
+					{
+						Name:    "Set solar meter topic",
+						Command: suite.StringMessage("pt:j1/mt:cmd/rt:ad/rn:easee/ad:1", "cmd.config.set_solar_meter_topic", "easee", solarMeterTopic),
+						Expectations: []*suite.Expectation{
+							suite.ExpectString("pt:j1/mt:evt/rt:ad/rn:easee/ad:1", "evt.config.solar_meter_topic_report", "easee", solarMeterTopic),
+						},
+					},
+					{
+						Name:    "Start solar charging",
+						Command: chargeStartMessage(model.ChargingModeSolar),
+						Expectations: []*suite.Expectation{
+							suite.ExpectMessage(evtDeviceChargepointTopic, "evt.state.report", "chargepoint"),
+						},
+					},
+					{
+						Name: "Charging is resumed once the surplus is sufficient",
+						Command: suite.FloatMapMessage(solarMeterTopic, "evt.meter_ext.report", "meter_elec", map[string]float64{
+							"p_import": 0,
+							"p_export": 5600,
+						}),
+						Callbacks: []suite.Callback{
+							func(_ *testing.T) {
+								time.Sleep(time.Second)
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: "Load guard",
+				Setup: serviceSetup(
+					testContainer,
+					"configured",
+					mqttAddr,
+					func(client *mocks.APIClient) {
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
+							DetectedPowerGridType: model.GridTypeTN3Phase,
+							PhaseMode:             2,
+						}, nil)
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
+							RatedCurrent: 32,
+						}, nil)
+						client.On("Ping", mock.Anything).Return(nil)
+					},
+					signalRSetup(test.DefaultSignalRAddr, nil)),
+				TearDown: []suite.Callback{tearDown("configured"), testContainer.TearDown()},
+				Nodes: []*suite.Node{
+					{
+						Name:          "Set load guard meter topic",
+						InitCallbacks: []suite.Callback{waitForRunning()},
+						Command:       suite.StringMessage("pt:j1/mt:cmd/rt:ad/rn:easee/ad:1", "cmd.config.set_load_guard_meter_topic", "easee", mainMeterTopic),
+						Expectations: []*suite.Expectation{
+							suite.ExpectString("pt:j1/mt:evt/rt:ad/rn:easee/ad:1", "evt.config.load_guard_meter_topic_report", "easee", mainMeterTopic),
+						},
+					},
+					{
+						Name:    "Set main fuse rating",
+						Command: suite.FloatMessage("pt:j1/mt:cmd/rt:ad/rn:easee/ad:1", "cmd.config.set_main_fuse_rating", "easee", 25),
+						Expectations: []*suite.Expectation{
+							suite.ExpectFloat("pt:j1/mt:evt/rt:ad/rn:easee/ad:1", "evt.config.main_fuse_rating_report", "easee", 25),
+						},
+					},
+				},
+			},
+			{
+				Name: "Power sharing",
+				Setup: serviceSetup(
+					testContainer,
+					"configured",
+					mqttAddr,
+					func(client *mocks.APIClient) {
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
+							DetectedPowerGridType: model.GridTypeTN3Phase,
+							PhaseMode:             2,
+						}, nil)
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
+							RatedCurrent: 32,
+						}, nil)
+						client.On("Ping", mock.Anything).Return(nil)
+					},
+					signalRSetup(test.DefaultSignalRAddr, nil)),
+				TearDown: []suite.Callback{tearDown("configured"), testContainer.TearDown()},
+				Nodes: []*suite.Node{
+					{
+						Name:          "Set power sharing",
+						InitCallbacks: []suite.Callback{waitForRunning()},
+						Command: suite.ObjectMessage("pt:j1/mt:cmd/rt:ad/rn:easee/ad:1", "cmd.config.set_power_sharing", "easee", model.PowerSharing{
+							Strategy: model.SharingStrategyEqual,
+							Budget:   32,
+						}),
+						Expectations: []*suite.Expectation{
+							suite.ExpectObject("pt:j1/mt:evt/rt:ad/rn:easee/ad:1", "evt.config.power_sharing_report", "easee", &model.PowerSharing{
+								Strategy: model.SharingStrategyEqual,
+								Budget:   32,
+							}),
+						},
+					},
+					{
+						Name: "Invalid power sharing strategy",
+						Command: suite.ObjectMessage("pt:j1/mt:cmd/rt:ad/rn:easee/ad:1", "cmd.config.set_power_sharing", "easee", model.PowerSharing{
+							Strategy: "random",
+							Budget:   32,
+						}),
+						Expectations: []*suite.Expectation{
+							suite.ExpectError("pt:j1/mt:evt/rt:ad/rn:easee/ad:1", "easee"),
+						},
+					},
+				},
+			},
 			{
 				Name: "Cable current is properly reported, if is greater than or equal to 0",
 				Setup: serviceSetup(
@@ -1234,14 +1796,14 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
 							DetectedPowerGridType: model.GridTypeUnknown,
 							PhaseMode:             1,
 						}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -1277,14 +1839,14 @@ func TestEaseeAdapter(t *testing.T) { //nolint:paralleltest
 					"configured",
 					mqttAddr,
 					func(client *mocks.APIClient) {
-						client.On("ChargerConfig", "XX12345").Return(&model.ChargerConfig{
+						client.On("ChargerConfig", mock.Anything, "XX12345").Return(&model.ChargerConfig{
 							DetectedPowerGridType: model.GridTypeUnknown,
 							PhaseMode:             1,
 						}, nil)
-						client.On("ChargerSiteInfo", "XX12345").Return(&model.ChargerSiteInfo{
+						client.On("ChargerSiteInfo", mock.Anything, "XX12345").Return(&model.ChargerSiteInfo{
 							RatedCurrent: 32,
 						}, nil)
-						client.On("Ping").Return(nil)
+						client.On("Ping", mock.Anything).Return(nil)
 					},
 					signalRSetup(test.DefaultSignalRAddr, func(s *test.SignalRServer) {
 						s.MockObservations(0, []model.Observation{
@@ -1340,6 +1902,11 @@ func serviceSetup(tc *testContainer, configSet, mqttAddr string, mockClientFn fu
 		client := mocks.NewAPIClient(t)
 		mockClientFn(client)
 
+		// Missed sessions are reconciled on every SignalR connection, unless stated otherwise there is nothing to reconcile.
+		client.On("ChargerSessions", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+		// Firmware versions are informative only, unless stated otherwise they are unknown.
+		client.On("ChargerFirmware", mock.Anything, mock.Anything).Return(&model.Firmware{}, nil).Maybe()
+
 		services.easeeAPIClient = client
 
 		app, err := Build(cfg)
@@ -1351,6 +1918,12 @@ func serviceSetup(tc *testContainer, configSet, mqttAddr string, mockClientFn fu
 	}
 }
 
+func sessionStorageSetup(setup func(storage db.ChargingSessionStorage)) func(tc *testContainer) {
+	return func(_ *testContainer) {
+		setup(getSessionStorage(getConfigService().Model()))
+	}
+}
+
 func signalRSetup(addr string, setupServer func(s *test.SignalRServer)) func(tc *testContainer) {
 	return func(tc *testContainer) {
 		tc.signalRAddress = addr
@@ -1435,6 +2008,13 @@ func loggerSetup(t *testing.T) {
 	bootstrap.InitializeLogger(cfg.LogFile, cfg.LogLevel, cfg.LogFormat)
 }
 
+func chargeStartMessage(mode string) *fimpgo.Message {
+	msg := suite.NullMessage(cmdDeviceChargepointTopic, "cmd.charge.start", "chargepoint")
+	msg.Payload.Properties = fimpgo.Props{chargepoint.PropertyChargingMode: mode}
+
+	return msg
+}
+
 func waitForRunning() suite.Callback {
 	return func(t *testing.T) {
 		t.Helper()
@@ -1533,3 +2113,129 @@ func ExpectInclusionReportWithChargepointProps(topic string, wanted map[string]a
 
 	return e
 }
+
+func supportedParameterSpecifications() []parameters.ParameterSpecification {
+	minBrightness, maxBrightness := 0, 100
+
+	return []parameters.ParameterSpecification{
+		{
+			ID:          "cable_always_locked",
+			Name:        "Cable always locked",
+			Description: "Maintains locked cable at all times.",
+			ValueType:   "bool",
+			WidgetType:  "select",
+			Options: parameters.SelectOptions{
+				parameters.SelectOption{
+					Label: "Yes",
+					Value: true,
+				},
+				parameters.SelectOption{
+					Label: "No",
+					Value: false,
+				},
+			},
+			DefaultValue: false,
+			ReadOnly:     false,
+		},
+		{
+			ID:          "authorization_required",
+			Name:        "Authorization required",
+			Description: "Requires charging sessions to be authorized before charging starts.",
+			ValueType:   "bool",
+			WidgetType:  "select",
+			Options: parameters.SelectOptions{
+				parameters.SelectOption{
+					Label: "Yes",
+					Value: true,
+				},
+				parameters.SelectOption{
+					Label: "No",
+					Value: false,
+				},
+			},
+			DefaultValue: false,
+			ReadOnly:     false,
+		},
+		{
+			ID:          "access_level",
+			Name:        "Access level",
+			Description: "Defines who is allowed to charge when authorization is required.",
+			ValueType:   "int",
+			WidgetType:  "select",
+			Options: parameters.SelectOptions{
+				parameters.SelectOption{
+					Label: "Open for all",
+					Value: 1,
+				},
+				parameters.SelectOption{
+					Label: "Easee account required",
+					Value: 2,
+				},
+				parameters.SelectOption{
+					Label: "Whitelisted users and RFID tags",
+					Value: 3,
+				},
+			},
+			DefaultValue: 1,
+			ReadOnly:     false,
+		},
+		{
+			ID:           "led_strip_brightness",
+			Name:         "LED strip brightness",
+			Description:  "Brightness of the LED strip in percent.",
+			ValueType:    "int",
+			WidgetType:   "input",
+			Min:          &minBrightness,
+			Max:          &maxBrightness,
+			DefaultValue: 100,
+			ReadOnly:     false,
+		},
+		{
+			ID:          "smart_button_enabled",
+			Name:        "Smart button enabled",
+			Description: "Allows switching between charging modes with the button on the charger.",
+			ValueType:   "bool",
+			WidgetType:  "select",
+			Options: parameters.SelectOptions{
+				parameters.SelectOption{
+					Label: "Yes",
+					Value: true,
+				},
+				parameters.SelectOption{
+					Label: "No",
+					Value: false,
+				},
+			},
+			DefaultValue: false,
+			ReadOnly:     false,
+		},
+		{
+			ID:          "enable_idle_current",
+			Name:        "Enable idle current",
+			Description: "Keeps offering current after the car has stopped charging, e.g. to precondition the car.",
+			ValueType:   "bool",
+			WidgetType:  "select",
+			Options: parameters.SelectOptions{
+				parameters.SelectOption{
+					Label: "Yes",
+					Value: true,
+				},
+				parameters.SelectOption{
+					Label: "No",
+					Value: false,
+				},
+			},
+			DefaultValue: false,
+			ReadOnly:     false,
+		},
+		{
+			ID:           "offline_max_current",
+			Name:         "Offline max current",
+			Description:  "Max current per phase (L1, L2, L3) in A offered while the charger is disconnected from the cloud, 0 disables offline charging.",
+			ValueType:    "int_array",
+			WidgetType:   "input",
+			DefaultValue: []int{0, 0, 0},
+			ReadOnly:     false,
+		},
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/futurehomeno/edge-easee-adapter/internal/db"
 	"github.com/futurehomeno/edge-easee-adapter/internal/easee"
 	"github.com/futurehomeno/edge-easee-adapter/internal/routing"
+	"github.com/futurehomeno/edge-easee-adapter/internal/sharing"
 	"github.com/futurehomeno/edge-easee-adapter/internal/signalr"
 	"github.com/futurehomeno/edge-easee-adapter/internal/tasks"
 )
@@ -36,20 +37,28 @@ type serviceContainer struct {
 	lifecycle     *lifecycle.Lifecycle
 	mqtt          *fimpgo.MqttTransport
 
-	application     app.Application
-	manifestLoader  manifest.Loader
-	eventManager    event.Manager
-	adapter         adapter.Adapter
-	thingFactory    adapter.ThingFactory
-	adapterState    adapter.State
-	httpClient      *http.Client
-	easeeHTTPClient api.HTTPClient
-	easeeAPIClient  api.Client
-	authenticator   api.Authenticator
-	signalRClient   signalr.Client
-	signalRManager  signalr.Manager
-	eventListener   event.Listener
-	sessionStorage  db.ChargingSessionStorage
+	application      app.Application
+	manifestLoader   manifest.Loader
+	eventManager     event.Manager
+	adapter          adapter.Adapter
+	thingFactory     adapter.ThingFactory
+	adapterState     adapter.State
+	httpClient       *http.Client
+	rateLimiter      api.RateLimiter
+	commandTracker   api.CommandTracker
+	easeeHTTPClient  api.HTTPClient
+	easeeAPIClient   api.Client
+	authenticator    api.Authenticator
+	signalRClient    signalr.Client
+	signalRManager   signalr.Manager
+	eventListener    event.Listener
+	database         database.Database
+	sessionStorage   db.ChargingSessionStorage
+	scheduleStorage  db.ChargingScheduleStorage
+	departureStorage db.DepartureTargetStorage
+	allocator        sharing.Allocator
+	firmwareMonitor  easee.FirmwareMonitor
+	notifier         api.Notifier
 }
 
 func resetContainer() {
@@ -93,9 +102,9 @@ func getEventListener(cfg *config.Config) event.Listener {
 	return services.eventListener
 }
 
-// getEventListener creates or returns existing event listener service.
-func getSessionStorage(cfg *config.Config) db.ChargingSessionStorage {
-	if services.sessionStorage == nil {
+// getDatabase creates or returns existing database.
+func getDatabase(cfg *config.Config) database.Database {
+	if services.database == nil {
 		dataBase, err := database.NewDatabase(cfg.WorkDir)
 		if err != nil {
 			log.WithError(err).Error("can't create db")
@@ -103,12 +112,54 @@ func getSessionStorage(cfg *config.Config) db.ChargingSessionStorage {
 			return nil
 		}
 
+		services.database = dataBase
+	}
+
+	return services.database
+}
+
+// getSessionStorage creates or returns existing session storage service, which also manages the lifecycle of the database.
+func getSessionStorage(cfg *config.Config) db.ChargingSessionStorage {
+	if services.sessionStorage == nil {
+		dataBase := getDatabase(cfg)
+		if dataBase == nil {
+			return nil
+		}
+
 		services.sessionStorage = db.NewSessionStorage(dataBase)
 	}
 
 	return services.sessionStorage
 }
 
+// getScheduleStorage creates or returns existing schedule storage service.
+func getScheduleStorage(cfg *config.Config) db.ChargingScheduleStorage {
+	if services.scheduleStorage == nil {
+		dataBase := getDatabase(cfg)
+		if dataBase == nil {
+			return nil
+		}
+
+		services.scheduleStorage = db.NewScheduleStorage(dataBase)
+	}
+
+	return services.scheduleStorage
+}
+
+// getDepartureStorage creates or returns existing departure target storage service.
+func getDepartureStorage(cfg *config.Config) db.DepartureTargetStorage {
+	if services.departureStorage == nil {
+		dataBase := getDatabase(cfg)
+		if dataBase == nil {
+			return nil
+		}
+
+		services.departureStorage = db.NewDepartureStorage(dataBase)
+	}
+
+	return services.departureStorage
+}
+
 // getMQTT creates or returns existing MQTT broker service.
 func getMQTT(cfg *config.Config) *fimpgo.MqttTransport {
 	if services.mqtt == nil {
@@ -201,12 +252,35 @@ func getThingFactory(cfg *config.Config) adapter.ThingFactory {
 			getConfigService(),
 			getSignalRManager(cfg),
 			getSessionStorage(cfg),
+			getScheduleStorage(cfg),
+			getDepartureStorage(cfg),
+			getAllocator(cfg),
+			getFirmwareMonitor(cfg),
+			getNotifier(cfg),
 		)
 	}
 
 	return services.thingFactory
 }
 
+// getAllocator creates or returns existing allocator sharing the current budget between chargers.
+func getAllocator(cfg *config.Config) sharing.Allocator {
+	if services.allocator == nil {
+		services.allocator = sharing.NewAllocator(getConfigService())
+	}
+
+	return services.allocator
+}
+
+// getFirmwareMonitor creates or returns existing monitor of the charger firmware.
+func getFirmwareMonitor(cfg *config.Config) easee.FirmwareMonitor {
+	if services.firmwareMonitor == nil {
+		services.firmwareMonitor = easee.NewFirmwareMonitor(getEaseeAPIClient(cfg), getConfigService(), getNotifier(cfg))
+	}
+
+	return services.firmwareMonitor
+}
+
 // getEaseeHTTPClient creates or returns existing Easee HTTP client.
 func getEaseeHTTPClient() api.HTTPClient {
 	if services.easeeHTTPClient == nil {
@@ -226,29 +300,58 @@ func getEaseeAPIClient(cfg *config.Config) api.Client {
 		services.easeeAPIClient = api.NewAPIClient(
 			getEaseeHTTPClient(),
 			getAuthenticator(cfg),
+			getCommandTracker(),
 		)
 	}
 
 	return services.easeeAPIClient
 }
 
-// getHTTPClient creates or returns existing HTTP client with predefined timeout.
+// getHTTPClient creates or returns existing HTTP client retrying transient failures.
+// The HTTP timeout is applied by the rate limiter, so the time spent waiting for the rate limit does not count against it.
 func getHTTPClient() *http.Client {
 	if services.httpClient == nil {
 		services.httpClient = &http.Client{
-			Timeout: getConfigService().GetHTTPTimeout(),
+			Transport: api.NewRetryTransport(getConfigService(), getRateLimiter()),
 		}
 	}
 
 	return services.httpClient
 }
 
+// getCommandTracker creates or returns existing tracker of responses of chargers to commands.
+func getCommandTracker() api.CommandTracker {
+	if services.commandTracker == nil {
+		services.commandTracker = api.NewCommandTracker()
+	}
+
+	return services.commandTracker
+}
+
+// getRateLimiter creates or returns existing rate limiter of Easee API requests.
+func getRateLimiter() api.RateLimiter {
+	if services.rateLimiter == nil {
+		services.rateLimiter = api.NewRateLimiter(getConfigService(), http.DefaultTransport)
+	}
+
+	return services.rateLimiter
+}
+
+// getNotifier creates or returns existing notifier sending push notifications to the user.
+func getNotifier(cfg *config.Config) api.Notifier {
+	if services.notifier == nil {
+		services.notifier = notification.NewNotification(getMQTT(cfg))
+	}
+
+	return services.notifier
+}
+
 func getAuthenticator(cfg *config.Config) api.Authenticator {
 	if services.authenticator == nil {
 		services.authenticator = api.NewAuthenticator(
 			getEaseeHTTPClient(),
 			getConfigService(),
-			notification.NewNotification(getMQTT(cfg)),
+			getNotifier(cfg),
 			getMQTT(cfg),
 			routing.ServiceName,
 		)
@@ -267,7 +370,12 @@ func getSignalRClient(cfg *config.Config) signalr.Client {
 
 func getSignalRManager(cfg *config.Config) signalr.Manager {
 	if services.signalRManager == nil {
-		services.signalRManager = signalr.NewManager(getConfigService(), getSignalRClient(cfg))
+		services.signalRManager = signalr.NewManager(
+			getConfigService(),
+			getSignalRClient(cfg),
+			signalr.NewSessionReconciler(getEaseeAPIClient(cfg), getSessionStorage(cfg), getConfigService()),
+			getCommandTracker(),
+		)
 	}
 
 	return services.signalRManager
@@ -280,6 +388,7 @@ func newRouting(cfg *config.Config) []*cliffRouter.Routing {
 		getLifecycle(),
 		getApplication(cfg),
 		getAdapter(cfg),
+		getRateLimiter(),
 	)
 }
 
@@ -290,5 +399,9 @@ func newTasks(cfg *config.Config) []*task.Task {
 		getLifecycle(),
 		getApplication(cfg),
 		getAdapter(cfg),
+		getSessionStorage(cfg),
+		getAllocator(cfg),
+		getFirmwareMonitor(cfg),
+		getRateLimiter(),
 	)
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/futurehomeno/cliffhanger/adapter/service/numericmeter"
 	"github.com/futurehomeno/cliffhanger/bootstrap"
 	"github.com/futurehomeno/cliffhanger/root"
 	cliffRouter "github.com/futurehomeno/cliffhanger/router"
@@ -28,6 +29,8 @@ func Execute() {
 
 	bootstrap.WaitForShutdown()
 
+	getEaseeAPIClient(cfg).CancelRequests()
+
 	err = edgeApp.Stop()
 	if err != nil {
 		log.WithError(err).Fatalf("failed to stop the edge application")
@@ -42,6 +45,7 @@ func Build(cfg *config.Config) (root.App, error) {
 		WithTopicSubscription(
 			cliffRouter.TopicPatternAdapter(routing.ServiceName),
 			cliffRouter.TopicPatternDevices(routing.ServiceName),
+			cliffRouter.TopicPatternDeviceServiceEvents(numericmeter.MeterElec),
 		).
 		WithRouting(newRouting(cfg)...).
 		WithTask(newTasks(cfg)...).
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/michalkurzeja/go-clock"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+// unclaimedResponseMaxAge is the time a command response delivered before its ticket has been awaited is kept for.
+const unclaimedResponseMaxAge = time.Minute
+
+// acknowledgementKey is a request context key marking commands awaiting acknowledgement of the charger.
+type acknowledgementKey struct{}
+
+// ticketsKey is a request context key of the collector of command tickets returned by the Easee API.
+type ticketsKey struct{}
+
+// WithAcknowledgement returns a context making commands wait until the charger accepts or rejects them.
+// The charger response is awaited until the context is done.
+func WithAcknowledgement(ctx context.Context) context.Context {
+	return context.WithValue(ctx, acknowledgementKey{}, true)
+}
+
+// CommandTracker correlates tickets of commands accepted by the Easee API with responses of chargers delivered over SignalR.
+type CommandTracker interface {
+	// Await waits until the charger responds to the command identified by the ticket or the context is done.
+	Await(ctx context.Context, ticket model.CommandTicket) (model.CommandResponse, error)
+	// Resolve delivers the response of the charger to the command awaiting it.
+	Resolve(response model.CommandResponse)
+}
+
+type commandTracker struct {
+	mu        sync.Mutex
+	awaiting  map[string]chan model.CommandResponse
+	unclaimed map[string]unclaimedResponse
+}
+
+// unclaimedResponse represents a command response delivered before its ticket has been awaited.
+type unclaimedResponse struct {
+	response   model.CommandResponse
+	receivedAt time.Time
+}
+
+// NewCommandTracker returns a new instance of CommandTracker.
+func NewCommandTracker() CommandTracker {
+	return &commandTracker{
+		awaiting:  make(map[string]chan model.CommandResponse),
+		unclaimed: make(map[string]unclaimedResponse),
+	}
+}
+
+func (t *commandTracker) Await(ctx context.Context, ticket model.CommandTicket) (model.CommandResponse, error) {
+	key := ticket.Key()
+
+	t.mu.Lock()
+
+	// The response might be delivered before the ticket is returned by the Easee API.
+	if unclaimed, ok := t.unclaimed[key]; ok {
+		delete(t.unclaimed, key)
+		t.mu.Unlock()
+
+		return unclaimed.response, nil
+	}
+
+	responses := make(chan model.CommandResponse, 1)
+	t.awaiting[key] = responses
+
+	t.mu.Unlock()
+
+	defer func() {
+		t.mu.Lock()
+		delete(t.awaiting, key)
+		t.mu.Unlock()
+	}()
+
+	select {
+	case response := <-responses:
+		return response, nil
+	case <-ctx.Done():
+		return model.CommandResponse{}, fmt.Errorf("charger %s has not responded to command %d: %w", ticket.Device, ticket.CommandID, ctx.Err())
+	}
+}
+
+func (t *commandTracker) Resolve(response model.CommandResponse) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if responses, ok := t.awaiting[response.Key()]; ok {
+		responses <- response
+
+		delete(t.awaiting, response.Key())
+
+		return
+	}
+
+	now := clock.Now()
+
+	for key, unclaimed := range t.unclaimed {
+		if now.Sub(unclaimed.receivedAt) > unclaimedResponseMaxAge {
+			delete(t.unclaimed, key)
+		}
+	}
+
+	t.unclaimed[response.Key()] = unclaimedResponse{response: response, receivedAt: now}
+}
+
+// ticketCollector collects tickets of commands accepted by the Easee API within a request context.
+type ticketCollector struct {
+	mu      sync.Mutex
+	tickets []model.CommandTicket
+}
+
+// awaitsAcknowledgement checks if the command executed with the context should wait for acknowledgement of the charger.
+func awaitsAcknowledgement(ctx context.Context) bool {
+	awaits, _ := ctx.Value(acknowledgementKey{}).(bool)
+
+	return awaits
+}
+
+// collectTickets decodes command tickets from the response body, if they are collected within the request context.
+// The Easee API returns a single ticket for commands and a list of tickets for settings updates.
+func collectTickets(ctx context.Context, resp *http.Response) error {
+	collector, ok := ctx.Value(ticketsKey{}).(*ticketCollector)
+	if !ok {
+		return nil
+	}
+
+	var raw json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		// Nothing is awaited if the command has not been ticketed.
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to decode command ticket: %w", err)
+	}
+
+	var tickets []model.CommandTicket
+
+	if err := json.Unmarshal(raw, &tickets); err != nil {
+		var ticket model.CommandTicket
+
+		if err := json.Unmarshal(raw, &ticket); err != nil {
+			return fmt.Errorf("failed to decode command ticket: %w", err)
+		}
+
+		tickets = append(tickets, ticket)
+	}
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	collector.tickets = append(collector.tickets, tickets...)
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/api"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+	"github.com/futurehomeno/edge-easee-adapter/internal/test"
+	"github.com/futurehomeno/edge-easee-adapter/internal/test/mocks"
+)
+
+func TestCommandTracker(t *testing.T) {
+	t.Parallel()
+
+	ticket := model.CommandTicket{Device: test.ChargerID, CommandID: 48, Ticks: 638000000000000000}
+	response := model.CommandResponse{SerialNumber: test.ChargerID, ID: 48, Ticks: 638000000000000000, WasAccepted: true}
+
+	t.Run("response delivered while awaiting", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := api.NewCommandTracker()
+
+		go func() {
+			// Responses to other commands are not delivered.
+			tracker.Resolve(model.CommandResponse{SerialNumber: test.ChargerID, ID: 48, Ticks: 1})
+
+			time.Sleep(10 * time.Millisecond)
+			tracker.Resolve(response)
+		}()
+
+		got, err := tracker.Await(context.Background(), ticket)
+		assert.NoError(t, err)
+		assert.Equal(t, response, got)
+	})
+
+	t.Run("response delivered before awaiting", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := api.NewCommandTracker()
+		tracker.Resolve(response)
+
+		got, err := tracker.Await(context.Background(), ticket)
+		assert.NoError(t, err)
+		assert.Equal(t, response, got)
+	})
+
+	t.Run("charger not responding", func(t *testing.T) {
+		t.Parallel()
+
+		tracker := api.NewCommandTracker()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := tracker.Await(ctx, ticket)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestAPIClient_Acknowledgement(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		response    string
+		resolve     *model.CommandResponse
+		acknowledge bool
+		wantErr     bool
+	}{
+		{
+			name:        "command accepted by the charger",
+			response:    `[{"device":"XX12345","commandId":48,"ticks":638000000000000000}]`,
+			resolve:     &model.CommandResponse{SerialNumber: test.ChargerID, ID: 48, Ticks: 638000000000000000, WasAccepted: true},
+			acknowledge: true,
+		},
+		{
+			name:        "command rejected by the charger",
+			response:    `[{"device":"XX12345","commandId":48,"ticks":638000000000000000}]`,
+			resolve:     &model.CommandResponse{SerialNumber: test.ChargerID, ID: 48, Ticks: 638000000000000000, ResultCode: 3},
+			acknowledge: true,
+			wantErr:     true,
+		},
+		{
+			name:        "result unknown if the charger is not responding",
+			response:    `{"device":"XX12345","commandId":48,"ticks":638000000000000000}`,
+			acknowledge: true,
+		},
+		{
+			name:     "acknowledgement not requested",
+			response: `[{"device":"XX12345","commandId":48,"ticks":638000000000000000}]`,
+		},
+		{
+			name:        "command not ticketed",
+			acknowledge: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tracker := api.NewCommandTracker()
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.resolve != nil {
+					tracker.Resolve(*tt.resolve)
+				}
+
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+
+			t.Cleanup(func() {
+				s.Close()
+			})
+
+			auth := mocks.NewAuthenticator(t)
+			auth.On("AccessToken", mock.Anything).Return(test.AccessToken, nil)
+
+			c := api.NewAPIClient(api.NewHTTPClient(nil, &http.Client{Timeout: 3 * time.Second}, s.URL), auth, tracker)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			if tt.acknowledge {
+				ctx = api.WithAcknowledgement(ctx)
+			}
+
+			err := c.UpdateDynamicCurrent(ctx, test.ChargerID, 16)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"sync"
@@ -31,11 +32,11 @@ type Notifier interface {
 // Authenticator is the interface for the Easee authenticator.
 type Authenticator interface {
 	// Login logs in to the Easee API and persists credentials in config service.
-	Login(userName, password string) error
+	Login(ctx context.Context, userName, password string) error
 	// AccessToken is responsible for providing a valid access token for the Easee API.
 	// It will automatically refresh the token if it's expired.
 	// Returns an error if the application is not logged in.
-	AccessToken() (string, error)
+	AccessToken(ctx context.Context) (string, error)
 	// Logout used to remove credentials from the config
 	Logout() error
 }
@@ -76,11 +77,11 @@ func NewAuthenticator(http HTTPClient, cfgSvc *config.Service, notify Notifier,
 	return a
 }
 
-func (a *authenticator) Login(userName, password string) error {
+func (a *authenticator) Login(ctx context.Context, userName, password string) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	creds, err := a.http.Login(userName, password)
+	creds, err := a.http.Login(ctx, userName, password)
 	if err != nil {
 		return err
 	}
@@ -95,7 +96,7 @@ func (a *authenticator) Login(userName, password string) error {
 	return nil
 }
 
-func (a *authenticator) AccessToken() (string, error) {
+func (a *authenticator) AccessToken(ctx context.Context) (string, error) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
@@ -127,7 +128,7 @@ func (a *authenticator) AccessToken() (string, error) {
 		return "", errors.New("too many requests: backoff is in use")
 	}
 
-	newCredentials, err := a.http.RefreshToken(credentials.AccessToken, credentials.RefreshToken)
+	newCredentials, err := a.http.RefreshToken(ctx, credentials.AccessToken, credentials.RefreshToken)
 	if err != nil {
 		return "", a.handleRefreshFailure(err, credentials)
 	}
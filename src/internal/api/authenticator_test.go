@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"context"
 	"net/http"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/michalkurzeja/go-clock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/futurehomeno/edge-easee-adapter/internal/api"
@@ -81,14 +83,14 @@ func TestLogin(t *testing.T) {
 
 			httpClient := mocks.NewHTTPClient(t)
 
-			httpClient.On("Login", v.username, v.password).Return(&model.Credentials{
+			httpClient.On("Login", mock.Anything, v.username, v.password).Return(&model.Credentials{
 				AccessToken:  v.accessToken,
 				RefreshToken: v.refreshToken,
 			}, v.loginError)
 
 			auth := api.NewAuthenticator(httpClient, cfgSrv, notificationManager, nil, "test")
 
-			err := auth.Login(v.username, v.password)
+			err := auth.Login(context.Background(), v.username, v.password)
 
 			if v.errorContains != "" {
 				assert.NotNil(t, err)
@@ -191,7 +193,7 @@ func TestAccessToken(t *testing.T) {
 			httpClient := mocks.NewHTTPClient(t)
 
 			if !clock.Now().After(v.credentialsCfg.RefreshTokenExpiresAt) && clock.Now().After(v.credentialsCfg.AccessTokenExpiresAt) {
-				httpClient.On("RefreshToken", cfg.AccessToken, cfg.RefreshToken).Return(&model.Credentials{
+				httpClient.On("RefreshToken", mock.Anything, cfg.AccessToken, cfg.RefreshToken).Return(&model.Credentials{
 					AccessToken:  accessToken,
 					RefreshToken: refreshToken,
 				}, v.refreshTokenError)
@@ -199,7 +201,7 @@ func TestAccessToken(t *testing.T) {
 
 			auth := api.NewAuthenticator(httpClient, cfgSrv, notificationManager, mqtt, "test")
 
-			token, err := auth.AccessToken()
+			token, err := auth.AccessToken(context.Background())
 
 			if v.errorContains != "" {
 				assert.NotNil(t, err)
@@ -293,7 +295,7 @@ func TestHandleFailedRefreshToken(t *testing.T) {
 	notificationManager := fakes.NewNotifier(t)
 
 	client := mocks.NewHTTPClient(t)
-	client.On("RefreshToken", accessToken, refreshToken).
+	client.On("RefreshToken", mock.Anything, accessToken, refreshToken).
 		Return(
 			nil,
 			api.HTTPError{
@@ -314,22 +316,22 @@ func TestHandleFailedRefreshToken(t *testing.T) {
 
 	auth := api.NewAuthenticator(client, configService, notificationManager, mqtt, routing.ServiceName)
 
-	_, err = auth.AccessToken()
+	_, err = auth.AccessToken(context.Background())
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to perform token refresh api call")
 
 	for i := 0; i < 10; i++ {
-		_, err = auth.AccessToken()
+		_, err = auth.AccessToken(context.Background())
 		assert.Contains(t, err.Error(), "too many requests: backoff is in use")
 	}
 
 	time.Sleep(1 * time.Second)
 
-	_, err = auth.AccessToken()
+	_, err = auth.AccessToken(context.Background())
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to perform token refresh api call")
 
-	_, err = auth.AccessToken()
+	_, err = auth.AccessToken(context.Background())
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "too many requests: backoff is in use")
 }
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -12,6 +13,9 @@ type requestBuilder struct {
 	url     string
 	body    interface{}
 	headers map[string]string
+
+	retry bool
+	class EndpointClass
 }
 
 func newRequestBuilder(method, url string) *requestBuilder {
@@ -34,7 +38,21 @@ func (r *requestBuilder) addHeader(key, value string) *requestBuilder {
 	return r
 }
 
-func (r *requestBuilder) build() (*http.Request, error) {
+// retryable marks the request as safe to be retried, as executing it repeatedly has the same effect as executing it once.
+func (r *requestBuilder) retryable() *requestBuilder {
+	r.retry = true
+
+	return r
+}
+
+// withClass assigns the request to the endpoint class, which determines the rate limit applied to the request.
+func (r *requestBuilder) withClass(class EndpointClass) *requestBuilder {
+	r.class = class
+
+	return r
+}
+
+func (r *requestBuilder) build(ctx context.Context) (*http.Request, error) {
 	var body io.Reader
 
 	if r.body != nil {
@@ -46,7 +64,15 @@ func (r *requestBuilder) build() (*http.Request, error) {
 		body = bytes.NewReader(b)
 	}
 
-	req, err := http.NewRequest(r.method, r.url, body) //nolint:noctx
+	if r.retry {
+		ctx = context.WithValue(ctx, retryableKey{}, true)
+	}
+
+	if r.class != "" {
+		ctx = context.WithValue(ctx, endpointClassKey{}, r.class)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, r.url, body)
 	if err != nil {
 		return nil, err
 	}
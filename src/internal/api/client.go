@@ -1,7 +1,12 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
 
 	"github.com/futurehomeno/edge-easee-adapter/internal/model"
 )
@@ -9,114 +14,461 @@ import (
 // Client is a wrapper around the Easee HTTP Client with authentication capabilities.
 type Client interface {
 	// UpdateMaxCurrent updates max charger current.
-	UpdateMaxCurrent(chargerID string, current float64) error
+	UpdateMaxCurrent(ctx context.Context, chargerID string, current float64) error
 	// UpdateDynamicCurrent updates dynamic charger current, dynamic current is used as offered current.
-	UpdateDynamicCurrent(chargerID string, current float64) error
+	UpdateDynamicCurrent(ctx context.Context, chargerID string, current float64) error
+	// UpdatePhaseMode updates charger phase mode setting.
+	UpdatePhaseMode(ctx context.Context, chargerID string, phaseMode int) error
 	// StopCharging stops charging session for the selected charger.
-	StopCharging(chargerID string) error
+	StopCharging(ctx context.Context, chargerID string) error
 	// ChargerConfig retrieves charger config.
-	ChargerConfig(chargerID string) (*model.ChargerConfig, error)
-	// ChargerSiteInfo retrieves charger rated current, rated current is used as supported max current.
-	ChargerSiteInfo(chargerID string) (*model.ChargerSiteInfo, error)
+	ChargerConfig(ctx context.Context, chargerID string) (*model.ChargerConfig, error)
+	// ChargerSiteInfo retrieves the site of the charger with its circuits, rated current is used as supported max current.
+	ChargerSiteInfo(ctx context.Context, chargerID string) (*model.ChargerSiteInfo, error)
+	// CircuitDynamicCurrent retrieves dynamic current per phase of the circuit.
+	CircuitDynamicCurrent(ctx context.Context, siteID, circuitID int64) (*model.CircuitCurrent, error)
+	// UpdateCircuitDynamicCurrent updates dynamic current per phase of the circuit, limiting all chargers of the circuit.
+	UpdateCircuitDynamicCurrent(ctx context.Context, siteID, circuitID int64, current model.CircuitCurrent) error
+	// ChargerSessions returns a page of charger sessions, latest first.
+	ChargerSessions(ctx context.Context, chargerID string, limit, offset int) ([]model.ChargerSession, error)
 	// Chargers returns all available chargers.
-	Chargers() ([]model.Charger, error)
-	ChargerDetails(chargerID string) (model.ChargerDetails, error)
-	SetCableAlwaysLocked(chargerID string, locked bool) error
+	Chargers(ctx context.Context) ([]model.Charger, error)
+	ChargerDetails(ctx context.Context, chargerID string) (model.ChargerDetails, error)
+	// ChargerFirmware returns the firmware version installed on the charger and the latest version available.
+	ChargerFirmware(ctx context.Context, chargerID string) (*model.Firmware, error)
+	// Equalizers returns all available equalizers.
+	Equalizers(ctx context.Context) ([]model.Equalizer, error)
+	SetCableAlwaysLocked(ctx context.Context, chargerID string, locked bool) error
+	// SetAuthorizationRequired sets whether charging sessions have to be authorized before charging starts.
+	SetAuthorizationRequired(ctx context.Context, chargerID string, required bool) error
+	// UpdateChargerSettings updates the provided charger settings.
+	UpdateChargerSettings(ctx context.Context, chargerID string, settings model.ChargerSettings) error
+	// OfflineMaxCurrent returns the max current per phase offered by the charger while it's disconnected from the cloud.
+	OfflineMaxCurrent(ctx context.Context, chargerID string) (*model.OfflineCurrent, error)
+	// UpdateOfflineMaxCurrent updates the max current per phase offered by the charger while it's disconnected from the cloud.
+	UpdateOfflineMaxCurrent(ctx context.Context, chargerID string, current model.OfflineCurrent) error
+	// ChargerAccess returns the access level of the charger.
+	ChargerAccess(ctx context.Context, chargerID string) (model.AccessLevel, error)
+	// SetChargerAccess sets the access level of the charger.
+	SetChargerAccess(ctx context.Context, chargerID string, level model.AccessLevel) error
+	// AuthorizeCharging approves the charging session awaiting authorization.
+	AuthorizeCharging(ctx context.Context, chargerID string) error
+	// DeauthorizeCharging denies the charging session awaiting authorization or stops the authorized one.
+	DeauthorizeCharging(ctx context.Context, chargerID string) error
+	// RebootCharger reboots the charger.
+	RebootCharger(ctx context.Context, chargerID string) error
+	// UpdateFirmware updates the charger to the latest firmware available, the charger reboots once it's updated.
+	UpdateFirmware(ctx context.Context, chargerID string) error
 	// Ping checks if an external service is available.
-	Ping() error
+	Ping(ctx context.Context) error
+	// CancelRequests cancels all in-flight requests, e.g. once the application is stopped or the user logs out.
+	CancelRequests()
 }
 
 type apiClient struct {
 	httpClient HTTPClient
 	auth       Authenticator
+	tracker    CommandTracker
+
+	mu     sync.Mutex
+	ctx    context.Context //nolint:containedctx
+	cancel context.CancelFunc
 }
 
-func NewAPIClient(http HTTPClient, auth Authenticator) Client {
+func NewAPIClient(http HTTPClient, auth Authenticator, tracker CommandTracker) Client {
+	ctx, cancel := context.WithCancel(context.Background())
+
 	return &apiClient{
 		httpClient: http,
 		auth:       auth,
+		tracker:    tracker,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+func (a *apiClient) CancelRequests() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.cancel()
+	a.ctx, a.cancel = context.WithCancel(context.Background())
+}
+
+// requestContext returns a context of the request, cancelled either by the caller or once in-flight requests are cancelled.
+func (a *apiClient) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	a.mu.Lock()
+	base := a.ctx
+	a.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(base, cancel)
+
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+func (a *apiClient) UpdateMaxCurrent(ctx context.Context, chargerID string, current float64) error {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
+	if err != nil {
+		return a.tokenError(err)
+	}
+
+	return a.acknowledged(ctx, func(ctx context.Context) error {
+		return a.httpClient.UpdateMaxCurrent(ctx, token, chargerID, current)
+	})
+}
+
+func (a *apiClient) SetCableAlwaysLocked(ctx context.Context, chargerID string, locked bool) error {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
+	if err != nil {
+		return a.tokenError(err)
+	}
+
+	return a.acknowledged(ctx, func(ctx context.Context) error {
+		return a.httpClient.SetCableAlwaysLocked(ctx, token, chargerID, locked)
+	})
+}
+
+func (a *apiClient) SetAuthorizationRequired(ctx context.Context, chargerID string, required bool) error {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
+	if err != nil {
+		return a.tokenError(err)
+	}
+
+	return a.acknowledged(ctx, func(ctx context.Context) error {
+		return a.httpClient.SetAuthorizationRequired(ctx, token, chargerID, required)
+	})
+}
+
+func (a *apiClient) UpdateChargerSettings(ctx context.Context, chargerID string, settings model.ChargerSettings) error {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
+	if err != nil {
+		return a.tokenError(err)
+	}
+
+	return a.acknowledged(ctx, func(ctx context.Context) error {
+		return a.httpClient.UpdateChargerSettings(ctx, token, chargerID, settings)
+	})
+}
+
+func (a *apiClient) OfflineMaxCurrent(ctx context.Context, chargerID string) (*model.OfflineCurrent, error) {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
+	if err != nil {
+		return nil, a.tokenError(err)
+	}
+
+	return a.httpClient.OfflineMaxCurrent(ctx, token, chargerID)
+}
+
+func (a *apiClient) UpdateOfflineMaxCurrent(ctx context.Context, chargerID string, current model.OfflineCurrent) error {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
+	if err != nil {
+		return a.tokenError(err)
+	}
+
+	return a.acknowledged(ctx, func(ctx context.Context) error {
+		return a.httpClient.UpdateOfflineMaxCurrent(ctx, token, chargerID, current)
+	})
+}
+
+func (a *apiClient) ChargerAccess(ctx context.Context, chargerID string) (model.AccessLevel, error) {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
+	if err != nil {
+		return 0, a.tokenError(err)
+	}
+
+	return a.httpClient.ChargerAccess(ctx, token, chargerID)
+}
+
+func (a *apiClient) SetChargerAccess(ctx context.Context, chargerID string, level model.AccessLevel) error {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
+	if err != nil {
+		return a.tokenError(err)
+	}
+
+	return a.httpClient.SetChargerAccess(ctx, token, chargerID, level)
+}
+
+func (a *apiClient) AuthorizeCharging(ctx context.Context, chargerID string) error {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
+	if err != nil {
+		return a.tokenError(err)
+	}
+
+	return a.acknowledged(ctx, func(ctx context.Context) error {
+		return a.httpClient.AuthorizeCharging(ctx, token, chargerID)
+	})
+}
+
+func (a *apiClient) RebootCharger(ctx context.Context, chargerID string) error {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
+	if err != nil {
+		return a.tokenError(err)
 	}
+
+	return a.httpClient.RebootCharger(ctx, token, chargerID)
 }
 
-func (a *apiClient) UpdateMaxCurrent(chargerID string, current float64) error {
-	token, err := a.auth.AccessToken()
+func (a *apiClient) UpdateFirmware(ctx context.Context, chargerID string) error {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
 	if err != nil {
 		return a.tokenError(err)
 	}
 
-	return a.httpClient.UpdateMaxCurrent(token, chargerID, current)
+	return a.httpClient.UpdateFirmware(ctx, token, chargerID)
 }
 
-func (a *apiClient) SetCableAlwaysLocked(chargerID string, locked bool) error {
-	token, err := a.auth.AccessToken()
+func (a *apiClient) DeauthorizeCharging(ctx context.Context, chargerID string) error {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
 	if err != nil {
 		return a.tokenError(err)
 	}
 
-	return a.httpClient.SetCableAlwaysLocked(token, chargerID, locked)
+	return a.acknowledged(ctx, func(ctx context.Context) error {
+		return a.httpClient.DeauthorizeCharging(ctx, token, chargerID)
+	})
 }
 
-func (a *apiClient) UpdateDynamicCurrent(chargerID string, current float64) error {
-	token, err := a.auth.AccessToken()
+func (a *apiClient) UpdateDynamicCurrent(ctx context.Context, chargerID string, current float64) error {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
 	if err != nil {
 		return a.tokenError(err)
 	}
 
-	return a.httpClient.UpdateDynamicCurrent(token, chargerID, current)
+	return a.acknowledged(ctx, func(ctx context.Context) error {
+		return a.httpClient.UpdateDynamicCurrent(ctx, token, chargerID, current)
+	})
 }
 
-func (a *apiClient) StopCharging(chargerID string) error {
-	token, err := a.auth.AccessToken()
+func (a *apiClient) UpdatePhaseMode(ctx context.Context, chargerID string, phaseMode int) error {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
 	if err != nil {
 		return a.tokenError(err)
 	}
 
-	return a.httpClient.StopCharging(token, chargerID)
+	return a.acknowledged(ctx, func(ctx context.Context) error {
+		return a.httpClient.UpdatePhaseMode(ctx, token, chargerID, phaseMode)
+	})
 }
 
-func (a *apiClient) ChargerSiteInfo(chargerID string) (*model.ChargerSiteInfo, error) {
-	token, err := a.auth.AccessToken()
+func (a *apiClient) StopCharging(ctx context.Context, chargerID string) error {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
+	if err != nil {
+		return a.tokenError(err)
+	}
+
+	return a.acknowledged(ctx, func(ctx context.Context) error {
+		return a.httpClient.StopCharging(ctx, token, chargerID)
+	})
+}
+
+func (a *apiClient) ChargerSiteInfo(ctx context.Context, chargerID string) (*model.ChargerSiteInfo, error) {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
 	if err != nil {
 		return nil, a.tokenError(err)
 	}
 
-	return a.httpClient.ChargerSiteInfo(token, chargerID)
+	return a.httpClient.ChargerSiteInfo(ctx, token, chargerID)
 }
 
-func (a *apiClient) ChargerConfig(chargerID string) (*model.ChargerConfig, error) {
-	token, err := a.auth.AccessToken()
+func (a *apiClient) CircuitDynamicCurrent(ctx context.Context, siteID, circuitID int64) (*model.CircuitCurrent, error) {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
+	if err != nil {
+		return nil, a.tokenError(err)
+	}
+
+	return a.httpClient.CircuitDynamicCurrent(ctx, token, siteID, circuitID)
+}
+
+func (a *apiClient) UpdateCircuitDynamicCurrent(ctx context.Context, siteID, circuitID int64, current model.CircuitCurrent) error {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
+	if err != nil {
+		return a.tokenError(err)
+	}
+
+	return a.httpClient.UpdateCircuitDynamicCurrent(ctx, token, siteID, circuitID, current)
+}
+
+func (a *apiClient) ChargerConfig(ctx context.Context, chargerID string) (*model.ChargerConfig, error) {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
 	if err != nil {
 		return nil, a.tokenError(err)
 	}
 
-	return a.httpClient.ChargerConfig(token, chargerID)
+	return a.httpClient.ChargerConfig(ctx, token, chargerID)
 }
 
-func (a *apiClient) Chargers() ([]model.Charger, error) {
-	token, err := a.auth.AccessToken()
+func (a *apiClient) ChargerSessions(ctx context.Context, chargerID string, limit, offset int) ([]model.ChargerSession, error) {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
 	if err != nil {
 		return nil, a.tokenError(err)
 	}
 
-	return a.httpClient.Chargers(token)
+	return a.httpClient.ChargerSessions(ctx, token, chargerID, limit, offset)
 }
 
-func (a *apiClient) ChargerDetails(chargerID string) (model.ChargerDetails, error) {
-	token, err := a.auth.AccessToken()
+func (a *apiClient) Chargers(ctx context.Context) ([]model.Charger, error) {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
+	if err != nil {
+		return nil, a.tokenError(err)
+	}
+
+	return a.httpClient.Chargers(ctx, token)
+}
+
+func (a *apiClient) ChargerDetails(ctx context.Context, chargerID string) (model.ChargerDetails, error) {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
 	if err != nil {
 		return model.ChargerDetails{}, a.tokenError(err)
 	}
 
-	return a.httpClient.ChargerDetails(token, chargerID)
+	return a.httpClient.ChargerDetails(ctx, token, chargerID)
+}
+
+func (a *apiClient) ChargerFirmware(ctx context.Context, chargerID string) (*model.Firmware, error) {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
+	if err != nil {
+		return nil, a.tokenError(err)
+	}
+
+	return a.httpClient.ChargerFirmware(ctx, token, chargerID)
+}
+
+func (a *apiClient) Equalizers(ctx context.Context) ([]model.Equalizer, error) {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
+	if err != nil {
+		return nil, a.tokenError(err)
+	}
+
+	return a.httpClient.Equalizers(ctx, token)
 }
 
-func (a *apiClient) Ping() error {
-	token, err := a.auth.AccessToken()
+func (a *apiClient) Ping(ctx context.Context) error {
+	ctx, cancel := a.requestContext(ctx)
+	defer cancel()
+
+	token, err := a.auth.AccessToken(ctx)
 	if err != nil {
 		return a.tokenError(err)
 	}
 
-	return a.httpClient.Ping(token)
+	return a.httpClient.Ping(ctx, token)
+}
+
+// acknowledged executes the command and waits until the charger accepts or rejects it, if requested with WithAcknowledgement.
+// Only rejection of the command is returned as an error, as the charger might not respond before the context deadline.
+func (a *apiClient) acknowledged(ctx context.Context, command func(ctx context.Context) error) error {
+	if !awaitsAcknowledgement(ctx) || a.tracker == nil {
+		return command(ctx)
+	}
+
+	collector := &ticketCollector{}
+
+	if err := command(context.WithValue(ctx, ticketsKey{}, collector)); err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, ticket := range collector.tickets {
+		response, err := a.tracker.Await(ctx, ticket)
+		if errors.Is(err, context.DeadlineExceeded) {
+			// The response might have been lost while the charger applied the command, so the command is not considered failed.
+			log.WithError(err).Warn("result of the command is unknown")
+
+			continue
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		log.WithField("charger_id", response.SerialNumber).
+			WithField("command_id", response.ID).
+			WithField("accepted", response.WasAccepted).
+			Debug("received command response")
+
+		errs = append(errs, response.Err())
+	}
+
+	return errors.Join(errs...)
 }
 
 func (a *apiClient) tokenError(err error) error {
@@ -0,0 +1,55 @@
+package api_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/api"
+	"github.com/futurehomeno/edge-easee-adapter/internal/test"
+	"github.com/futurehomeno/edge-easee-adapter/internal/test/mocks"
+)
+
+func TestAPIClient_CancelRequests(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+
+	httpClient := mocks.NewHTTPClient(t)
+	httpClient.On("Ping", mock.Anything, test.AccessToken).
+		Run(func(args mock.Arguments) {
+			close(started)
+
+			<-args.Get(0).(context.Context).Done() //nolint:forcetypeassert
+		}).
+		Return(context.Canceled).
+		Once()
+	httpClient.On("Ping", mock.Anything, test.AccessToken).Return(nil).Once()
+
+	auth := mocks.NewAuthenticator(t)
+	auth.On("AccessToken", mock.Anything).Return(test.AccessToken, nil)
+
+	c := api.NewAPIClient(httpClient, auth, api.NewCommandTracker())
+
+	errC := make(chan error)
+
+	go func() {
+		errC <- c.Ping(context.Background())
+	}()
+
+	<-started
+	c.CancelRequests()
+
+	select {
+	case err := <-errC:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("request has not been cancelled")
+	}
+
+	// Requests sent after the cancellation are not affected.
+	assert.NoError(t, c.Ping(context.Background()))
+}
@@ -1,15 +1,13 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
-	"sync"
-	"time"
 
-	"github.com/michalkurzeja/go-clock"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/thoas/go-funk"
@@ -22,6 +20,7 @@ const (
 	loginURI        = "/api/accounts/login"
 	tokenRefreshURI = "/api/accounts/refresh_token" //nolint:gosec
 	chargersURI     = "/api/chargers"
+	equalizersURI   = "/api/equalizers"
 	healthURI       = "/health"
 
 	chargerConfigURITemplate   = "/api/chargers/%s/config"
@@ -29,8 +28,16 @@ const (
 	chargerSettingsURITemplate = "/api/chargers/%s/settings"
 	chargerStopURITemplate     = "/api/chargers/%s/commands/pause_charging"
 	cableLockURITemplate       = "/api/chargers/%s/commands/lock_state"
-	chargerSessionsURITemplate = "/api/sessions/charger/%s/sessions/descending?limit=2"
+	chargerAccessURITemplate   = "/api/chargers/%s/access"
+	authorizeURITemplate       = "/api/chargers/%s/commands/authorize_charging"
+	deauthorizeURITemplate     = "/api/chargers/%s/commands/deauthorize_and_stop_charging"
+	chargerSessionsURITemplate = "/api/sessions/charger/%s/sessions/descending?limit=%d&offset=%d"
 	chargerDetailsURITemplate  = "/api/chargers/%s/details?alwaysGetChargerAccessLevel=false"
+	chargerStateURITemplate    = "/api/chargers/%s/state"
+	rebootURITemplate          = "/api/chargers/%s/commands/reboot"
+	updateFirmwareURITemplate  = "/api/chargers/%s/commands/update_firmware"
+
+	circuitDynamicCurrentURITemplate = "/api/sites/%d/circuits/%d/dynamicCurrent"
 
 	authorizationHeader = "Authorization"
 	contentTypeHeader   = "Content-Type"
@@ -41,49 +48,77 @@ const (
 // HTTPClient represents Easee HTTP API Client.
 type HTTPClient interface {
 	// UpdateMaxCurrent updates max charger current.
-	UpdateMaxCurrent(accessToken, chargerID string, current float64) error
+	UpdateMaxCurrent(ctx context.Context, accessToken, chargerID string, current float64) error
 	// UpdateDynamicCurrent updates dynamic charger current, dynamic current is used as offered current.
-	UpdateDynamicCurrent(accessToken, chargerID string, current float64) error
+	UpdateDynamicCurrent(ctx context.Context, accessToken, chargerID string, current float64) error
+	// UpdatePhaseMode updates charger phase mode setting.
+	UpdatePhaseMode(ctx context.Context, accessToken, chargerID string, phaseMode int) error
 	// Login logs the user in the Easee API and retrieves credentials.
-	Login(userName, password string) (*model.Credentials, error)
+	Login(ctx context.Context, userName, password string) (*model.Credentials, error)
 	// RefreshToken retrieves new credentials based on an access token and a refresh token.
-	RefreshToken(accessToken, refreshToken string) (*model.Credentials, error)
+	RefreshToken(ctx context.Context, accessToken, refreshToken string) (*model.Credentials, error)
 	// StopCharging stops charging session for the selected charger.
-	StopCharging(accessToken, chargerID string) error
+	StopCharging(ctx context.Context, accessToken, chargerID string) error
 	// ChargerConfig retrieves charger config.
-	ChargerConfig(accessToken, chargerID string) (*model.ChargerConfig, error)
-	// ChargerSiteInfo retrieves charger rated current, rated current is used as supported max current.
-	ChargerSiteInfo(accessToken, chargerID string) (*model.ChargerSiteInfo, error)
+	ChargerConfig(ctx context.Context, accessToken, chargerID string) (*model.ChargerConfig, error)
+	// ChargerSiteInfo retrieves the site of the charger with its circuits, rated current is used as supported max current.
+	ChargerSiteInfo(ctx context.Context, accessToken, chargerID string) (*model.ChargerSiteInfo, error)
+	// CircuitDynamicCurrent retrieves dynamic current per phase of the circuit.
+	CircuitDynamicCurrent(ctx context.Context, accessToken string, siteID, circuitID int64) (*model.CircuitCurrent, error)
+	// UpdateCircuitDynamicCurrent updates dynamic current per phase of the circuit, limiting all chargers of the circuit.
+	UpdateCircuitDynamicCurrent(ctx context.Context, accessToken string, siteID, circuitID int64, current model.CircuitCurrent) error
+	// ChargerSessions returns a page of charger sessions, latest first.
+	ChargerSessions(ctx context.Context, accessToken, chargerID string, limit, offset int) ([]model.ChargerSession, error)
 	// Chargers returns all available chargers.
-	Chargers(accessToken string) ([]model.Charger, error)
+	Chargers(ctx context.Context, accessToken string) ([]model.Charger, error)
 	// ChargerDetails returns product's name.
-	ChargerDetails(accessToken string, chargerID string) (model.ChargerDetails, error)
+	ChargerDetails(ctx context.Context, accessToken string, chargerID string) (model.ChargerDetails, error)
+	// ChargerFirmware returns the firmware version installed on the charger and the latest version available.
+	ChargerFirmware(ctx context.Context, accessToken, chargerID string) (*model.Firmware, error)
+	// Equalizers returns all available equalizers.
+	Equalizers(ctx context.Context, accessToken string) ([]model.Equalizer, error)
 	// SetCableAlwaysLocked sets cable always lock state.
-	SetCableAlwaysLocked(accessToken string, chargerID string, locked bool) error
+	SetCableAlwaysLocked(ctx context.Context, accessToken string, chargerID string, locked bool) error
+	// SetAuthorizationRequired sets whether charging sessions have to be authorized before charging starts.
+	SetAuthorizationRequired(ctx context.Context, accessToken, chargerID string, required bool) error
+	// UpdateChargerSettings updates the provided charger settings.
+	UpdateChargerSettings(ctx context.Context, accessToken, chargerID string, settings model.ChargerSettings) error
+	// OfflineMaxCurrent returns the max current per phase offered by the charger while it's disconnected from the cloud.
+	OfflineMaxCurrent(ctx context.Context, accessToken, chargerID string) (*model.OfflineCurrent, error)
+	// UpdateOfflineMaxCurrent updates the max current per phase offered by the charger while it's disconnected from the cloud.
+	UpdateOfflineMaxCurrent(ctx context.Context, accessToken, chargerID string, current model.OfflineCurrent) error
+	// ChargerAccess returns the access level of the charger.
+	ChargerAccess(ctx context.Context, accessToken, chargerID string) (model.AccessLevel, error)
+	// SetChargerAccess sets the access level of the charger.
+	SetChargerAccess(ctx context.Context, accessToken, chargerID string, level model.AccessLevel) error
+	// AuthorizeCharging approves the charging session awaiting authorization.
+	AuthorizeCharging(ctx context.Context, accessToken, chargerID string) error
+	// DeauthorizeCharging denies the charging session awaiting authorization or stops the authorized one.
+	DeauthorizeCharging(ctx context.Context, accessToken, chargerID string) error
+	// RebootCharger reboots the charger.
+	RebootCharger(ctx context.Context, accessToken, chargerID string) error
+	// UpdateFirmware updates the charger to the latest firmware available, the charger reboots once it's updated.
+	UpdateFirmware(ctx context.Context, accessToken, chargerID string) error
 	// Ping checks if an external service is available.
-	Ping(accessToken string) error
+	Ping(ctx context.Context, accessToken string) error
 }
 
 type httpClient struct {
 	httpClient *http.Client
 	baseURL    string
 	cfgSrv     *config.Service
-
-	lock              sync.RWMutex
-	lastMaxCurrentSet map[string]time.Time
 }
 
 // NewHTTPClient returns a new instance of Easee HTTPClient.
 func NewHTTPClient(cfgSrv *config.Service, http *http.Client, baseURL string) HTTPClient {
 	return &httpClient{
-		httpClient:        http,
-		baseURL:           baseURL,
-		lastMaxCurrentSet: make(map[string]time.Time),
-		cfgSrv:            cfgSrv,
+		httpClient: http,
+		baseURL:    baseURL,
+		cfgSrv:     cfgSrv,
 	}
 }
 
-func (c *httpClient) Login(userName, password string) (*model.Credentials, error) {
+func (c *httpClient) Login(ctx context.Context, userName, password string) (*model.Credentials, error) {
 	body := loginBody{
 		Username: strings.TrimSpace(userName),
 		Password: strings.TrimSpace(password),
@@ -92,7 +127,7 @@ func (c *httpClient) Login(userName, password string) (*model.Credentials, error
 	req, err := newRequestBuilder(http.MethodPost, c.buildURL(loginURI)).
 		withBody(body).
 		addHeader(contentTypeHeader, jsonContentType).
-		build()
+		build(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create login request")
 	}
@@ -120,7 +155,7 @@ func (c *httpClient) Login(userName, password string) (*model.Credentials, error
 	return credentials, nil
 }
 
-func (c *httpClient) RefreshToken(accessToken, refreshToken string) (*model.Credentials, error) {
+func (c *httpClient) RefreshToken(ctx context.Context, accessToken, refreshToken string) (*model.Credentials, error) {
 	body := refreshBody{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
@@ -129,7 +164,7 @@ func (c *httpClient) RefreshToken(accessToken, refreshToken string) (*model.Cred
 	req, err := newRequestBuilder(http.MethodPost, c.buildURL(tokenRefreshURI)).
 		withBody(body).
 		addHeader(contentTypeHeader, jsonContentType).
-		build()
+		build(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create token refresh request")
 	}
@@ -157,14 +192,16 @@ func (c *httpClient) RefreshToken(accessToken, refreshToken string) (*model.Cred
 	return loginData, nil
 }
 
-func (c *httpClient) UpdateMaxCurrent(accessToken, chargerID string, current float64) error {
+func (c *httpClient) UpdateMaxCurrent(ctx context.Context, accessToken, chargerID string, current float64) error {
 	u := c.buildURL(chargerSettingsURITemplate, chargerID)
 
 	req, err := newRequestBuilder(http.MethodPost, u).
 		withBody(maxCurrentBody{MaxChargerCurrent: current}).
 		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
 		addHeader(contentTypeHeader, jsonContentType).
-		build()
+		retryable().
+		withClass(EndpointClassSettings).
+		build(ctx)
 	if err != nil {
 		return errors.Wrap(err, "failed to create max current request")
 	}
@@ -182,21 +219,18 @@ func (c *httpClient) UpdateMaxCurrent(accessToken, chargerID string, current flo
 		return c.handleFailedResponse(resp, "update max current request failed: unexpected status code")
 	}
 
-	return nil
+	return collectTickets(ctx, resp)
 }
 
-func (c *httpClient) UpdateDynamicCurrent(accessToken, chargerID string, current float64) error {
-	if c.shouldBackoffWithMaxCurrentChange(chargerID) {
-		return errors.New("client: failed to update dynamic current: too many requests")
-	}
-
+func (c *httpClient) UpdateDynamicCurrent(ctx context.Context, accessToken, chargerID string, current float64) error {
 	u := c.buildURL(chargerSettingsURITemplate, chargerID)
 
 	req, err := newRequestBuilder(http.MethodPost, u).
 		withBody(dynamicCurrentBody{DynamicChargerCurrent: current}).
 		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
 		addHeader(contentTypeHeader, jsonContentType).
-		build()
+		withClass(EndpointClassSettings).
+		build(ctx)
 	if err != nil {
 		return errors.Wrap(err, "failed to create dynamic current request")
 	}
@@ -214,23 +248,45 @@ func (c *httpClient) UpdateDynamicCurrent(accessToken, chargerID string, current
 		return c.handleFailedResponse(resp, "update dynamic current request failed: unexpected status code")
 	}
 
-	c.registerMaxCurrentChange(chargerID)
-
-	return nil
+	return collectTickets(ctx, resp)
 }
 
-func (c *httpClient) StopCharging(accessToken, chargerID string) error {
-	// When stop charging command is sent, Easee sets dynamic current to 0.
-	// That's why a protection against changing offered current more often than once in 30 seconds is needed.
-	if c.shouldBackoffWithMaxCurrentChange(chargerID) {
-		return errors.New("client: failed to stop charging: too many requests to the charger")
+func (c *httpClient) UpdatePhaseMode(ctx context.Context, accessToken, chargerID string, phaseMode int) error {
+	u := c.buildURL(chargerSettingsURITemplate, chargerID)
+
+	req, err := newRequestBuilder(http.MethodPost, u).
+		withBody(phaseModeBody{PhaseMode: phaseMode}).
+		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
+		addHeader(contentTypeHeader, jsonContentType).
+		retryable().
+		withClass(EndpointClassSettings).
+		build(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create phase mode request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "update phase mode request failed")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		c.logFailedResponse(resp)
+
+		return c.handleFailedResponse(resp, "update phase mode request failed: unexpected status code")
 	}
 
+	return collectTickets(ctx, resp)
+}
+
+func (c *httpClient) StopCharging(ctx context.Context, accessToken, chargerID string) error {
 	u := c.buildURL(chargerStopURITemplate, chargerID)
 
 	req, err := newRequestBuilder(http.MethodPost, u).
 		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
-		build()
+		build(ctx)
 	if err != nil {
 		return errors.Wrap(err, "failed to create stop charging request")
 	}
@@ -248,17 +304,19 @@ func (c *httpClient) StopCharging(accessToken, chargerID string) error {
 		return c.handleFailedResponse(resp, "stop charging request failed: unexpected status code")
 	}
 
-	return nil
+	return collectTickets(ctx, resp)
 }
 
-func (c *httpClient) SetCableAlwaysLocked(accessToken, chargerID string, locked bool) error {
+func (c *httpClient) SetCableAlwaysLocked(ctx context.Context, accessToken, chargerID string, locked bool) error {
 	u := c.buildURL(cableLockURITemplate, chargerID)
 
 	req, err := newRequestBuilder(http.MethodPost, u).
 		withBody(cableLockStateBody{State: locked}).
 		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
 		addHeader(contentTypeHeader, jsonContentType).
-		build()
+		retryable().
+		withClass(EndpointClassSettings).
+		build(ctx)
 	if err != nil {
 		return errors.Wrap(err, "failed to create cable lock request")
 	}
@@ -276,15 +334,305 @@ func (c *httpClient) SetCableAlwaysLocked(accessToken, chargerID string, locked
 		return c.handleFailedResponse(resp, "cable lock request failed: unexpected status code")
 	}
 
+	return collectTickets(ctx, resp)
+}
+
+func (c *httpClient) SetAuthorizationRequired(ctx context.Context, accessToken, chargerID string, required bool) error {
+	u := c.buildURL(chargerSettingsURITemplate, chargerID)
+
+	req, err := newRequestBuilder(http.MethodPost, u).
+		withBody(authorizationRequiredBody{AuthorizationRequired: required}).
+		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
+		addHeader(contentTypeHeader, jsonContentType).
+		retryable().
+		withClass(EndpointClassSettings).
+		build(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create authorization required request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "update authorization required request failed")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		c.logFailedResponse(resp)
+
+		return c.handleFailedResponse(resp, "update authorization required request failed: unexpected status code")
+	}
+
+	return collectTickets(ctx, resp)
+}
+
+func (c *httpClient) UpdateChargerSettings(ctx context.Context, accessToken, chargerID string, settings model.ChargerSettings) error {
+	u := c.buildURL(chargerSettingsURITemplate, chargerID)
+
+	req, err := newRequestBuilder(http.MethodPost, u).
+		withBody(settings).
+		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
+		addHeader(contentTypeHeader, jsonContentType).
+		retryable().
+		withClass(EndpointClassSettings).
+		build(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create charger settings request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "update charger settings request failed")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		c.logFailedResponse(resp)
+
+		return c.handleFailedResponse(resp, "update charger settings request failed: unexpected status code")
+	}
+
+	return collectTickets(ctx, resp)
+}
+
+func (c *httpClient) OfflineMaxCurrent(ctx context.Context, accessToken, chargerID string) (*model.OfflineCurrent, error) {
+	// Offline current is a part of the charger config.
+	u := c.buildURL(chargerConfigURITemplate, chargerID)
+
+	req, err := newRequestBuilder(http.MethodGet, u).
+		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
+		build(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create offline max current request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not perform offline max current api call")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		c.logFailedResponse(resp)
+
+		return nil, c.handleFailedResponse(resp, "offline max current request failed: unexpected status code")
+	}
+
+	current := &model.OfflineCurrent{}
+
+	// Zero current of all phases is a valid response, therefore the body is not checked for emptiness.
+	if err := json.NewDecoder(resp.Body).Decode(current); err != nil {
+		return nil, errors.Wrap(err, "could not read offline max current response body")
+	}
+
+	return current, nil
+}
+
+func (c *httpClient) UpdateOfflineMaxCurrent(ctx context.Context, accessToken, chargerID string, current model.OfflineCurrent) error {
+	u := c.buildURL(chargerSettingsURITemplate, chargerID)
+
+	req, err := newRequestBuilder(http.MethodPost, u).
+		withBody(current).
+		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
+		addHeader(contentTypeHeader, jsonContentType).
+		retryable().
+		withClass(EndpointClassSettings).
+		build(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create offline max current request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "update offline max current request failed")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		c.logFailedResponse(resp)
+
+		return c.handleFailedResponse(resp, "update offline max current request failed: unexpected status code")
+	}
+
+	return collectTickets(ctx, resp)
+}
+
+func (c *httpClient) ChargerAccess(ctx context.Context, accessToken, chargerID string) (model.AccessLevel, error) {
+	u := c.buildURL(chargerAccessURITemplate, chargerID)
+
+	req, err := newRequestBuilder(http.MethodGet, u).
+		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
+		build(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create charger access request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not perform charger access api call")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		c.logFailedResponse(resp)
+
+		return 0, c.handleFailedResponse(resp, "charger access request failed: unexpected status code")
+	}
+
+	var level model.AccessLevel
+
+	err = c.readResponseBody(resp, &level)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not read charger access response body")
+	}
+
+	return level, nil
+}
+
+func (c *httpClient) SetChargerAccess(ctx context.Context, accessToken, chargerID string, level model.AccessLevel) error {
+	u := c.buildURL(chargerAccessURITemplate, chargerID)
+
+	req, err := newRequestBuilder(http.MethodPut, u).
+		withBody(level).
+		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
+		addHeader(contentTypeHeader, jsonContentType).
+		withClass(EndpointClassSettings).
+		build(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create update charger access request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "update charger access request failed")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		c.logFailedResponse(resp)
+
+		return c.handleFailedResponse(resp, "update charger access request failed: unexpected status code")
+	}
+
+	return nil
+}
+
+func (c *httpClient) AuthorizeCharging(ctx context.Context, accessToken, chargerID string) error {
+	u := c.buildURL(authorizeURITemplate, chargerID)
+
+	req, err := newRequestBuilder(http.MethodPost, u).
+		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
+		build(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create authorize charging request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "authorize charging request failed")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		c.logFailedResponse(resp)
+
+		return c.handleFailedResponse(resp, "authorize charging request failed: unexpected status code")
+	}
+
+	return collectTickets(ctx, resp)
+}
+
+func (c *httpClient) DeauthorizeCharging(ctx context.Context, accessToken, chargerID string) error {
+	u := c.buildURL(deauthorizeURITemplate, chargerID)
+
+	req, err := newRequestBuilder(http.MethodPost, u).
+		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
+		build(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create deauthorize charging request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "deauthorize charging request failed")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		c.logFailedResponse(resp)
+
+		return c.handleFailedResponse(resp, "deauthorize charging request failed: unexpected status code")
+	}
+
+	return collectTickets(ctx, resp)
+}
+
+func (c *httpClient) RebootCharger(ctx context.Context, accessToken, chargerID string) error {
+	u := c.buildURL(rebootURITemplate, chargerID)
+
+	req, err := newRequestBuilder(http.MethodPost, u).
+		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
+		build(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create reboot charger request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "reboot charger request failed")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		c.logFailedResponse(resp)
+
+		return c.handleFailedResponse(resp, "reboot charger request failed: unexpected status code")
+	}
+
+	return nil
+}
+
+func (c *httpClient) UpdateFirmware(ctx context.Context, accessToken, chargerID string) error {
+	u := c.buildURL(updateFirmwareURITemplate, chargerID)
+
+	req, err := newRequestBuilder(http.MethodPost, u).
+		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
+		build(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create update firmware request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "update firmware request failed")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		c.logFailedResponse(resp)
+
+		return c.handleFailedResponse(resp, "update firmware request failed: unexpected status code")
+	}
+
 	return nil
 }
 
-func (c *httpClient) ChargerConfig(accessToken, chargerID string) (*model.ChargerConfig, error) {
+func (c *httpClient) ChargerConfig(ctx context.Context, accessToken, chargerID string) (*model.ChargerConfig, error) {
 	u := c.buildURL(chargerConfigURITemplate, chargerID)
 
 	req, err := newRequestBuilder(http.MethodGet, u).
 		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
-		build()
+		build(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create charger state request")
 	}
@@ -312,12 +660,12 @@ func (c *httpClient) ChargerConfig(accessToken, chargerID string) (*model.Charge
 	return state, nil
 }
 
-func (c *httpClient) ChargerSiteInfo(accessToken, chargerID string) (*model.ChargerSiteInfo, error) {
+func (c *httpClient) ChargerSiteInfo(ctx context.Context, accessToken, chargerID string) (*model.ChargerSiteInfo, error) {
 	u := c.buildURL(chargerSiteURITemplate, chargerID)
 
 	req, err := newRequestBuilder(http.MethodGet, u).
 		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
-		build()
+		build(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create charger site info request")
 	}
@@ -345,10 +693,108 @@ func (c *httpClient) ChargerSiteInfo(accessToken, chargerID string) (*model.Char
 	return state, nil
 }
 
-func (c *httpClient) Chargers(accessToken string) ([]model.Charger, error) {
+func (c *httpClient) CircuitDynamicCurrent(ctx context.Context, accessToken string, siteID, circuitID int64) (*model.CircuitCurrent, error) {
+	u := c.buildURL(circuitDynamicCurrentURITemplate, siteID, circuitID)
+
+	req, err := newRequestBuilder(http.MethodGet, u).
+		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
+		build(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create circuit dynamic current request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not perform circuit dynamic current api call")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		c.logFailedResponse(resp)
+
+		return nil, c.handleFailedResponse(resp, "circuit dynamic current request failed: unexpected status code")
+	}
+
+	current := &model.CircuitCurrent{}
+
+	// Zero current of all phases is a valid response, therefore the body is not checked for emptiness.
+	if err := json.NewDecoder(resp.Body).Decode(current); err != nil {
+		return nil, errors.Wrap(err, "could not read circuit dynamic current response body")
+	}
+
+	return current, nil
+}
+
+func (c *httpClient) UpdateCircuitDynamicCurrent(ctx context.Context, accessToken string, siteID, circuitID int64, current model.CircuitCurrent) error {
+	u := c.buildURL(circuitDynamicCurrentURITemplate, siteID, circuitID)
+
+	req, err := newRequestBuilder(http.MethodPost, u).
+		withBody(circuitDynamicCurrentBody{
+			Phase1: current.Phase1,
+			Phase2: current.Phase2,
+			Phase3: current.Phase3,
+		}).
+		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
+		addHeader(contentTypeHeader, jsonContentType).
+		withClass(EndpointClassSettings).
+		build(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create circuit dynamic current request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "update circuit dynamic current request failed")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		c.logFailedResponse(resp)
+
+		return c.handleFailedResponse(resp, "update circuit dynamic current request failed: unexpected status code")
+	}
+
+	return nil
+}
+
+func (c *httpClient) ChargerSessions(ctx context.Context, accessToken, chargerID string, limit, offset int) ([]model.ChargerSession, error) {
+	u := c.buildURL(chargerSessionsURITemplate, chargerID, limit, offset)
+
+	req, err := newRequestBuilder(http.MethodGet, u).
+		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
+		build(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create charger sessions request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not perform charger sessions api call")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		c.logFailedResponse(resp)
+
+		return nil, c.handleFailedResponse(resp, "charger sessions request failed: unexpected status code")
+	}
+
+	var sessions []model.ChargerSession
+
+	if err := c.readResponseBody(resp, &sessions); err != nil {
+		return nil, errors.Wrap(err, "could not read charger sessions response body")
+	}
+
+	return sessions, nil
+}
+
+func (c *httpClient) Chargers(ctx context.Context, accessToken string) ([]model.Charger, error) {
 	req, err := newRequestBuilder(http.MethodGet, c.buildURL(chargersURI)).
 		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
-		build()
+		build(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create chargers request")
 	}
@@ -375,12 +821,43 @@ func (c *httpClient) Chargers(accessToken string) ([]model.Charger, error) {
 	return chargers, nil
 }
 
-func (c *httpClient) ChargerDetails(accessToken string, chargerID string) (model.ChargerDetails, error) {
+func (c *httpClient) Equalizers(ctx context.Context, accessToken string) ([]model.Equalizer, error) {
+	req, err := newRequestBuilder(http.MethodGet, c.buildURL(equalizersURI)).
+		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
+		build(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create equalizers request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch equalizers from api")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		c.logFailedResponse(resp)
+
+		return nil, c.handleFailedResponse(resp, "equalizers request failed: unexpected status code")
+	}
+
+	var equalizers []model.Equalizer
+
+	// Account without equalizers is valid, therefore the body is not checked for emptiness.
+	if err := json.NewDecoder(resp.Body).Decode(&equalizers); err != nil {
+		return nil, errors.Wrap(err, "failed to read equalizers response body")
+	}
+
+	return equalizers, nil
+}
+
+func (c *httpClient) ChargerDetails(ctx context.Context, accessToken string, chargerID string) (model.ChargerDetails, error) {
 	u := c.buildURL(chargerDetailsURITemplate, chargerID)
 
 	req, err := newRequestBuilder(http.MethodGet, u).
 		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
-		build()
+		build(ctx)
 	if err != nil {
 		return model.ChargerDetails{}, errors.Wrap(err, "failed to create charger details state request")
 	}
@@ -408,10 +885,44 @@ func (c *httpClient) ChargerDetails(accessToken string, chargerID string) (model
 	return chargerDetails, nil
 }
 
-func (c *httpClient) Ping(accessToken string) error {
+func (c *httpClient) ChargerFirmware(ctx context.Context, accessToken, chargerID string) (*model.Firmware, error) {
+	// Firmware versions are a part of the charger state.
+	u := c.buildURL(chargerStateURITemplate, chargerID)
+
+	req, err := newRequestBuilder(http.MethodGet, u).
+		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
+		build(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create charger firmware request")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not perform charger firmware api call")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		c.logFailedResponse(resp)
+
+		return nil, c.handleFailedResponse(resp, "charger firmware request failed: unexpected status code")
+	}
+
+	firmware := &model.Firmware{}
+
+	err = c.readResponseBody(resp, firmware)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read charger firmware response body")
+	}
+
+	return firmware, nil
+}
+
+func (c *httpClient) Ping(ctx context.Context, accessToken string) error {
 	req, err := newRequestBuilder(http.MethodGet, c.buildURL(healthURI)).
 		addHeader(authorizationHeader, c.bearerTokenHeader(accessToken)).
-		build()
+		build(ctx)
 	if err != nil {
 		return errors.Wrap(err, "failed to create ping request")
 	}
@@ -479,26 +990,3 @@ func (c *httpClient) readResponseBody(r *http.Response, body interface{}) error
 func (c *httpClient) bearerTokenHeader(authToken string) string {
 	return "Bearer " + authToken
 }
-
-func (c *httpClient) shouldBackoffWithMaxCurrentChange(chargerID string) bool {
-	c.lock.RLock()
-	defer c.lock.RUnlock()
-
-	lastMaxCurrentSet, ok := c.lastMaxCurrentSet[chargerID]
-	if !ok {
-		return false
-	}
-
-	if clock.Now().Sub(lastMaxCurrentSet) >= c.cfgSrv.GetOfferedCurrentWaitTime() {
-		return false
-	}
-
-	return true
-}
-
-func (c *httpClient) registerMaxCurrentChange(chargerID string) {
-	c.lock.Lock()
-	defer c.lock.Unlock()
-
-	c.lastMaxCurrentSet[chargerID] = clock.Now()
-}
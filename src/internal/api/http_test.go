@@ -4,6 +4,7 @@ package api_test
 // TODO: refactor there test to use our internal HTTP testing package.
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -118,7 +119,7 @@ func TestClient_Login(t *testing.T) {
 			httpClient := &http.Client{Timeout: 3 * time.Second}
 			c := api.NewHTTPClient(cfgSrv, httpClient, s.URL)
 
-			got, err := c.Login(tt.username, tt.password)
+			got, err := c.Login(context.Background(), tt.username, tt.password)
 			if tt.wantErr {
 				assert.Error(t, err)
 
@@ -179,7 +180,7 @@ func TestClient_RefreshToken(t *testing.T) { //nolint:paralleltest
 			cfgSrv := config.NewConfigServiceWithStorage(&storage)
 
 			client := api.NewHTTPClient(cfgSrv, server.Client(), server.URL+v.baseURLAdj)
-			creds, err := client.RefreshToken("", "")
+			creds, err := client.RefreshToken(context.Background(), "", "")
 
 			if v.errorContains != "" {
 				assert.Contains(t, err.Error(), v.errorContains)
@@ -273,7 +274,7 @@ func TestClient_UpdateMaxCurrent(t *testing.T) { //nolint:paralleltest
 			httpClient := &http.Client{Timeout: 3 * time.Second}
 			c := api.NewHTTPClient(cfgSrv, httpClient, s.URL)
 
-			err := c.UpdateMaxCurrent(tt.accessToken, tt.chargerID, tt.current)
+			err := c.UpdateMaxCurrent(context.Background(), tt.accessToken, tt.chargerID, tt.current)
 			if tt.wantErr {
 				assert.Error(t, err)
 
@@ -367,7 +368,101 @@ func TestClient_UpdateDynamicCurrent(t *testing.T) { //nolint:paralleltest
 			httpClient := &http.Client{Timeout: 3 * time.Second}
 			c := api.NewHTTPClient(cfgSrv, httpClient, s.URL)
 
-			err := c.UpdateDynamicCurrent(tt.accessToken, tt.chargerID, tt.current)
+			err := c.UpdateDynamicCurrent(context.Background(), tt.accessToken, tt.chargerID, tt.current)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_UpdatePhaseMode(t *testing.T) { //nolint:paralleltest
+	clock.Mock(time.Date(2022, time.September, 10, 8, 0o0, 12, 0o0, time.UTC))
+
+	t.Cleanup(func() {
+		clock.Restore()
+	})
+
+	tests := []struct {
+		name             string
+		chargerID        string
+		accessToken      string
+		serverHandler    http.Handler
+		forceServerError bool
+		phaseMode        int
+		wantErr          bool
+	}{
+		{
+			name:        "successful call to Easee API",
+			chargerID:   test.ChargerID,
+			accessToken: test.AccessToken,
+			serverHandler: newTestHandler(t, []call{
+				{
+					requestMethod: http.MethodPost,
+					requestPath:   "/api/chargers/XX12345/settings",
+					requestBody:   `{"phaseMode":1}`,
+					requestHeaders: map[string]string{
+						"Authorization": "Bearer test.access.token",
+					},
+					responseCode: http.StatusAccepted,
+				},
+			}...),
+			phaseMode: 1,
+		},
+		{
+			name:        "response code != 200",
+			chargerID:   test.ChargerID,
+			accessToken: test.AccessToken,
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodPost,
+				requestPath:   "/api/chargers/XX12345/settings",
+				requestBody:   `{"phaseMode":1}`,
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusInternalServerError,
+			}),
+			phaseMode: 1,
+			wantErr:   true,
+		},
+		{
+			name:             "http client error",
+			chargerID:        test.ChargerID,
+			accessToken:      test.AccessToken,
+			forceServerError: true,
+			wantErr:          true,
+		},
+		{
+			name:      "return error if access token is empty",
+			chargerID: test.ChargerID,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			s := httptest.NewServer(tt.serverHandler)
+
+			t.Cleanup(func() {
+				s.Close()
+			})
+
+			if tt.forceServerError {
+				s.Close()
+			}
+
+			storage := mockedstorage.Storage[*config.Config]{}
+
+			cfgSrv := config.NewConfigServiceWithStorage(&storage)
+
+			httpClient := &http.Client{Timeout: 3 * time.Second}
+			c := api.NewHTTPClient(cfgSrv, httpClient, s.URL)
+
+			err := c.UpdatePhaseMode(context.Background(), tt.accessToken, tt.chargerID, tt.phaseMode)
 			if tt.wantErr {
 				assert.Error(t, err)
 
@@ -388,34 +483,716 @@ func TestClient_StopCharging(t *testing.T) { //nolint:paralleltest
 
 	tests := []struct {
 		name             string
-		chargerID        string
+		chargerID        string
+		accessToken      string
+		serverHandler    http.Handler
+		forceServerError bool
+		wantErr          bool
+	}{
+		{
+			name:        "successful call to Easee API",
+			chargerID:   test.ChargerID,
+			accessToken: test.AccessToken,
+			serverHandler: newTestHandler(t, []call{
+				{
+					requestMethod: http.MethodPost,
+					requestPath:   "/api/chargers/XX12345/commands/pause_charging",
+					requestHeaders: map[string]string{
+						"Authorization": "Bearer test.access.token",
+					},
+					responseCode: http.StatusAccepted,
+				},
+			}...),
+		},
+		{
+			name:        "response code != 200",
+			chargerID:   test.ChargerID,
+			accessToken: test.AccessToken,
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodPost,
+				requestPath:   "/api/chargers/XX12345/commands/pause_charging",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusInternalServerError,
+			}),
+			wantErr: true,
+		},
+		{
+			name:             "http client error",
+			chargerID:        test.ChargerID,
+			accessToken:      test.AccessToken,
+			forceServerError: true,
+			wantErr:          true,
+		},
+		{
+			name:        "return error if access token is empty",
+			chargerID:   test.ChargerID,
+			accessToken: test.AccessToken,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			s := httptest.NewServer(tt.serverHandler)
+
+			t.Cleanup(func() {
+				s.Close()
+			})
+
+			if tt.forceServerError {
+				s.Close()
+			}
+
+			storage := mockedstorage.Storage[*config.Config]{}
+
+			cfgSrv := config.NewConfigServiceWithStorage(&storage)
+
+			httpClient := &http.Client{Timeout: 3 * time.Second}
+			c := api.NewHTTPClient(cfgSrv, httpClient, s.URL)
+
+			err := c.StopCharging(context.Background(), tt.accessToken, tt.chargerID)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_AuthorizeCharging(t *testing.T) { //nolint:paralleltest
+	tests := []struct {
+		name             string
+		authorize        bool
+		serverHandler    http.Handler
+		forceServerError bool
+		wantErr          bool
+	}{
+		{
+			name:      "successful authorization",
+			authorize: true,
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodPost,
+				requestPath:   "/api/chargers/XX12345/commands/authorize_charging",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusAccepted,
+			}),
+		},
+		{
+			name: "successful deauthorization",
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodPost,
+				requestPath:   "/api/chargers/XX12345/commands/deauthorize_and_stop_charging",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusAccepted,
+			}),
+		},
+		{
+			name:      "response code != 202",
+			authorize: true,
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodPost,
+				requestPath:   "/api/chargers/XX12345/commands/authorize_charging",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusBadRequest,
+			}),
+			wantErr: true,
+		},
+		{
+			name:             "http client error",
+			authorize:        true,
+			forceServerError: true,
+			wantErr:          true,
+		},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			s := httptest.NewServer(tt.serverHandler)
+
+			t.Cleanup(func() {
+				s.Close()
+			})
+
+			if tt.forceServerError {
+				s.Close()
+			}
+
+			cfgSrv := config.NewConfigServiceWithStorage(&mockedstorage.Storage[*config.Config]{})
+			c := api.NewHTTPClient(cfgSrv, &http.Client{Timeout: 3 * time.Second}, s.URL)
+
+			var err error
+			if tt.authorize {
+				err = c.AuthorizeCharging(context.Background(), test.AccessToken, test.ChargerID)
+			} else {
+				err = c.DeauthorizeCharging(context.Background(), test.AccessToken, test.ChargerID)
+			}
+
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_RebootCharger(t *testing.T) { //nolint:paralleltest
+	tests := []struct {
+		name             string
+		reboot           bool
+		serverHandler    http.Handler
+		forceServerError bool
+		wantErr          bool
+	}{
+		{
+			name:   "successful reboot",
+			reboot: true,
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodPost,
+				requestPath:   "/api/chargers/XX12345/commands/reboot",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusAccepted,
+			}),
+		},
+		{
+			name: "successful firmware update",
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodPost,
+				requestPath:   "/api/chargers/XX12345/commands/update_firmware",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusAccepted,
+			}),
+		},
+		{
+			name:   "response code != 202",
+			reboot: true,
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodPost,
+				requestPath:   "/api/chargers/XX12345/commands/reboot",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusNotFound,
+			}),
+			wantErr: true,
+		},
+		{
+			name:             "http client error",
+			forceServerError: true,
+			wantErr:          true,
+		},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			s := httptest.NewServer(tt.serverHandler)
+
+			t.Cleanup(func() {
+				s.Close()
+			})
+
+			if tt.forceServerError {
+				s.Close()
+			}
+
+			cfgSrv := config.NewConfigServiceWithStorage(&mockedstorage.Storage[*config.Config]{})
+			c := api.NewHTTPClient(cfgSrv, &http.Client{Timeout: 3 * time.Second}, s.URL)
+
+			var err error
+			if tt.reboot {
+				err = c.RebootCharger(context.Background(), test.AccessToken, test.ChargerID)
+			} else {
+				err = c.UpdateFirmware(context.Background(), test.AccessToken, test.ChargerID)
+			}
+
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_ChargerAccess(t *testing.T) { //nolint:paralleltest
+	tests := []struct {
+		name             string
+		serverHandler    http.Handler
+		forceServerError bool
+		want             model.AccessLevel
+		wantErr          bool
+	}{
+		{
+			name: "successful call to Easee API",
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodGet,
+				requestPath:   "/api/chargers/XX12345/access",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusOK,
+				responseBody: "3",
+			}),
+			want: model.AccessLevelWhitelist,
+		},
+		{
+			name: "empty response body",
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodGet,
+				requestPath:   "/api/chargers/XX12345/access",
+				responseCode:  http.StatusOK,
+			}),
+			wantErr: true,
+		},
+		{
+			name: "response code != 200",
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodGet,
+				requestPath:   "/api/chargers/XX12345/access",
+				responseCode:  http.StatusNotFound,
+			}),
+			wantErr: true,
+		},
+		{
+			name:             "http client error",
+			forceServerError: true,
+			wantErr:          true,
+		},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			s := httptest.NewServer(tt.serverHandler)
+
+			t.Cleanup(func() {
+				s.Close()
+			})
+
+			if tt.forceServerError {
+				s.Close()
+			}
+
+			cfgSrv := config.NewConfigServiceWithStorage(&mockedstorage.Storage[*config.Config]{})
+			c := api.NewHTTPClient(cfgSrv, &http.Client{Timeout: 3 * time.Second}, s.URL)
+
+			got, err := c.ChargerAccess(context.Background(), test.AccessToken, test.ChargerID)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_SetChargerAccess(t *testing.T) { //nolint:paralleltest
+	s := httptest.NewServer(newTestHandler(t,
+		call{
+			requestMethod: http.MethodPut,
+			requestPath:   "/api/chargers/XX12345/access",
+			requestHeaders: map[string]string{
+				"Authorization": "Bearer test.access.token",
+				"Content-Type":  "application/*+json",
+			},
+			requestBody:  "2",
+			responseCode: http.StatusOK,
+		},
+		call{
+			requestMethod: http.MethodPost,
+			requestPath:   "/api/chargers/XX12345/settings",
+			requestHeaders: map[string]string{
+				"Authorization": "Bearer test.access.token",
+				"Content-Type":  "application/*+json",
+			},
+			requestBody:  `{"authorizationRequired":true}`,
+			responseCode: http.StatusAccepted,
+		},
+	))
+
+	t.Cleanup(func() {
+		s.Close()
+	})
+
+	cfgSrv := config.NewConfigServiceWithStorage(&mockedstorage.Storage[*config.Config]{})
+	c := api.NewHTTPClient(cfgSrv, &http.Client{Timeout: 3 * time.Second}, s.URL)
+
+	assert.NoError(t, c.SetChargerAccess(context.Background(), test.AccessToken, test.ChargerID, model.AccessLevelEaseeAccount))
+	assert.NoError(t, c.SetAuthorizationRequired(context.Background(), test.AccessToken, test.ChargerID, true))
+}
+
+func TestClient_UpdateChargerSettings(t *testing.T) { //nolint:paralleltest
+	s := httptest.NewServer(newTestHandler(t,
+		call{
+			requestMethod: http.MethodPost,
+			requestPath:   "/api/chargers/XX12345/settings",
+			requestHeaders: map[string]string{
+				"Authorization": "Bearer test.access.token",
+				"Content-Type":  "application/*+json",
+			},
+			requestBody:  `{"ledStripBrightness":0}`,
+			responseCode: http.StatusAccepted,
+		},
+		call{
+			requestMethod: http.MethodPost,
+			requestPath:   "/api/chargers/XX12345/settings",
+			requestBody:   `{"smartButtonEnabled":false}`,
+			responseCode:  http.StatusBadRequest,
+		},
+	))
+
+	t.Cleanup(func() {
+		s.Close()
+	})
+
+	cfgSrv := config.NewConfigServiceWithStorage(&mockedstorage.Storage[*config.Config]{})
+	c := api.NewHTTPClient(cfgSrv, &http.Client{Timeout: 3 * time.Second}, s.URL)
+
+	brightness, enabled := 0, false
+
+	assert.NoError(t, c.UpdateChargerSettings(context.Background(), test.AccessToken, test.ChargerID, model.ChargerSettings{LEDStripBrightness: &brightness}))
+	assert.Error(t, c.UpdateChargerSettings(context.Background(), test.AccessToken, test.ChargerID, model.ChargerSettings{SmartButtonEnabled: &enabled}))
+}
+
+func TestClient_OfflineMaxCurrent(t *testing.T) { //nolint:paralleltest
+	s := httptest.NewServer(newTestHandler(t,
+		call{
+			requestMethod: http.MethodGet,
+			requestPath:   "/api/chargers/XX12345/config",
+			requestHeaders: map[string]string{
+				"Authorization": "Bearer test.access.token",
+			},
+			responseCode: http.StatusOK,
+			responseBody: `{"phaseMode":2,"maxCurrentOfflineFallback_P1":0,"maxCurrentOfflineFallback_P2":0,"maxCurrentOfflineFallback_P3":0}`,
+		},
+		call{
+			requestMethod: http.MethodPost,
+			requestPath:   "/api/chargers/XX12345/settings",
+			requestHeaders: map[string]string{
+				"Authorization": "Bearer test.access.token",
+				"Content-Type":  "application/*+json",
+			},
+			requestBody:  `{"maxCurrentOfflineFallback_P1":16,"maxCurrentOfflineFallback_P2":16,"maxCurrentOfflineFallback_P3":10}`,
+			responseCode: http.StatusAccepted,
+		},
+	))
+
+	t.Cleanup(func() {
+		s.Close()
+	})
+
+	cfgSrv := config.NewConfigServiceWithStorage(&mockedstorage.Storage[*config.Config]{})
+	c := api.NewHTTPClient(cfgSrv, &http.Client{Timeout: 3 * time.Second}, s.URL)
+
+	got, err := c.OfflineMaxCurrent(context.Background(), test.AccessToken, test.ChargerID)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.OfflineCurrent{}, got)
+
+	err = c.UpdateOfflineMaxCurrent(context.Background(), test.AccessToken, test.ChargerID, model.OfflineCurrent{Phase1: 16, Phase2: 16, Phase3: 10})
+	assert.NoError(t, err)
+}
+
+func TestClient_ChargerConfig(t *testing.T) { //nolint:paralleltest
+	clock.Mock(time.Date(2022, time.September, 10, 8, 0o0, 12, 0o0, time.UTC))
+
+	t.Cleanup(func() {
+		clock.Restore()
+	})
+
+	tests := []struct {
+		name             string
+		chargerID        string
+		accessToken      string
+		serverHandler    http.Handler
+		forceServerError bool
+		want             *model.ChargerConfig
+		wantErr          bool
+	}{
+		{
+			name:        "successful call to Easee API",
+			chargerID:   test.ChargerID,
+			accessToken: test.AccessToken,
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodGet,
+				requestPath:   "/api/chargers/XX12345/config",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusOK,
+				responseBody: `{"maxChargerCurrent":32, "detectedPowerGridType":1}`,
+			}),
+			want: &model.ChargerConfig{
+				DetectedPowerGridType: 1,
+			},
+		},
+		{
+			name:        "response code != 200",
+			chargerID:   test.ChargerID,
+			accessToken: test.AccessToken,
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodGet,
+				requestPath:   "/api/chargers/XX12345/config",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusInternalServerError,
+			}),
+			wantErr: true,
+		},
+		{
+			name:             "http client error",
+			chargerID:        test.ChargerID,
+			accessToken:      test.AccessToken,
+			forceServerError: true,
+			wantErr:          true,
+		},
+		{
+			name:      "return error if access token is empty",
+			chargerID: test.ChargerID,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			s := httptest.NewServer(tt.serverHandler)
+
+			t.Cleanup(func() {
+				s.Close()
+			})
+
+			if tt.forceServerError {
+				s.Close()
+			}
+
+			storage := mockedstorage.Storage[*config.Config]{}
+
+			cfgSrv := config.NewConfigServiceWithStorage(&storage)
+
+			httpClient := &http.Client{Timeout: 3 * time.Second}
+			c := api.NewHTTPClient(cfgSrv, httpClient, s.URL)
+
+			got, err := c.ChargerConfig(context.Background(), tt.accessToken, tt.chargerID)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_ChargerFirmware(t *testing.T) { //nolint:paralleltest
+	tests := []struct {
+		name          string
+		serverHandler http.Handler
+		want          *model.Firmware
+		wantErr       bool
+	}{
+		{
+			name: "successful call to Easee API",
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodGet,
+				requestPath:   "/api/chargers/XX12345/state",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusOK,
+				responseBody: `{"chargerOpMode":1,"chargerFirmware":302,"latestFirmware":305}`,
+			}),
+			want: &model.Firmware{Version: 302, LatestVersion: 305},
+		},
+		{
+			name: "response code != 200",
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodGet,
+				requestPath:   "/api/chargers/XX12345/state",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusNotFound,
+			}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			s := httptest.NewServer(tt.serverHandler)
+
+			t.Cleanup(func() {
+				s.Close()
+			})
+
+			cfgSrv := config.NewConfigServiceWithStorage(&mockedstorage.Storage[*config.Config]{})
+			c := api.NewHTTPClient(cfgSrv, &http.Client{Timeout: 3 * time.Second}, s.URL)
+
+			got, err := c.ChargerFirmware(context.Background(), test.AccessToken, test.ChargerID)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, got.UpdateAvailable())
+		})
+	}
+}
+
+func TestClient_Ping(t *testing.T) { //nolint:paralleltest
+	clock.Mock(time.Date(2022, time.September, 10, 8, 0o0, 12, 0o0, time.UTC))
+
+	t.Cleanup(func() {
+		clock.Restore()
+	})
+
+	tests := []struct {
+		name             string
+		accessToken      string
+		serverHandler    http.Handler
+		forceServerError bool
+		wantErr          bool
+	}{
+		{
+			name:        "successful call to Easee API",
+			accessToken: test.AccessToken,
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodGet,
+				requestPath:   "/health",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusOK,
+			}),
+		},
+		{
+			name:        "response code != 200",
+			accessToken: test.AccessToken,
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodGet,
+				requestPath:   "/health",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusInternalServerError,
+			}),
+			wantErr: true,
+		},
+		{
+			name:             "http client error",
+			accessToken:      test.AccessToken,
+			forceServerError: true,
+			wantErr:          true,
+		},
+		{
+			name:    "error - access token empty",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			s := httptest.NewServer(tt.serverHandler)
+
+			t.Cleanup(func() {
+				s.Close()
+			})
+
+			if tt.forceServerError {
+				s.Close()
+			}
+
+			storage := mockedstorage.Storage[*config.Config]{}
+
+			cfgSrv := config.NewConfigServiceWithStorage(&storage)
+
+			httpClient := &http.Client{Timeout: 3 * time.Second}
+			c := api.NewHTTPClient(cfgSrv, httpClient, s.URL)
+
+			err := c.Ping(context.Background(), tt.accessToken)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_Chargers(t *testing.T) { //nolint:paralleltest
+	clock.Mock(time.Date(2022, time.September, 10, 8, 0o0, 12, 0o0, time.UTC))
+
+	t.Cleanup(func() {
+		clock.Restore()
+	})
+
+	tests := []struct {
+		name             string
 		accessToken      string
 		serverHandler    http.Handler
 		forceServerError bool
+		want             []model.Charger
 		wantErr          bool
 	}{
 		{
 			name:        "successful call to Easee API",
-			chargerID:   test.ChargerID,
 			accessToken: test.AccessToken,
-			serverHandler: newTestHandler(t, []call{
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodGet,
+				requestPath:   "/api/chargers",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusOK,
+				responseBody: `[{"id":"XX12345","name":"XX12345","color":4,"createdOn":"2021-09-22T12:01:43.299176","updatedOn":"2022-01-13T12:33:03.232669","backPlate":null,"levelOfAccess":1,"productCode":1}]`,
+			}),
+			want: []model.Charger{
 				{
-					requestMethod: http.MethodPost,
-					requestPath:   "/api/chargers/XX12345/commands/pause_charging",
-					requestHeaders: map[string]string{
-						"Authorization": "Bearer test.access.token",
-					},
-					responseCode: http.StatusAccepted,
+					ID:            test.ChargerID,
+					Name:          test.ChargerID,
+					Color:         4,
+					CreatedOn:     "2021-09-22T12:01:43.299176",
+					UpdatedOn:     "2022-01-13T12:33:03.232669",
+					BackPlate:     model.BackPlate{},
+					LevelOfAccess: 1,
+					ProductCode:   1,
 				},
-			}...),
+			},
 		},
 		{
 			name:        "response code != 200",
-			chargerID:   test.ChargerID,
 			accessToken: test.AccessToken,
 			serverHandler: newTestHandler(t, call{
-				requestMethod: http.MethodPost,
-				requestPath:   "/api/chargers/XX12345/commands/pause_charging",
+				requestMethod: http.MethodGet,
+				requestPath:   "/api/chargers",
 				requestHeaders: map[string]string{
 					"Authorization": "Bearer test.access.token",
 				},
@@ -425,16 +1202,13 @@ func TestClient_StopCharging(t *testing.T) { //nolint:paralleltest
 		},
 		{
 			name:             "http client error",
-			chargerID:        test.ChargerID,
 			accessToken:      test.AccessToken,
 			forceServerError: true,
 			wantErr:          true,
 		},
 		{
-			name:        "return error if access token is empty",
-			chargerID:   test.ChargerID,
-			accessToken: test.AccessToken,
-			wantErr:     true,
+			name:    "error - access token empty",
+			wantErr: true,
 		},
 	}
 
@@ -457,7 +1231,7 @@ func TestClient_StopCharging(t *testing.T) { //nolint:paralleltest
 			httpClient := &http.Client{Timeout: 3 * time.Second}
 			c := api.NewHTTPClient(cfgSrv, httpClient, s.URL)
 
-			err := c.StopCharging(tt.accessToken, tt.chargerID)
+			got, err := c.Chargers(context.Background(), tt.accessToken)
 			if tt.wantErr {
 				assert.Error(t, err)
 
@@ -465,11 +1239,12 @@ func TestClient_StopCharging(t *testing.T) { //nolint:paralleltest
 			}
 
 			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
-func TestClient_ChargerConfig(t *testing.T) { //nolint:paralleltest
+func TestClient_ChargerSessions(t *testing.T) { //nolint:paralleltest
 	clock.Mock(time.Date(2022, time.September, 10, 8, 0o0, 12, 0o0, time.UTC))
 
 	t.Cleanup(func() {
@@ -480,35 +1255,54 @@ func TestClient_ChargerConfig(t *testing.T) { //nolint:paralleltest
 		name             string
 		chargerID        string
 		accessToken      string
+		limit            int
+		offset           int
 		serverHandler    http.Handler
 		forceServerError bool
-		want             *model.ChargerConfig
+		want             []model.ChargerSession
 		wantErr          bool
 	}{
 		{
 			name:        "successful call to Easee API",
 			chargerID:   test.ChargerID,
 			accessToken: test.AccessToken,
+			limit:       10,
+			offset:      20,
 			serverHandler: newTestHandler(t, call{
 				requestMethod: http.MethodGet,
-				requestPath:   "/api/chargers/XX12345/config",
+				requestPath:   "/api/sessions/charger/XX12345/sessions/descending",
+				requestQuery:  "limit=10&offset=20",
 				requestHeaders: map[string]string{
 					"Authorization": "Bearer test.access.token",
 				},
 				responseCode: http.StatusOK,
-				responseBody: `{"maxChargerCurrent":32, "detectedPowerGridType":1}`,
+				responseBody: `[
+					{"sessionId":436,"carConnected":"2025-01-22T14:00:00Z","carDisconnected":null,"kiloWattHours":1.5},
+					{"sessionId":435,"carConnected":"2025-01-22T12:51:47Z","carDisconnected":"2025-01-22T13:05:38Z","kiloWattHours":0.411273}
+				]`,
 			}),
-			want: &model.ChargerConfig{
-				DetectedPowerGridType: 1,
+			want: []model.ChargerSession{
+				{
+					ID:            436,
+					CarConnected:  time.Date(2025, time.January, 22, 14, 0, 0, 0, time.UTC),
+					KiloWattHours: 1.5,
+				},
+				{
+					ID:              435,
+					CarConnected:    time.Date(2025, time.January, 22, 12, 51, 47, 0, time.UTC),
+					CarDisconnected: time.Date(2025, time.January, 22, 13, 5, 38, 0, time.UTC),
+					KiloWattHours:   0.411273,
+				},
 			},
 		},
 		{
 			name:        "response code != 200",
 			chargerID:   test.ChargerID,
 			accessToken: test.AccessToken,
+			limit:       10,
 			serverHandler: newTestHandler(t, call{
 				requestMethod: http.MethodGet,
-				requestPath:   "/api/chargers/XX12345/config",
+				requestPath:   "/api/sessions/charger/XX12345/sessions/descending",
 				requestHeaders: map[string]string{
 					"Authorization": "Bearer test.access.token",
 				},
@@ -549,7 +1343,7 @@ func TestClient_ChargerConfig(t *testing.T) { //nolint:paralleltest
 			httpClient := &http.Client{Timeout: 3 * time.Second}
 			c := api.NewHTTPClient(cfgSrv, httpClient, s.URL)
 
-			got, err := c.ChargerConfig(tt.accessToken, tt.chargerID)
+			got, err := c.ChargerSessions(context.Background(), tt.accessToken, tt.chargerID, tt.limit, tt.offset)
 			if tt.wantErr {
 				assert.Error(t, err)
 
@@ -562,18 +1356,13 @@ func TestClient_ChargerConfig(t *testing.T) { //nolint:paralleltest
 	}
 }
 
-func TestClient_Ping(t *testing.T) { //nolint:paralleltest
-	clock.Mock(time.Date(2022, time.September, 10, 8, 0o0, 12, 0o0, time.UTC))
-
-	t.Cleanup(func() {
-		clock.Restore()
-	})
-
+func TestClient_Equalizers(t *testing.T) { //nolint:paralleltest
 	tests := []struct {
 		name             string
 		accessToken      string
 		serverHandler    http.Handler
 		forceServerError bool
+		want             []model.Equalizer
 		wantErr          bool
 	}{
 		{
@@ -581,19 +1370,42 @@ func TestClient_Ping(t *testing.T) { //nolint:paralleltest
 			accessToken: test.AccessToken,
 			serverHandler: newTestHandler(t, call{
 				requestMethod: http.MethodGet,
-				requestPath:   "/health",
+				requestPath:   "/api/equalizers",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusOK,
+				responseBody: `[{"id":"QH12345","name":"Main meter","siteId":123,"circuitId":456}]`,
+			}),
+			want: []model.Equalizer{
+				{
+					ID:        "QH12345",
+					Name:      "Main meter",
+					SiteID:    123,
+					CircuitID: 456,
+				},
+			},
+		},
+		{
+			name:        "account without equalizers",
+			accessToken: test.AccessToken,
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodGet,
+				requestPath:   "/api/equalizers",
 				requestHeaders: map[string]string{
 					"Authorization": "Bearer test.access.token",
 				},
 				responseCode: http.StatusOK,
+				responseBody: `[]`,
 			}),
+			want: []model.Equalizer{},
 		},
 		{
 			name:        "response code != 200",
 			accessToken: test.AccessToken,
 			serverHandler: newTestHandler(t, call{
 				requestMethod: http.MethodGet,
-				requestPath:   "/health",
+				requestPath:   "/api/equalizers",
 				requestHeaders: map[string]string{
 					"Authorization": "Bearer test.access.token",
 				},
@@ -607,10 +1419,98 @@ func TestClient_Ping(t *testing.T) { //nolint:paralleltest
 			forceServerError: true,
 			wantErr:          true,
 		},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			s := httptest.NewServer(tt.serverHandler)
+
+			t.Cleanup(func() {
+				s.Close()
+			})
+
+			if tt.forceServerError {
+				s.Close()
+			}
+
+			storage := mockedstorage.Storage[*config.Config]{}
+
+			cfgSrv := config.NewConfigServiceWithStorage(&storage)
+
+			httpClient := &http.Client{Timeout: 3 * time.Second}
+			c := api.NewHTTPClient(cfgSrv, httpClient, s.URL)
+
+			got, err := c.Equalizers(context.Background(), tt.accessToken)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_ChargerSiteInfo(t *testing.T) { //nolint:paralleltest
+	tests := []struct {
+		name             string
+		chargerID        string
+		accessToken      string
+		serverHandler    http.Handler
+		forceServerError bool
+		want             *model.ChargerSiteInfo
+		wantErr          bool
+	}{
 		{
-			name:    "error - access token empty",
+			name:        "successful call to Easee API",
+			chargerID:   test.ChargerID,
+			accessToken: test.AccessToken,
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodGet,
+				requestPath:   "/api/chargers/XX12345/site",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusOK,
+				responseBody: `{"id":123,"ratedCurrent":40,"circuits":[{"id":456,"siteId":123,"panelName":"1","ratedCurrent":25,"chargers":[{"id":"XX12345"}]}]}`,
+			}),
+			want: &model.ChargerSiteInfo{
+				ID:           123,
+				RatedCurrent: 40,
+				Circuits: []model.Circuit{
+					{
+						ID:           456,
+						SiteID:       123,
+						PanelName:    "1",
+						RatedCurrent: 25,
+						Chargers:     []model.Charger{{ID: test.ChargerID}},
+					},
+				},
+			},
+		},
+		{
+			name:        "response code != 200",
+			chargerID:   test.ChargerID,
+			accessToken: test.AccessToken,
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodGet,
+				requestPath:   "/api/chargers/XX12345/site",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusInternalServerError,
+			}),
 			wantErr: true,
 		},
+		{
+			name:             "http client error",
+			chargerID:        test.ChargerID,
+			accessToken:      test.AccessToken,
+			forceServerError: true,
+			wantErr:          true,
+		},
 	}
 
 	for _, tt := range tests { //nolint:paralleltest
@@ -632,7 +1532,7 @@ func TestClient_Ping(t *testing.T) { //nolint:paralleltest
 			httpClient := &http.Client{Timeout: 3 * time.Second}
 			c := api.NewHTTPClient(cfgSrv, httpClient, s.URL)
 
-			err := c.Ping(tt.accessToken)
+			got, err := c.ChargerSiteInfo(context.Background(), tt.accessToken, tt.chargerID)
 			if tt.wantErr {
 				assert.Error(t, err)
 
@@ -640,23 +1540,18 @@ func TestClient_Ping(t *testing.T) { //nolint:paralleltest
 			}
 
 			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
-func TestClient_Chargers(t *testing.T) { //nolint:paralleltest
-	clock.Mock(time.Date(2022, time.September, 10, 8, 0o0, 12, 0o0, time.UTC))
-
-	t.Cleanup(func() {
-		clock.Restore()
-	})
-
+func TestClient_CircuitDynamicCurrent(t *testing.T) { //nolint:paralleltest
 	tests := []struct {
 		name             string
 		accessToken      string
 		serverHandler    http.Handler
 		forceServerError bool
-		want             []model.Charger
+		want             *model.CircuitCurrent
 		wantErr          bool
 	}{
 		{
@@ -664,32 +1559,35 @@ func TestClient_Chargers(t *testing.T) { //nolint:paralleltest
 			accessToken: test.AccessToken,
 			serverHandler: newTestHandler(t, call{
 				requestMethod: http.MethodGet,
-				requestPath:   "/api/chargers",
+				requestPath:   "/api/sites/123/circuits/456/dynamicCurrent",
 				requestHeaders: map[string]string{
 					"Authorization": "Bearer test.access.token",
 				},
 				responseCode: http.StatusOK,
-				responseBody: `[{"id":"XX12345","name":"XX12345","color":4,"createdOn":"2021-09-22T12:01:43.299176","updatedOn":"2022-01-13T12:33:03.232669","backPlate":null,"levelOfAccess":1,"productCode":1}]`,
+				responseBody: `{"phase1":16,"phase2":16,"phase3":10}`,
 			}),
-			want: []model.Charger{
-				{
-					ID:            test.ChargerID,
-					Name:          test.ChargerID,
-					Color:         4,
-					CreatedOn:     "2021-09-22T12:01:43.299176",
-					UpdatedOn:     "2022-01-13T12:33:03.232669",
-					BackPlate:     model.BackPlate{},
-					LevelOfAccess: 1,
-					ProductCode:   1,
+			want: &model.CircuitCurrent{Phase1: 16, Phase2: 16, Phase3: 10},
+		},
+		{
+			name:        "zero current is a valid response",
+			accessToken: test.AccessToken,
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodGet,
+				requestPath:   "/api/sites/123/circuits/456/dynamicCurrent",
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
 				},
-			},
+				responseCode: http.StatusOK,
+				responseBody: `{"phase1":0,"phase2":0,"phase3":0}`,
+			}),
+			want: &model.CircuitCurrent{},
 		},
 		{
 			name:        "response code != 200",
 			accessToken: test.AccessToken,
 			serverHandler: newTestHandler(t, call{
 				requestMethod: http.MethodGet,
-				requestPath:   "/api/chargers",
+				requestPath:   "/api/sites/123/circuits/456/dynamicCurrent",
 				requestHeaders: map[string]string{
 					"Authorization": "Bearer test.access.token",
 				},
@@ -703,10 +1601,84 @@ func TestClient_Chargers(t *testing.T) { //nolint:paralleltest
 			forceServerError: true,
 			wantErr:          true,
 		},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			s := httptest.NewServer(tt.serverHandler)
+
+			t.Cleanup(func() {
+				s.Close()
+			})
+
+			if tt.forceServerError {
+				s.Close()
+			}
+
+			storage := mockedstorage.Storage[*config.Config]{}
+
+			cfgSrv := config.NewConfigServiceWithStorage(&storage)
+
+			httpClient := &http.Client{Timeout: 3 * time.Second}
+			c := api.NewHTTPClient(cfgSrv, httpClient, s.URL)
+
+			got, err := c.CircuitDynamicCurrent(context.Background(), tt.accessToken, 123, 456)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_UpdateCircuitDynamicCurrent(t *testing.T) { //nolint:paralleltest
+	tests := []struct {
+		name             string
+		accessToken      string
+		serverHandler    http.Handler
+		forceServerError bool
+		current          model.CircuitCurrent
+		wantErr          bool
+	}{
+		{
+			name:        "successful call to Easee API",
+			accessToken: test.AccessToken,
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodPost,
+				requestPath:   "/api/sites/123/circuits/456/dynamicCurrent",
+				requestBody:   `{"phase1":16,"phase2":16,"phase3":10}`,
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusAccepted,
+			}),
+			current: model.CircuitCurrent{Phase1: 16, Phase2: 16, Phase3: 10},
+		},
 		{
-			name:    "error - access token empty",
+			name:        "response code != 200",
+			accessToken: test.AccessToken,
+			serverHandler: newTestHandler(t, call{
+				requestMethod: http.MethodPost,
+				requestPath:   "/api/sites/123/circuits/456/dynamicCurrent",
+				requestBody:   `{"phase1":16,"phase2":16,"phase3":10}`,
+				requestHeaders: map[string]string{
+					"Authorization": "Bearer test.access.token",
+				},
+				responseCode: http.StatusInternalServerError,
+			}),
+			current: model.CircuitCurrent{Phase1: 16, Phase2: 16, Phase3: 10},
 			wantErr: true,
 		},
+		{
+			name:             "http client error",
+			accessToken:      test.AccessToken,
+			forceServerError: true,
+			wantErr:          true,
+		},
 	}
 
 	for _, tt := range tests { //nolint:paralleltest
@@ -728,7 +1700,7 @@ func TestClient_Chargers(t *testing.T) { //nolint:paralleltest
 			httpClient := &http.Client{Timeout: 3 * time.Second}
 			c := api.NewHTTPClient(cfgSrv, httpClient, s.URL)
 
-			got, err := c.Chargers(tt.accessToken)
+			err := c.UpdateCircuitDynamicCurrent(context.Background(), tt.accessToken, 123, 456, tt.current)
 			if tt.wantErr {
 				assert.Error(t, err)
 
@@ -736,7 +1708,6 @@ func TestClient_Chargers(t *testing.T) { //nolint:paralleltest
 			}
 
 			assert.NoError(t, err)
-			assert.Equal(t, tt.want, got)
 		})
 	}
 }
@@ -744,6 +1715,7 @@ func TestClient_Chargers(t *testing.T) { //nolint:paralleltest
 type call struct {
 	requestMethod  string
 	requestPath    string
+	requestQuery   string
 	requestHeaders map[string]string
 	requestBody    string
 
@@ -778,6 +1750,10 @@ func (t *testHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		t.testingT.Fatalf("request path mismatch: want: %s, got: %s", call.requestPath, r.URL.Path)
 	}
 
+	if call.requestQuery != "" && r.URL.RawQuery != call.requestQuery {
+		t.testingT.Fatalf("request query mismatch: want: %s, got: %s", call.requestQuery, r.URL.RawQuery)
+	}
+
 	if len(call.requestHeaders) != 0 {
 		for k, v := range call.requestHeaders {
 			got := r.Header.Get(k)
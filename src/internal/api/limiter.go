@@ -0,0 +1,327 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/michalkurzeja/go-clock"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/config"
+)
+
+// EndpointClass represents a class of Easee API endpoints sharing a rate limit.
+type EndpointClass string
+
+// Classes of Easee API endpoints.
+const (
+	// EndpointClassCommands covers charger commands, e.g. stopping charging or rebooting, and authentication.
+	EndpointClassCommands EndpointClass = "commands"
+	// EndpointClassSettings covers updates of charger and circuit settings.
+	EndpointClassSettings EndpointClass = "settings"
+	// EndpointClassReads covers requests reading data.
+	EndpointClassReads EndpointClass = "reads"
+)
+
+// endpointClassKey is a request context key assigning the request to an endpoint class.
+type endpointClassKey struct{}
+
+// RateLimitStats represents throttling statistics of an endpoint class.
+type RateLimitStats struct {
+	// Requests is the number of requests sent to the Easee API.
+	Requests uint64 `json:"requests"`
+	// Throttled is the number of requests delayed due to the rate limit.
+	Throttled uint64 `json:"throttled"`
+	// Coalesced is the number of requests served with a response to an identical request in flight.
+	Coalesced uint64 `json:"coalesced"`
+	// WaitSeconds is the total time requests were delayed for.
+	WaitSeconds float64 `json:"waitSeconds"`
+}
+
+// RateLimiter is an http.RoundTripper limiting the rate of requests to the Easee API.
+// Limits are applied per endpoint class and shared by all chargers of the account.
+type RateLimiter interface {
+	http.RoundTripper
+
+	// Stats returns throttling statistics of all endpoint classes.
+	Stats() map[EndpointClass]RateLimitStats
+}
+
+type rateLimiter struct {
+	transport http.RoundTripper
+	cfgSrv    *config.Service
+
+	mu       sync.Mutex
+	buckets  map[EndpointClass]*bucket
+	stats    map[EndpointClass]RateLimitStats
+	inFlight map[string]*inFlightRead
+}
+
+// bucket represents the state of a token bucket.
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// inFlightRead represents a read request awaited by identical requests.
+type inFlightRead struct {
+	done chan struct{}
+	resp *snapshot
+	err  error
+	// cancelled is true if the request failed because the context of its caller is done.
+	cancelled bool
+}
+
+// snapshot represents a response which can be copied for every coalesced request.
+type snapshot struct {
+	resp *http.Response
+	body []byte
+}
+
+// NewRateLimiter returns a new instance of RateLimiter. Requests wait in a queue until a request to the endpoint class is allowed,
+// while identical read requests sent at the same time are coalesced into a single request. The HTTP timeout is applied to every request
+// once it is allowed to be sent.
+func NewRateLimiter(cfgSrv *config.Service, transport http.RoundTripper) RateLimiter {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &rateLimiter{
+		transport: transport,
+		cfgSrv:    cfgSrv,
+		buckets:   make(map[EndpointClass]*bucket),
+		stats:     make(map[EndpointClass]RateLimitStats),
+		inFlight:  make(map[string]*inFlightRead),
+	}
+}
+
+func (l *rateLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	class := l.classOf(req)
+
+	if class != EndpointClassReads || req.Method != http.MethodGet {
+		return l.send(req, class)
+	}
+
+	key := req.URL.String() + " " + req.Header.Get(authorizationHeader)
+
+	for {
+		l.mu.Lock()
+
+		read, ok := l.inFlight[key]
+		if !ok {
+			break
+		}
+
+		l.record(class, func(s *RateLimitStats) { s.Coalesced++ })
+		l.mu.Unlock()
+
+		select {
+		case <-read.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+
+		// The identical request has been cancelled by its own caller, so the request is sent again or joins another one.
+		if read.cancelled {
+			continue
+		}
+
+		if read.err != nil {
+			return nil, read.err
+		}
+
+		return read.resp.copy(req), nil
+	}
+
+	read := &inFlightRead{done: make(chan struct{})}
+	l.inFlight[key] = read
+
+	l.mu.Unlock()
+
+	defer func() {
+		l.mu.Lock()
+		delete(l.inFlight, key)
+		l.mu.Unlock()
+
+		close(read.done)
+	}()
+
+	resp, err := l.send(req, class)
+	if err != nil {
+		read.err = err
+		read.cancelled = req.Context().Err() != nil
+
+		return nil, err
+	}
+
+	read.resp, read.err = newSnapshot(resp)
+	if read.err != nil {
+		read.cancelled = req.Context().Err() != nil
+
+		return nil, read.err
+	}
+
+	return read.resp.copy(req), nil
+}
+
+func (l *rateLimiter) Stats() map[EndpointClass]RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := make(map[EndpointClass]RateLimitStats, len(l.stats))
+	for class, s := range l.stats {
+		stats[class] = s
+	}
+
+	return stats
+}
+
+// send waits until the request to the endpoint class is allowed and sends it.
+// The HTTP timeout starts only once the request is allowed, so requests are not failed just for waiting in the queue.
+func (l *rateLimiter) send(req *http.Request, class EndpointClass) (*http.Response, error) {
+	delay := l.reserve(class)
+	if delay > 0 {
+		log.WithField("class", class).
+			WithField("delay", delay).
+			Debugf("rate limit reached, delaying %s %s", req.Method, req.URL.String())
+
+		select {
+		case <-req.Context().Done():
+			l.release(class)
+
+			return nil, req.Context().Err()
+		case <-clock.After(delay):
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), l.cfgSrv.GetHTTPTimeout())
+
+	resp, err := l.transport.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	// The body is read after the request is sent, so the timeout is cancelled once it is closed.
+	resp.Body = &cancelingBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// reserve takes a token from the bucket of the endpoint class and returns the time after which the request is allowed.
+// Tokens are allowed to go below zero, so requests are queued in the order of reservation.
+func (l *rateLimiter) reserve(class EndpointClass) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.record(class, func(s *RateLimitStats) { s.Requests++ })
+
+	cfg := l.cfgSrv.GetRateLimitsCfg().Class(string(class))
+	if cfg.RequestsPerMinute <= 0 {
+		return 0
+	}
+
+	b := l.refill(class, cfg)
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	delay := time.Duration(-b.tokens / cfg.RequestsPerMinute * float64(time.Minute))
+
+	l.record(class, func(s *RateLimitStats) {
+		s.Throttled++
+		s.WaitSeconds += delay.Seconds()
+	})
+
+	return delay
+}
+
+// release returns the token reserved by the request which has not been sent.
+func (l *rateLimiter) release(class EndpointClass) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.buckets[class]; ok {
+		b.tokens++
+	}
+}
+
+// refill returns the bucket of the endpoint class with tokens added for the time elapsed since the last update.
+func (l *rateLimiter) refill(class EndpointClass, cfg config.RateLimitCfg) *bucket {
+	now := clock.Now()
+	burst := float64(max(cfg.Burst, 1))
+
+	b, ok := l.buckets[class]
+	if !ok {
+		b = &bucket{tokens: burst, updatedAt: now}
+		l.buckets[class] = b
+
+		return b
+	}
+
+	b.tokens = min(b.tokens+now.Sub(b.updatedAt).Minutes()*cfg.RequestsPerMinute, burst)
+	b.updatedAt = now
+
+	return b
+}
+
+// record updates statistics of the endpoint class. Must be called with the lock held.
+func (l *rateLimiter) record(class EndpointClass, update func(s *RateLimitStats)) {
+	s := l.stats[class]
+	update(&s)
+	l.stats[class] = s
+}
+
+// classOf returns the endpoint class assigned to the request by the request builder, or derived from its method otherwise.
+func (l *rateLimiter) classOf(req *http.Request) EndpointClass {
+	if class, ok := req.Context().Value(endpointClassKey{}).(EndpointClass); ok {
+		return class
+	}
+
+	if req.Method == http.MethodGet {
+		return EndpointClassReads
+	}
+
+	return EndpointClassCommands
+}
+
+// cancelingBody is a response body releasing the timeout of the request once closed.
+type cancelingBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelingBody) Close() error {
+	defer b.cancel()
+
+	return b.ReadCloser.Close()
+}
+
+// newSnapshot reads and closes the body of the response, so it can be copied for every coalesced request.
+func newSnapshot(resp *http.Response) (*snapshot, error) {
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshot{resp: resp, body: body}, nil
+}
+
+// copy returns a copy of the response to the provided request.
+func (s *snapshot) copy(req *http.Request) *http.Response {
+	resp := *s.resp
+	resp.Header = s.resp.Header.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(s.body))
+	resp.Request = req
+
+	return &resp
+}
@@ -0,0 +1,292 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mockedstorage "github.com/futurehomeno/cliffhanger/test/mocks/storage"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/api"
+	"github.com/futurehomeno/edge-easee-adapter/internal/config"
+	"github.com/futurehomeno/edge-easee-adapter/internal/test"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	limits := config.RateLimitsCfg{
+		Commands: config.RateLimitCfg{RequestsPerMinute: 600, Burst: 1},
+		Settings: config.RateLimitCfg{RequestsPerMinute: 600, Burst: 2},
+		Reads:    config.RateLimitCfg{RequestsPerMinute: 0},
+	}
+
+	tests := []struct {
+		name      string
+		requests  []func(c api.HTTPClient) error
+		wantCalls int32
+		wantStats map[api.EndpointClass]api.RateLimitStats
+	}{
+		{
+			name: "requests within the burst are not throttled",
+			requests: []func(c api.HTTPClient) error{
+				func(c api.HTTPClient) error {
+					return c.UpdateMaxCurrent(context.Background(), test.AccessToken, test.ChargerID, 16)
+				},
+				func(c api.HTTPClient) error {
+					return c.UpdatePhaseMode(context.Background(), test.AccessToken, test.ChargerID, 2)
+				},
+				func(c api.HTTPClient) error {
+					return c.StopCharging(context.Background(), test.AccessToken, test.ChargerID)
+				},
+			},
+			wantCalls: 3,
+			wantStats: map[api.EndpointClass]api.RateLimitStats{
+				api.EndpointClassSettings: {Requests: 2},
+				api.EndpointClassCommands: {Requests: 1},
+			},
+		},
+		{
+			name: "requests exceeding the burst are delayed",
+			requests: []func(c api.HTTPClient) error{
+				func(c api.HTTPClient) error {
+					return c.StopCharging(context.Background(), test.AccessToken, test.ChargerID)
+				},
+				func(c api.HTTPClient) error {
+					return c.StopCharging(context.Background(), test.AccessToken, test.ChargerID)
+				},
+			},
+			wantCalls: 2,
+			wantStats: map[api.EndpointClass]api.RateLimitStats{
+				api.EndpointClassCommands: {Requests: 2, Throttled: 1, WaitSeconds: 0.1},
+			},
+		},
+		{
+			name: "disabled limit",
+			requests: []func(c api.HTTPClient) error{
+				func(c api.HTTPClient) error { return c.Ping(context.Background(), test.AccessToken) },
+				func(c api.HTTPClient) error { return c.Ping(context.Background(), test.AccessToken) },
+				func(c api.HTTPClient) error { return c.Ping(context.Background(), test.AccessToken) },
+			},
+			wantCalls: 3,
+			wantStats: map[api.EndpointClass]api.RateLimitStats{
+				api.EndpointClassReads: {Requests: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+
+					return
+				}
+
+				w.WriteHeader(http.StatusAccepted)
+			}))
+
+			t.Cleanup(func() {
+				s.Close()
+			})
+
+			limiter := newTestRateLimiter(t, limits)
+			c := api.NewHTTPClient(nil, &http.Client{Timeout: 3 * time.Second, Transport: limiter}, s.URL)
+
+			for _, request := range tt.requests {
+				assert.NoError(t, request(c))
+			}
+
+			assert.Equal(t, tt.wantCalls, calls.Load())
+
+			stats := limiter.Stats()
+			for class, want := range tt.wantStats {
+				assert.Equal(t, want.Requests, stats[class].Requests, class)
+				assert.Equal(t, want.Throttled, stats[class].Throttled, class)
+				assert.InDelta(t, want.WaitSeconds, stats[class].WaitSeconds, 0.01, class)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_Coalescing(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	release := make(chan struct{})
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+
+		<-release
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Cleanup(func() {
+		s.Close()
+	})
+
+	limiter := newTestRateLimiter(t, config.RateLimitsCfg{})
+	c := api.NewHTTPClient(nil, &http.Client{Timeout: 3 * time.Second, Transport: limiter}, s.URL)
+
+	wg := sync.WaitGroup{}
+
+	for range 3 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, c.Ping(context.Background(), test.AccessToken))
+		}()
+	}
+
+	assert.Eventually(t, func() bool {
+		return limiter.Stats()[api.EndpointClassReads].Coalesced == 2
+	}, time.Second, 10*time.Millisecond)
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, uint64(1), limiter.Stats()[api.EndpointClassReads].Requests)
+}
+
+func TestRateLimiter_CoalescingCancellation(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	release := make(chan struct{})
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// The first request is held until its caller gives up.
+		if calls.Add(1) == 1 {
+			<-release
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Cleanup(func() {
+		s.Close()
+	})
+
+	limiter := newTestRateLimiter(t, config.RateLimitsCfg{})
+	c := api.NewHTTPClient(nil, &http.Client{Timeout: 3 * time.Second, Transport: limiter}, s.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	leaderErr := make(chan error, 1)
+
+	go func() {
+		leaderErr <- c.Ping(ctx, test.AccessToken)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return calls.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	followerErr := make(chan error, 1)
+
+	go func() {
+		followerErr <- c.Ping(context.Background(), test.AccessToken)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return limiter.Stats()[api.EndpointClassReads].Coalesced == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// Cancellation of the first request does not fail the identical request, which is sent again instead.
+	cancel()
+
+	assert.ErrorIs(t, <-leaderErr, context.Canceled)
+	assert.NoError(t, <-followerErr)
+	assert.Equal(t, int32(2), calls.Load())
+
+	close(release)
+}
+
+func TestRateLimiter_Cancellation(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	t.Cleanup(func() {
+		s.Close()
+	})
+
+	limiter := newTestRateLimiter(t, config.RateLimitsCfg{
+		Commands: config.RateLimitCfg{RequestsPerMinute: 1, Burst: 1},
+	})
+	c := api.NewHTTPClient(nil, &http.Client{Timeout: 3 * time.Second, Transport: limiter}, s.URL)
+
+	assert.NoError(t, c.StopCharging(context.Background(), test.AccessToken, test.ChargerID))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := c.StopCharging(ctx, test.AccessToken, test.ChargerID)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRateLimiter_Timeout(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			time.Sleep(200 * time.Millisecond)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	t.Cleanup(func() {
+		s.Close()
+	})
+
+	storage := mockedstorage.Storage[*config.Config]{}
+	storage.On("Model").Return(&config.Config{
+		HTTPTimeout: "100ms",
+		RateLimits: &config.RateLimitsCfg{
+			Commands: config.RateLimitCfg{RequestsPerMinute: 300, Burst: 1},
+		},
+	})
+
+	limiter := api.NewRateLimiter(config.NewConfigServiceWithStorage(&storage), nil)
+	c := api.NewHTTPClient(nil, &http.Client{Transport: limiter}, s.URL)
+
+	assert.NoError(t, c.StopCharging(context.Background(), test.AccessToken, test.ChargerID))
+
+	// The second request waits longer than the timeout for the rate limit, but is answered in time once sent.
+	assert.NoError(t, c.StopCharging(context.Background(), test.AccessToken, test.ChargerID))
+	assert.Equal(t, uint64(1), limiter.Stats()[api.EndpointClassCommands].Throttled)
+
+	err := c.Ping(context.Background(), test.AccessToken)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func newTestRateLimiter(t *testing.T, limits config.RateLimitsCfg) api.RateLimiter {
+	t.Helper()
+
+	storage := mockedstorage.Storage[*config.Config]{}
+	storage.On("Model").Return(&config.Config{RateLimits: &limits})
+
+	return api.NewRateLimiter(config.NewConfigServiceWithStorage(&storage), nil)
+}
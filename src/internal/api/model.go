@@ -29,7 +29,24 @@ type dynamicCurrentBody struct {
 	DynamicChargerCurrent float64 `json:"dynamicChargerCurrent"`
 }
 
+// phaseModeBody represents a charger phase mode request body.
+type phaseModeBody struct {
+	PhaseMode int `json:"phaseMode"`
+}
+
 // cableLockStateBody represents a charger cable lock state request body.
 type cableLockStateBody struct {
 	State bool `json:"state"`
 }
+
+// authorizationRequiredBody represents a charger authorization required request body.
+type authorizationRequiredBody struct {
+	AuthorizationRequired bool `json:"authorizationRequired"`
+}
+
+// circuitDynamicCurrentBody represents a circuit dynamic current request body.
+type circuitDynamicCurrentBody struct {
+	Phase1 float64 `json:"phase1"`
+	Phase2 float64 `json:"phase2"`
+	Phase3 float64 `json:"phase3"`
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/futurehomeno/cliffhanger/backoff"
+	"github.com/michalkurzeja/go-clock"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/config"
+)
+
+const retryAfterHeader = "Retry-After"
+
+// retryableKey is a request context key marking requests safe to be retried.
+type retryableKey struct{}
+
+type retryTransport struct {
+	transport http.RoundTripper
+	cfgSrv    *config.Service
+}
+
+// NewRetryTransport returns a new instance of http.RoundTripper retrying requests failed due to network errors,
+// server errors or rate limiting. Only GET requests and requests marked as retryable by the request builder are retried.
+func NewRetryTransport(cfgSrv *config.Service, transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &retryTransport{
+		transport: transport,
+		cfgSrv:    cfgSrv,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.isRetryable(req) {
+		return t.transport.RoundTrip(req)
+	}
+
+	cfg := t.cfgSrv.GetHTTPRetryCfg()
+	strategy := backoff.New(cfg.InitialBackoff, cfg.RepeatedBackoff, cfg.FinalBackoff, cfg.InitialFailureCount, cfg.RepeatedFailureCount)
+
+	for attempt := uint32(1); ; attempt++ {
+		attemptReq, err := t.rewind(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.transport.RoundTrip(attemptReq)
+		if attempt >= cfg.MaxAttempts || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := strategy.Delay(attempt)
+
+		if retryAfter, ok := t.retryAfter(resp); ok {
+			// Waiting longer than the backoff allows would block the caller for too long, so the failure is returned instead.
+			if retryAfter > cfg.FinalBackoff {
+				return resp, nil
+			}
+
+			delay = retryAfter
+		}
+
+		t.logRetry(req, resp, err, attempt, delay)
+		t.discard(resp)
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-clock.After(delay):
+		}
+	}
+}
+
+// isRetryable checks if the request can be safely repeated.
+func (t *retryTransport) isRetryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if req.Method == http.MethodGet {
+		return true
+	}
+
+	retryable, _ := req.Context().Value(retryableKey{}).(bool)
+
+	return retryable
+}
+
+// rewind returns the request to be sent in the provided attempt, with the body reset for repeated attempts.
+func (t *retryTransport) rewind(req *http.Request, attempt uint32) (*http.Request, error) {
+	if attempt == 1 || req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = body
+
+	return clone, nil
+}
+
+// shouldRetry checks if the request failed due to a transient error.
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// retryAfter returns the delay requested by the server with the Retry-After header, either in seconds or as a date.
+func (t *retryTransport) retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get(retryAfterHeader)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(date.Sub(clock.Now()), 0), true
+}
+
+func (t *retryTransport) logRetry(req *http.Request, resp *http.Response, err error, attempt uint32, delay time.Duration) {
+	logger := log.WithField("attempt", attempt).WithField("delay", delay)
+
+	if err != nil {
+		logger.WithError(err).Warnf("%s %s failed, retrying", req.Method, req.URL.String())
+
+		return
+	}
+
+	logger.Warnf("%s %s resulted in %s, retrying", req.Method, req.URL.String(), resp.Status)
+}
+
+// discard drains and closes the body of the response which is not returned to the caller, so the connection can be reused.
+func (t *retryTransport) discard(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
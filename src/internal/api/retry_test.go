@@ -0,0 +1,195 @@
+package api_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mockedstorage "github.com/futurehomeno/cliffhanger/test/mocks/storage"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/api"
+	"github.com/futurehomeno/edge-easee-adapter/internal/config"
+	"github.com/futurehomeno/edge-easee-adapter/internal/test"
+)
+
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		responses  []int
+		retryAfter string
+		request    func(c api.HTTPClient) error
+		wantCalls  int32
+		wantErr    bool
+		wantBodies []string
+		// unsetAttempts leaves the max attempts unset, so the default is applied.
+		unsetAttempts bool
+	}{
+		{
+			name:      "GET request retried after a server error",
+			responses: []int{http.StatusBadGateway, http.StatusOK},
+			request: func(c api.HTTPClient) error {
+				return c.Ping(context.Background(), test.AccessToken)
+			},
+			wantCalls: 2,
+		},
+		{
+			name:       "retryable POST request retried after rate limiting with the same body",
+			responses:  []int{http.StatusTooManyRequests, http.StatusAccepted},
+			retryAfter: "0",
+			request: func(c api.HTTPClient) error {
+				return c.UpdatePhaseMode(context.Background(), test.AccessToken, test.ChargerID, 3)
+			},
+			wantCalls:  2,
+			wantBodies: []string{`{"phaseMode":3}`, `{"phaseMode":3}`},
+		},
+		{
+			name:      "command is not retried",
+			responses: []int{http.StatusInternalServerError},
+			request: func(c api.HTTPClient) error {
+				return c.RebootCharger(context.Background(), test.AccessToken, test.ChargerID)
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "dynamic current is not retried",
+			responses: []int{http.StatusBadGateway},
+			request: func(c api.HTTPClient) error {
+				return c.UpdateDynamicCurrent(context.Background(), test.AccessToken, test.ChargerID, 16)
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "login is not retried",
+			responses: []int{http.StatusBadGateway},
+			request: func(c api.HTTPClient) error {
+				_, err := c.Login(context.Background(), "user", "password")
+
+				return err
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "default attempts applied to unset config",
+			responses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			request: func(c api.HTTPClient) error {
+				return c.Ping(context.Background(), test.AccessToken)
+			},
+			wantCalls:     3,
+			unsetAttempts: true,
+		},
+		{
+			name:      "client error is not retried",
+			responses: []int{http.StatusBadRequest},
+			request: func(c api.HTTPClient) error {
+				return c.Ping(context.Background(), test.AccessToken)
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "attempts exhausted",
+			responses: []int{http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusBadGateway},
+			request: func(c api.HTTPClient) error {
+				return c.Ping(context.Background(), test.AccessToken)
+			},
+			wantCalls: 3,
+			wantErr:   true,
+		},
+		{
+			name:       "retry after exceeding the backoff",
+			responses:  []int{http.StatusTooManyRequests},
+			retryAfter: "120",
+			request: func(c api.HTTPClient) error {
+				return c.Ping(context.Background(), test.AccessToken)
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+
+			var bodies []string
+
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := calls.Add(1)
+				if int(call) > len(tt.responses) {
+					t.Errorf("unexpected call: %d", call)
+
+					return
+				}
+
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+
+				bodies = append(bodies, string(body))
+
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+
+				w.WriteHeader(tt.responses[call-1])
+			}))
+
+			t.Cleanup(func() {
+				s.Close()
+			})
+
+			cfg := config.Config{}
+			storage := mockedstorage.Storage[*config.Config]{}
+			storage.On("Model").Return(&cfg)
+			storage.On("Save").Return(nil)
+
+			cfgSrv := config.NewConfigServiceWithStorage(&storage)
+
+			retryCfg := config.HTTPRetryCfg{
+				BackoffCfg: config.BackoffCfg{
+					InitialBackoff:  time.Millisecond,
+					RepeatedBackoff: time.Millisecond,
+					FinalBackoff:    10 * time.Millisecond,
+				},
+				MaxAttempts: 3,
+			}
+
+			if tt.unsetAttempts {
+				retryCfg.MaxAttempts = 0
+			}
+
+			err := cfgSrv.SetHTTPRetryCfg(retryCfg)
+			assert.NoError(t, err)
+
+			httpClient := &http.Client{
+				Timeout:   3 * time.Second,
+				Transport: api.NewRetryTransport(cfgSrv, nil),
+			}
+			c := api.NewHTTPClient(cfgSrv, httpClient, s.URL)
+
+			err = tt.request(c)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantCalls, calls.Load())
+
+			if tt.wantBodies != nil {
+				assert.Equal(t, tt.wantBodies, bodies)
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package app
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/futurehomeno/cliffhanger/adapter"
 	cliffApp "github.com/futurehomeno/cliffhanger/app"
@@ -13,6 +16,7 @@ import (
 	"github.com/futurehomeno/edge-easee-adapter/internal/api"
 	"github.com/futurehomeno/edge-easee-adapter/internal/config"
 	"github.com/futurehomeno/edge-easee-adapter/internal/easee"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
 	"github.com/futurehomeno/edge-easee-adapter/internal/signalr"
 )
 
@@ -94,7 +98,9 @@ func (a *application) Uninstall() error {
 func (a *application) Login(credentials *cliffApp.LoginCredentials) error {
 	defer a.Check() //nolint:errcheck
 
-	if err := a.auth.Login(credentials.Username, credentials.Password); err != nil {
+	ctx := context.Background()
+
+	if err := a.auth.Login(ctx, credentials.Username, credentials.Password); err != nil {
 		a.lifecycle.SetAppState(lifecycle.AppStateNotConfigured, nil)
 		a.lifecycle.SetAuthState(lifecycle.AuthStateNotAuthenticated)
 		a.lifecycle.SetConfigState(lifecycle.ConfigStateNotConfigured)
@@ -102,7 +108,7 @@ func (a *application) Login(credentials *cliffApp.LoginCredentials) error {
 		return errors.Wrap(err, fmt.Sprintf("failed to login as '%s'", credentials.Username))
 	}
 
-	if err := a.registerChargers(); err != nil {
+	if err := a.registerChargers(ctx); err != nil {
 		a.lifecycle.SetAppState(lifecycle.AppStateNotConfigured, nil)
 		a.lifecycle.SetAuthState(lifecycle.AuthStateNotAuthenticated)
 		a.lifecycle.SetConfigState(lifecycle.ConfigStateNotConfigured)
@@ -118,7 +124,7 @@ func (a *application) Login(credentials *cliffApp.LoginCredentials) error {
 }
 
 func (a *application) Check() error {
-	if err := a.client.Ping(); err != nil {
+	if err := a.client.Ping(context.Background()); err != nil {
 		a.lifecycle.SetConnectionState(lifecycle.ConnStateDisconnected)
 
 		return nil //nolint:nilerr
@@ -160,6 +166,8 @@ func (a *application) Logout() error {
 		log.WithError(err).Warn("logout: failed to disconnect signalR client")
 	}
 
+	a.client.CancelRequests()
+
 	if err := a.auth.Logout(); err != nil {
 		a.lifecycle.SetAppState(lifecycle.AppStateError, nil)
 		a.lifecycle.SetAuthState(lifecycle.AuthStateNotAuthenticated)
@@ -177,16 +185,17 @@ func (a *application) Logout() error {
 	return nil
 }
 
-func (a *application) registerChargers() error {
-	chargers, err := a.client.Chargers()
+func (a *application) registerChargers(ctx context.Context) error {
+	chargers, err := a.client.Chargers(ctx)
 	if err != nil {
 		return errors.Wrap(err, "failed to fetch available chargers from Easee API")
 	}
 
 	seeds := make([]*adapter.ThingSeed, 0, len(chargers))
+	circuits := make(map[int64]model.Circuit)
 
 	for _, charger := range chargers {
-		chargerDetails, err := a.client.ChargerDetails(charger.ID)
+		chargerDetails, err := a.client.ChargerDetails(ctx, charger.ID)
 		if err != nil {
 			return errors.Wrap(err, "failed to fetch charger details from Easee API")
 		}
@@ -198,13 +207,43 @@ func (a *application) registerChargers() error {
 				Product:   chargerDetails.Product,
 			},
 		})
+
+		siteInfo, err := a.client.ChargerSiteInfo(ctx, charger.ID)
+		if err != nil {
+			log.WithError(err).Warnf("application: failed to fetch site of charger %s, its circuits are skipped", charger.ID)
+
+			continue
+		}
+
+		for _, circuit := range siteInfo.Circuits {
+			circuits[circuit.ID] = circuit
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(circuits)) {
+		seeds = append(seeds, &adapter.ThingSeed{
+			ID:   easee.CircuitThingID(id),
+			Info: easee.NewCircuitInfo(circuits[id]),
+		})
+	}
+
+	equalizers, err := a.client.Equalizers(ctx)
+	if err != nil {
+		log.WithError(err).Warn("application: failed to fetch available equalizers from Easee API, equalizers are skipped")
+	}
+
+	for _, equalizer := range equalizers {
+		seeds = append(seeds, &adapter.ThingSeed{
+			ID:   equalizer.ID,
+			Info: easee.NewEqualizerInfo(equalizer),
+		})
 	}
 
 	if err := a.ad.EnsureThings(seeds); err != nil {
 		return errors.Wrap(err, "application: failed to ensure things")
 	}
 
-	if len(chargers) > 0 {
+	if len(chargers) > 0 || len(equalizers) > 0 {
 		a.signalRClient.Start()
 	}
 
@@ -13,6 +13,7 @@ import (
 	"github.com/michalkurzeja/go-clock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/futurehomeno/edge-easee-adapter/internal/app"
 	"github.com/futurehomeno/edge-easee-adapter/internal/config"
@@ -221,16 +222,19 @@ func TestApplication_Login(t *testing.T) { //nolint:paralleltest
 				lc.SetConfigState(lifecycle.ConfigStateNotConfigured)
 			},
 			mockAuthenticator: func(a *mocks.Authenticator) {
-				a.On("Login", "test-user", "test-password").Return(nil)
+				a.On("Login", mock.Anything, "test-user", "test-password").Return(nil)
 			},
 			mockClient: func(c *mocks.APIClient) {
-				c.On("Chargers").Return([]model.Charger{
+				c.On("Chargers", mock.Anything).Return([]model.Charger{
 					{ID: "123"},
 					{ID: "456"},
 				}, nil)
-				c.On("ChargerDetails", "123").Return(model.ChargerDetails{Product: "xd"}, nil)
-				c.On("ChargerDetails", "456").Return(model.ChargerDetails{Product: "edi"}, nil)
-				c.On("Ping").Return(nil)
+				c.On("ChargerDetails", mock.Anything, "123").Return(model.ChargerDetails{Product: "xd"}, nil)
+				c.On("ChargerDetails", mock.Anything, "456").Return(model.ChargerDetails{Product: "edi"}, nil)
+				c.On("ChargerSiteInfo", mock.Anything, "123").Return(&model.ChargerSiteInfo{ID: 1, Circuits: []model.Circuit{{ID: 10, SiteID: 1, PanelName: "1", RatedCurrent: 25}}}, nil)
+				c.On("ChargerSiteInfo", mock.Anything, "456").Return(&model.ChargerSiteInfo{ID: 1, Circuits: []model.Circuit{{ID: 10, SiteID: 1, PanelName: "1", RatedCurrent: 25}}}, nil)
+				c.On("Equalizers", mock.Anything).Return([]model.Equalizer{{ID: "QH12345", SiteID: 1, CircuitID: 10}}, nil)
+				c.On("Ping", mock.Anything).Return(nil)
 			},
 			mockAdapter: func(a *mockedadapter.Adapter) {
 				a.On("EnsureThings", adapter.ThingSeeds{
@@ -248,6 +252,26 @@ func TestApplication_Login(t *testing.T) { //nolint:paralleltest
 							Product:   "edi",
 						},
 					},
+					&adapter.ThingSeed{
+						ID: "circuit-10",
+						Info: easee.Info{
+							Type:       easee.ThingTypeCircuit,
+							SiteID:     1,
+							CircuitID:  10,
+							PanelName:  "1",
+							FuseRating: 25,
+						},
+					},
+					&adapter.ThingSeed{
+						ID: "QH12345",
+						Info: easee.Info{
+							Type:        easee.ThingTypeEqualizer,
+							Product:     "Equalizer",
+							EqualizerID: "QH12345",
+							SiteID:      1,
+							CircuitID:   10,
+						},
+					},
 				}).Return(nil)
 			},
 			mockSignalRClient: func(c *mocks.Client) {
@@ -274,11 +298,11 @@ func TestApplication_Login(t *testing.T) { //nolint:paralleltest
 			},
 			mockAuthenticator: func(a *mocks.Authenticator) {
 				a.
-					On("Login", "test-user", "test-password").
+					On("Login", mock.Anything, "test-user", "test-password").
 					Return(errors.New("oops"))
 			},
 			mockClient: func(c *mocks.APIClient) {
-				c.On("Ping").Return(nil)
+				c.On("Ping", mock.Anything).Return(nil)
 			},
 			wantErr: true,
 			lifecycleAssertions: func(lc *lifecycle.Lifecycle) {
@@ -301,16 +325,18 @@ func TestApplication_Login(t *testing.T) { //nolint:paralleltest
 				lc.SetConfigState(lifecycle.ConfigStateNotConfigured)
 			},
 			mockAuthenticator: func(a *mocks.Authenticator) {
-				a.On("Login", "test-user", "test-password").Return(nil)
+				a.On("Login", mock.Anything, "test-user", "test-password").Return(nil)
 			},
 			mockClient: func(c *mocks.APIClient) {
-				c.On("Chargers").Return([]model.Charger{
+				c.On("Chargers", mock.Anything).Return([]model.Charger{
 					{ID: "123"},
 					{ID: "456"},
 				}, nil)
-				c.On("ChargerDetails", "123").Return(model.ChargerDetails{Product: "xd"}, nil)
-				c.On("ChargerDetails", "456").Return(model.ChargerDetails{Product: "edi"}, nil)
-				c.On("Ping").Return(errors.New("oops"))
+				c.On("ChargerDetails", mock.Anything, "123").Return(model.ChargerDetails{Product: "xd"}, nil)
+				c.On("ChargerDetails", mock.Anything, "456").Return(model.ChargerDetails{Product: "edi"}, nil)
+				c.On("ChargerSiteInfo", mock.Anything, mock.Anything).Return(nil, errors.New("oops"))
+				c.On("Equalizers", mock.Anything).Return(nil, errors.New("oops"))
+				c.On("Ping", mock.Anything).Return(errors.New("oops"))
 			},
 			mockAdapter: func(a *mockedadapter.Adapter) {
 				a.On("EnsureThings", adapter.ThingSeeds{
@@ -353,16 +379,18 @@ func TestApplication_Login(t *testing.T) { //nolint:paralleltest
 				lc.SetConfigState(lifecycle.ConfigStateNotConfigured)
 			},
 			mockAuthenticator: func(a *mocks.Authenticator) {
-				a.On("Login", "test-user", "test-password").Return(nil)
+				a.On("Login", mock.Anything, "test-user", "test-password").Return(nil)
 			},
 			mockClient: func(c *mocks.APIClient) {
-				c.On("Chargers").Return([]model.Charger{
+				c.On("Chargers", mock.Anything).Return([]model.Charger{
 					{ID: "123"},
 					{ID: "456"},
 				}, nil)
-				c.On("ChargerDetails", "123").Return(model.ChargerDetails{Product: "xd"}, nil)
-				c.On("ChargerDetails", "456").Return(model.ChargerDetails{Product: "edi"}, nil)
-				c.On("Ping").Return(nil)
+				c.On("ChargerDetails", mock.Anything, "123").Return(model.ChargerDetails{Product: "xd"}, nil)
+				c.On("ChargerDetails", mock.Anything, "456").Return(model.ChargerDetails{Product: "edi"}, nil)
+				c.On("ChargerSiteInfo", mock.Anything, mock.Anything).Return(nil, errors.New("oops"))
+				c.On("Equalizers", mock.Anything).Return(nil, errors.New("oops"))
+				c.On("Ping", mock.Anything).Return(nil)
 			},
 			mockAdapter: func(a *mockedadapter.Adapter) {
 				a.On("EnsureThings", adapter.ThingSeeds{
@@ -494,7 +522,8 @@ func TestApplication_Logout(t *testing.T) {
 			tt.setLifecycle(lc)
 
 			clientMock := new(mocks.APIClient)
-			clientMock.On("Ping").Return(errors.New("oops"))
+			clientMock.On("Ping", mock.Anything).Return(errors.New("oops"))
+			clientMock.On("CancelRequests").Return()
 
 			authMock := &mocks.Authenticator{}
 			authMock.On("Logout").Return(tt.authLogoutError)
@@ -544,7 +573,7 @@ func TestApplication_Initialize(t *testing.T) {
 				a.On("InitializeThings").Return(nil)
 			},
 			mockClient: func(c *mocks.APIClient) {
-				c.On("Ping").Return(nil)
+				c.On("Ping", mock.Anything).Return(nil)
 			},
 			lifecycleAssertions: func(lc *lifecycle.Lifecycle) {
 				assert.Equal(t, lifecycle.AppStateRunning, lc.AppState())
@@ -566,7 +595,7 @@ func TestApplication_Initialize(t *testing.T) {
 				a.On("InitializeThings").Return(nil)
 			},
 			mockClient: func(c *mocks.APIClient) {
-				c.On("Ping").Return(nil)
+				c.On("Ping", mock.Anything).Return(nil)
 			},
 			lifecycleAssertions: func(lc *lifecycle.Lifecycle) {
 				assert.Equal(t, lifecycle.AppStateNotConfigured, lc.AppState())
@@ -588,7 +617,7 @@ func TestApplication_Initialize(t *testing.T) {
 				a.On("InitializeThings").Return(errors.New("oops"))
 			},
 			mockClient: func(c *mocks.APIClient) {
-				c.On("Ping").Return(nil)
+				c.On("Ping", mock.Anything).Return(nil)
 			},
 			lifecycleAssertions: func(lc *lifecycle.Lifecycle) {
 				assert.Equal(t, lifecycle.AppStateNotConfigured, lc.AppState())
@@ -617,7 +646,7 @@ func TestApplication_Initialize(t *testing.T) {
 				a.On("InitializeThings").Return(nil)
 			},
 			mockClient: func(c *mocks.APIClient) {
-				c.On("Ping").Return(errors.New("oops"))
+				c.On("Ping", mock.Anything).Return(errors.New("oops"))
 			},
 			lifecycleAssertions: func(lc *lifecycle.Lifecycle) {
 				assert.Equal(t, lifecycle.AppStateRunning, lc.AppState())
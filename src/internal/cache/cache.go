@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"fmt"
 	"slices"
 	"sync"
 	"time"
@@ -47,6 +48,23 @@ type Cache interface {
 	CableCurrent() (*int64, time.Time)
 	// CableAlwaysLocked returns state of cable always locked parameter.
 	CableAlwaysLocked() (bool, time.Time)
+	// AuthorizationRequired returns state of authorization required parameter.
+	AuthorizationRequired() (bool, time.Time)
+	// LEDStripBrightness returns state of LED strip brightness parameter.
+	LEDStripBrightness() (int, time.Time)
+	// SmartButtonEnabled returns state of smart button enabled parameter.
+	SmartButtonEnabled() (bool, time.Time)
+	// EnableIdleCurrent returns state of enable idle current parameter.
+	EnableIdleCurrent() (bool, time.Time)
+	// OfflineMaxCurrent returns the max current per phase offered while offline.
+	// Returns false until the current of all phases is known, either from the persisted state or observed.
+	OfflineMaxCurrent() (model.OfflineCurrent, bool)
+	// FirmwareVersion returns the firmware version installed on the charger.
+	FirmwareVersion() (int, time.Time)
+	// ReasonForNoCurrent returns the reason why the charger is not offering current.
+	ReasonForNoCurrent() (model.NoCurrentReason, time.Time)
+	// Diagnostics returns errors and warnings reported by the charger.
+	Diagnostics() model.Diagnostics
 
 	SetPhaseMode(mode int, timestamp time.Time) bool
 	SetChargerState(state chargepoint.State, timestamp time.Time) bool
@@ -60,6 +78,20 @@ type Cache interface {
 	SetCableLocked(locked bool, timestamp time.Time) bool
 	SetCableCurrent(current *int64, timestamp time.Time) bool
 	SetCableAlwaysLocked(alwaysLocked bool, timestamp time.Time) bool
+	SetAuthorizationRequired(required bool, timestamp time.Time) bool
+	SetLEDStripBrightness(brightness int, timestamp time.Time) bool
+	SetSmartButtonEnabled(enabled bool, timestamp time.Time) bool
+	SetEnableIdleCurrent(enabled bool, timestamp time.Time) bool
+	SetOfflineMaxCurrent(current model.OfflineCurrent, timestamp time.Time) bool
+	SetOfflineMaxCurrentPhase(phase int, current int, timestamp time.Time) bool
+	SetFirmwareVersion(version int, timestamp time.Time) bool
+	SetErrorCode(code int, timestamp time.Time) bool
+	SetReasonForNoCurrent(reason model.NoCurrentReason, timestamp time.Time) bool
+	SetDetectedGridType(gridType model.GridType, timestamp time.Time) bool
+	// SetPendingMaintenance registers the maintenance command awaiting the charger to come back online.
+	SetPendingMaintenance(command model.MaintenanceCommand, issuedAt time.Time)
+	// CompleteMaintenance registers the charger connectivity and returns the pending maintenance command completed by it.
+	CompleteMaintenance(online bool, timestamp time.Time) (model.MaintenanceCommand, bool)
 	SetEnergySession(energy float64, timestamp time.Time) bool
 	SetPhase1Current(current float64, timestamp time.Time) bool
 	SetPhase2Current(current float64, timestamp time.Time) bool
@@ -67,6 +99,7 @@ type Cache interface {
 
 	WaitForMaxCurrent(current int64, duration time.Duration) bool
 	WaitForOfferedCurrent(current int64, duration time.Duration) bool
+	WaitForPhaseMode(mode int, duration time.Duration) bool
 }
 
 type cache struct {
@@ -91,14 +124,25 @@ type cache struct {
 	cableLocked             model.TimestampedValue[bool]
 	cableCurrent            model.TimestampedValue[*int64]
 	cableAlwaysLocked       model.TimestampedValue[bool]
+	authorizationRequired   model.TimestampedValue[bool]
+	ledStripBrightness      model.TimestampedValue[int]
+	smartButtonEnabled      model.TimestampedValue[bool]
+	enableIdleCurrent       model.TimestampedValue[bool]
+	offlineMaxCurrent       [3]model.TimestampedValue[int]
+	offlineMaxCurrentKnown  [3]bool
+	firmwareVersion         model.TimestampedValue[int]
+	errorCode               model.TimestampedValue[int]
+	reasonForNoCurrent      model.TimestampedValue[model.NoCurrentReason]
+	detectedGridType        model.TimestampedValue[model.GridType]
+	pendingMaintenance      *model.PendingMaintenance
 
-	currentListeners map[waitGroup][]chan<- int64
+	listeners map[waitGroup][]chan<- int64
 }
 
 func NewCache(chargerID string) Cache {
 	return &cache{
-		chargerID:        chargerID,
-		currentListeners: make(map[waitGroup][]chan<- int64),
+		chargerID: chargerID,
+		listeners: make(map[waitGroup][]chan<- int64),
 	}
 }
 
@@ -239,6 +283,276 @@ func (c *cache) SetCableAlwaysLocked(alwaysLocked bool, timestamp time.Time) boo
 	return true
 }
 
+func (c *cache) AuthorizationRequired() (bool, time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.authorizationRequired.Value, c.authorizationRequired.Timestamp
+}
+
+func (c *cache) SetAuthorizationRequired(required bool, timestamp time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if timestamp.Before(c.authorizationRequired.Timestamp) {
+		c.logOutdatedObservation("authorization required", c.authorizationRequired.Timestamp, timestamp)
+
+		return false
+	}
+
+	c.authorizationRequired = model.TimestampedValue[bool]{
+		Value:     required,
+		Timestamp: timestamp,
+	}
+
+	return true
+}
+
+func (c *cache) LEDStripBrightness() (int, time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.ledStripBrightness.Value, c.ledStripBrightness.Timestamp
+}
+
+func (c *cache) SetLEDStripBrightness(brightness int, timestamp time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if timestamp.Before(c.ledStripBrightness.Timestamp) {
+		c.logOutdatedObservation("LED strip brightness", c.ledStripBrightness.Timestamp, timestamp)
+
+		return false
+	}
+
+	c.ledStripBrightness = model.TimestampedValue[int]{
+		Value:     brightness,
+		Timestamp: timestamp,
+	}
+
+	return true
+}
+
+func (c *cache) SmartButtonEnabled() (bool, time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.smartButtonEnabled.Value, c.smartButtonEnabled.Timestamp
+}
+
+func (c *cache) SetSmartButtonEnabled(enabled bool, timestamp time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if timestamp.Before(c.smartButtonEnabled.Timestamp) {
+		c.logOutdatedObservation("smart button enabled", c.smartButtonEnabled.Timestamp, timestamp)
+
+		return false
+	}
+
+	c.smartButtonEnabled = model.TimestampedValue[bool]{
+		Value:     enabled,
+		Timestamp: timestamp,
+	}
+
+	return true
+}
+
+func (c *cache) EnableIdleCurrent() (bool, time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.enableIdleCurrent.Value, c.enableIdleCurrent.Timestamp
+}
+
+func (c *cache) SetEnableIdleCurrent(enabled bool, timestamp time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if timestamp.Before(c.enableIdleCurrent.Timestamp) {
+		c.logOutdatedObservation("enable idle current", c.enableIdleCurrent.Timestamp, timestamp)
+
+		return false
+	}
+
+	c.enableIdleCurrent = model.TimestampedValue[bool]{
+		Value:     enabled,
+		Timestamp: timestamp,
+	}
+
+	return true
+}
+
+func (c *cache) FirmwareVersion() (int, time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.firmwareVersion.Value, c.firmwareVersion.Timestamp
+}
+
+func (c *cache) SetFirmwareVersion(version int, timestamp time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if timestamp.Before(c.firmwareVersion.Timestamp) {
+		c.logOutdatedObservation("firmware version", c.firmwareVersion.Timestamp, timestamp)
+
+		return false
+	}
+
+	c.firmwareVersion = model.TimestampedValue[int]{
+		Value:     version,
+		Timestamp: timestamp,
+	}
+
+	return true
+}
+
+func (c *cache) Diagnostics() model.Diagnostics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return model.Diagnostics{
+		ErrorCode:          c.errorCode.Value,
+		ReasonForNoCurrent: c.reasonForNoCurrent.Value,
+		GridType:           c.detectedGridType.Value,
+	}
+}
+
+func (c *cache) SetErrorCode(code int, timestamp time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if timestamp.Before(c.errorCode.Timestamp) {
+		c.logOutdatedObservation("error code", c.errorCode.Timestamp, timestamp)
+
+		return false
+	}
+
+	c.errorCode = model.TimestampedValue[int]{
+		Value:     code,
+		Timestamp: timestamp,
+	}
+
+	return true
+}
+
+func (c *cache) ReasonForNoCurrent() (model.NoCurrentReason, time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.reasonForNoCurrent.Value, c.reasonForNoCurrent.Timestamp
+}
+
+func (c *cache) SetReasonForNoCurrent(reason model.NoCurrentReason, timestamp time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if timestamp.Before(c.reasonForNoCurrent.Timestamp) {
+		c.logOutdatedObservation("reason for no current", c.reasonForNoCurrent.Timestamp, timestamp)
+
+		return false
+	}
+
+	c.reasonForNoCurrent = model.TimestampedValue[model.NoCurrentReason]{
+		Value:     reason,
+		Timestamp: timestamp,
+	}
+
+	return true
+}
+
+func (c *cache) SetDetectedGridType(gridType model.GridType, timestamp time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if timestamp.Before(c.detectedGridType.Timestamp) {
+		c.logOutdatedObservation("detected grid type", c.detectedGridType.Timestamp, timestamp)
+
+		return false
+	}
+
+	c.detectedGridType = model.TimestampedValue[model.GridType]{
+		Value:     gridType,
+		Timestamp: timestamp,
+	}
+
+	return true
+}
+
+func (c *cache) SetPendingMaintenance(command model.MaintenanceCommand, issuedAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pendingMaintenance = &model.PendingMaintenance{
+		Command:  command,
+		IssuedAt: issuedAt,
+	}
+}
+
+func (c *cache) CompleteMaintenance(online bool, timestamp time.Time) (model.MaintenanceCommand, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pendingMaintenance == nil || !c.pendingMaintenance.Observe(online, timestamp) {
+		return "", false
+	}
+
+	command := c.pendingMaintenance.Command
+	c.pendingMaintenance = nil
+
+	return command, true
+}
+
+func (c *cache) OfflineMaxCurrent() (model.OfflineCurrent, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return model.OfflineCurrent{
+		Phase1: c.offlineMaxCurrent[0].Value,
+		Phase2: c.offlineMaxCurrent[1].Value,
+		Phase3: c.offlineMaxCurrent[2].Value,
+	}, !slices.Contains(c.offlineMaxCurrentKnown[:], false)
+}
+
+func (c *cache) SetOfflineMaxCurrent(current model.OfflineCurrent, timestamp time.Time) bool {
+	changed := false
+
+	for i, v := range current.Currents() {
+		if c.SetOfflineMaxCurrentPhase(i+1, v, timestamp) {
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// SetOfflineMaxCurrentPhase sets the offline max current of the phase numbered from 1.
+func (c *cache) SetOfflineMaxCurrentPhase(phase int, current int, timestamp time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if phase < 1 || phase > len(c.offlineMaxCurrent) {
+		return false
+	}
+
+	stored := &c.offlineMaxCurrent[phase-1]
+
+	if timestamp.Before(stored.Timestamp) {
+		c.logOutdatedObservation(fmt.Sprintf("offline max current of phase %d", phase), stored.Timestamp, timestamp)
+
+		return false
+	}
+
+	*stored = model.TimestampedValue[int]{
+		Value:     current,
+		Timestamp: timestamp,
+	}
+	c.offlineMaxCurrentKnown[phase-1] = true
+
+	return true
+}
+
 func (c *cache) SetCableLocked(locked bool, timestamp time.Time) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -290,6 +604,16 @@ func (c *cache) SetPhaseMode(phaseMode int, timestamp time.Time) bool {
 		Timestamp: timestamp,
 	}
 
+	if listeners, ok := c.listeners[waitGroupPhaseMode]; ok {
+		for _, c := range listeners {
+			select {
+			case c <- int64(phaseMode):
+			default:
+				log.Warn("Unable to publish phase mode change")
+			}
+		}
+	}
+
 	return true
 }
 
@@ -344,7 +668,7 @@ func (c *cache) SetMaxCurrent(current int64, timestamp time.Time) bool {
 		Timestamp: timestamp,
 	}
 
-	if listeners, ok := c.currentListeners[waitGroupMaxCurrent]; ok {
+	if listeners, ok := c.listeners[waitGroupMaxCurrent]; ok {
 		for _, c := range listeners {
 			select {
 			case c <- current:
@@ -390,7 +714,7 @@ func (c *cache) SetOfferedCurrent(current int64, timestamp time.Time) bool {
 		Timestamp: timestamp,
 	}
 
-	if listeners, ok := c.currentListeners[waitGroupOfferedCurrent]; ok {
+	if listeners, ok := c.listeners[waitGroupOfferedCurrent]; ok {
 		for _, c := range listeners {
 			select {
 			case c <- current:
@@ -554,17 +878,22 @@ type waitGroup int
 const (
 	waitGroupMaxCurrent waitGroup = iota
 	waitGroupOfferedCurrent
+	waitGroupPhaseMode
 )
 
 func (c *cache) WaitForMaxCurrent(current int64, duration time.Duration) bool {
-	return c.waitForCurrent(waitGroupMaxCurrent, current, duration)
+	return c.waitForValue(waitGroupMaxCurrent, current, duration)
 }
 
 func (c *cache) WaitForOfferedCurrent(current int64, duration time.Duration) bool {
-	return c.waitForCurrent(waitGroupOfferedCurrent, current, duration)
+	return c.waitForValue(waitGroupOfferedCurrent, current, duration)
+}
+
+func (c *cache) WaitForPhaseMode(mode int, duration time.Duration) bool {
+	return c.waitForValue(waitGroupPhaseMode, int64(mode), duration)
 }
 
-func (c *cache) waitForCurrent(group waitGroup, current int64, duration time.Duration) bool {
+func (c *cache) waitForValue(group waitGroup, expected int64, duration time.Duration) bool {
 	c.mu.Lock()
 
 	var value int64
@@ -574,6 +903,8 @@ func (c *cache) waitForCurrent(group waitGroup, current int64, duration time.Dur
 		value = c.maxCurrent.Value
 	case waitGroupOfferedCurrent:
 		value = c.offeredCurrent.Value
+	case waitGroupPhaseMode:
+		value = int64(c.phaseMode.Value)
 	default:
 		log.Warnf("invalid waitGroup: %v", group)
 		c.mu.Unlock()
@@ -581,14 +912,14 @@ func (c *cache) waitForCurrent(group waitGroup, current int64, duration time.Dur
 		return false
 	}
 
-	if current == value {
+	if expected == value {
 		c.mu.Unlock()
 
 		return true
 	}
 
 	channel := make(chan int64, 1)
-	c.currentListeners[group] = append(c.currentListeners[group], channel)
+	c.listeners[group] = append(c.listeners[group], channel)
 	c.mu.Unlock()
 
 	defer func() {
@@ -597,7 +928,7 @@ func (c *cache) waitForCurrent(group waitGroup, current int64, duration time.Dur
 
 		close(channel)
 
-		c.currentListeners[group] = slices.DeleteFunc(c.currentListeners[group], func(c chan<- int64) bool {
+		c.listeners[group] = slices.DeleteFunc(c.listeners[group], func(c chan<- int64) bool {
 			return c == channel
 		})
 	}()
@@ -608,7 +939,7 @@ func (c *cache) waitForCurrent(group waitGroup, current int64, duration time.Dur
 	for {
 		select {
 		case v := <-channel:
-			if v == current {
+			if v == expected {
 				return true
 			}
 		case <-timer.C:
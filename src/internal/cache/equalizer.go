@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+// EqualizerCache is a cache for equalizer observations.
+type EqualizerCache interface {
+	// Measurement returns the latest measurement of the provided observation.
+	Measurement(id model.ObservationID) (float64, time.Time)
+	// SetMeasurement sets the measurement of the provided observation, returns false if the measurement is outdated.
+	SetMeasurement(id model.ObservationID, value float64, timestamp time.Time) bool
+}
+
+type equalizerCache struct {
+	mu sync.RWMutex
+
+	equalizerID  string
+	measurements map[model.ObservationID]model.TimestampedValue[float64]
+}
+
+// NewEqualizerCache returns a new instance of EqualizerCache.
+func NewEqualizerCache(equalizerID string) EqualizerCache {
+	return &equalizerCache{
+		equalizerID:  equalizerID,
+		measurements: make(map[model.ObservationID]model.TimestampedValue[float64]),
+	}
+}
+
+func (c *equalizerCache) Measurement(id model.ObservationID) (float64, time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	measurement := c.measurements[id]
+
+	return measurement.Value, measurement.Timestamp
+}
+
+func (c *equalizerCache) SetMeasurement(id model.ObservationID, value float64, timestamp time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if current := c.measurements[id]; timestamp.Before(current.Timestamp) {
+		log.WithField("equalizer_id", c.equalizerID).
+			WithField("observation_id", id).
+			WithField("old", current.Timestamp.Format(time.RFC3339)).
+			WithField("new", timestamp.Format(time.RFC3339)).
+			Debug("cache: setting measurement skipped: outdated observation")
+
+		return false
+	}
+
+	c.measurements[id] = model.TimestampedValue[float64]{
+		Value:     value,
+		Timestamp: timestamp,
+	}
+
+	return true
+}
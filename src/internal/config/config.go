@@ -7,6 +7,8 @@ import (
 	"github.com/futurehomeno/cliffhanger/config"
 	"github.com/futurehomeno/cliffhanger/storage"
 	"github.com/michalkurzeja/go-clock"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
 )
 
 // Config is a model containing all application configuration settings.
@@ -14,15 +16,29 @@ type Config struct {
 	config.Default
 	Credentials
 
-	EaseeBaseURL                 string     `json:"easeeBaseURL2"`
-	PollingInterval              string     `json:"pollingInterval"`
-	CurrentWaitDuration          string     `json:"currentWaitDuration"`
-	SlowChargingCurrentInAmperes float64    `json:"slowChargingCurrentInAmperes"`
-	HTTPTimeout                  string     `json:"httpTimeout"`
-	SignalR                      SignalR    `json:"signalR"`
-	AuthenticatorBackoff         backoffCfg `json:"authenticatorBackoff"`
-	OfferedCurrentWaitTime       string     `json:"offered_current_wait_time"`
-	EnergyLifetimeInterval       string     `json:"energyLifetimeInterval"`
+	EaseeBaseURL                 string              `json:"easeeBaseURL2"`
+	PollingInterval              string              `json:"pollingInterval"`
+	CurrentWaitDuration          string              `json:"currentWaitDuration"`
+	SlowChargingCurrentInAmperes float64             `json:"slowChargingCurrentInAmperes"`
+	HTTPTimeout                  string              `json:"httpTimeout"`
+	CommandTimeout               string              `json:"commandTimeout"`
+	SignalR                      SignalR             `json:"signalR"`
+	AuthenticatorBackoff         backoffCfg          `json:"authenticatorBackoff"`
+	HTTPRetry                    httpRetryCfg        `json:"httpRetry"`
+	RateLimits                   *RateLimitsCfg      `json:"rateLimits,omitempty"`
+	OfferedCurrentWaitTime       string              `json:"offered_current_wait_time"`
+	EnergyLifetimeInterval       string              `json:"energyLifetimeInterval"`
+	SessionRetentionMaxAge       string              `json:"sessionRetentionMaxAge"`
+	SessionRetentionMaxCount     int                 `json:"sessionRetentionMaxCount"`
+	SessionRetentionInterval     string              `json:"sessionRetentionInterval"`
+	Tariff                       *model.Tariff       `json:"tariff,omitempty"`
+	PriceCurve                   *model.PriceCurve   `json:"priceCurve,omitempty"`
+	SolarMeterTopic              string              `json:"solarMeterTopic"`
+	SolarHysteresis              float64             `json:"solarHysteresis"`
+	LoadGuardMeterTopic          string              `json:"loadGuardMeterTopic"`
+	MainFuseRating               float64             `json:"mainFuseRating"`
+	LoadGuardMargin              float64             `json:"loadGuardMargin"`
+	PowerSharing                 *model.PowerSharing `json:"powerSharing,omitempty"`
 }
 
 // New creates new instance of a configuration object.
@@ -108,6 +124,48 @@ type BackoffCfg struct {
 	RepeatedFailureCount uint32
 }
 
+// httpRetryCfg represents a file storage representation of HTTPRetryCfg.
+type httpRetryCfg struct {
+	backoffCfg
+	MaxAttempts uint32 `json:"maxAttempts"`
+}
+
+// HTTPRetryCfg represents values used to configure retries of failed HTTP requests.
+type HTTPRetryCfg struct {
+	BackoffCfg
+	// MaxAttempts is the maximum number of attempts to execute a request, retries are disabled if it's lower than 2.
+	MaxAttempts uint32
+}
+
+// RateLimitCfg represents a token bucket limiting the rate of requests to a class of Easee API endpoints.
+type RateLimitCfg struct {
+	// RequestsPerMinute is the rate at which the bucket is refilled, the limit is disabled if it's not positive.
+	RequestsPerMinute float64 `json:"requestsPerMinute"`
+	// Burst is the capacity of the bucket, i.e. the number of requests which can be sent at once.
+	Burst int `json:"burst"`
+}
+
+// RateLimitsCfg represents rate limits of Easee API endpoint classes, shared by all chargers of the account.
+type RateLimitsCfg struct {
+	Commands RateLimitCfg `json:"commands"`
+	Settings RateLimitCfg `json:"settings"`
+	Reads    RateLimitCfg `json:"reads"`
+}
+
+// Class returns the rate limit of the endpoint class, or a disabled limit if the class is unknown.
+func (c RateLimitsCfg) Class(class string) RateLimitCfg {
+	switch class {
+	case "commands":
+		return c.Commands
+	case "settings":
+		return c.Settings
+	case "reads":
+		return c.Reads
+	default:
+		return RateLimitCfg{}
+	}
+}
+
 // NewService creates a new configuration service.
 func NewService(storage storage.Storage[*Config]) *Service {
 	return &Service{
@@ -303,6 +361,30 @@ func (cs *Service) SetHTTPTimeout(timeout time.Duration) error {
 	return cs.Storage.Save()
 }
 
+// GetCommandTimeout allows to safely access a configuration setting.
+func (cs *Service) GetCommandTimeout() time.Duration {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	timeout, err := time.ParseDuration(cs.Storage.Model().CommandTimeout)
+	if err != nil {
+		return 20 * time.Second
+	}
+
+	return timeout
+}
+
+// SetCommandTimeout allows to safely set and persist configuration settings.
+func (cs *Service) SetCommandTimeout(timeout time.Duration) error {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	cs.Storage.Model().ConfiguredAt = time.Now().Format(time.RFC3339)
+	cs.Storage.Model().CommandTimeout = timeout.String()
+
+	return cs.Storage.Save()
+}
+
 // GetSignalRBaseURL allows to safely access a configuration setting.
 func (cs *Service) GetSignalRBaseURL() string {
 	cs.lock.RLock()
@@ -550,6 +632,269 @@ func (cs *Service) SetOfferedCurrentWaitTime(duration time.Duration) error {
 	return cs.Storage.Save()
 }
 
+// GetSessionRetentionMaxAge allows to safely access a configuration setting.
+func (cs *Service) GetSessionRetentionMaxAge() time.Duration {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	duration, err := time.ParseDuration(cs.Storage.Model().SessionRetentionMaxAge)
+	if err != nil {
+		return 365 * 24 * time.Hour
+	}
+
+	return duration
+}
+
+// SetSessionRetentionMaxAge allows to safely set and persist a configuration setting.
+func (cs *Service) SetSessionRetentionMaxAge(duration time.Duration) error {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	cs.Storage.Model().ConfiguredAt = time.Now().Format(time.RFC3339)
+	cs.Storage.Model().SessionRetentionMaxAge = duration.String()
+
+	return cs.Storage.Save()
+}
+
+// GetSessionRetentionMaxCount allows to safely access a configuration setting.
+func (cs *Service) GetSessionRetentionMaxCount() int {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	return cs.Storage.Model().SessionRetentionMaxCount
+}
+
+// SetSessionRetentionMaxCount allows to safely set and persist a configuration setting.
+func (cs *Service) SetSessionRetentionMaxCount(count int) error {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	cs.Storage.Model().ConfiguredAt = time.Now().Format(time.RFC3339)
+	cs.Storage.Model().SessionRetentionMaxCount = count
+
+	return cs.Storage.Save()
+}
+
+// GetSessionRetentionInterval allows to safely access a configuration setting.
+func (cs *Service) GetSessionRetentionInterval() time.Duration {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	duration, err := time.ParseDuration(cs.Storage.Model().SessionRetentionInterval)
+	if err != nil {
+		return time.Hour
+	}
+
+	return duration
+}
+
+// SetSessionRetentionInterval allows to safely set and persist a configuration setting.
+func (cs *Service) SetSessionRetentionInterval(interval time.Duration) error {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	cs.Storage.Model().ConfiguredAt = time.Now().Format(time.RFC3339)
+	cs.Storage.Model().SessionRetentionInterval = interval.String()
+
+	return cs.Storage.Save()
+}
+
+// GetTariff allows to safely access a configuration setting. Returns nil if the tariff is not configured.
+func (cs *Service) GetTariff() *model.Tariff {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	if cs.Storage.Model().Tariff == nil {
+		return nil
+	}
+
+	tariff := *cs.Storage.Model().Tariff
+
+	return &tariff
+}
+
+// SetTariff allows to safely set and persist a configuration setting. Providing nil removes the tariff.
+func (cs *Service) SetTariff(tariff *model.Tariff) error {
+	if tariff != nil {
+		if err := tariff.Validate(); err != nil {
+			return err
+		}
+	}
+
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	cs.Storage.Model().ConfiguredAt = time.Now().Format(time.RFC3339)
+	cs.Storage.Model().Tariff = tariff
+
+	return cs.Storage.Save()
+}
+
+// GetPriceCurve allows to safely access a configuration setting. Returns nil if the price curve is not configured.
+func (cs *Service) GetPriceCurve() *model.PriceCurve {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	if cs.Storage.Model().PriceCurve == nil {
+		return nil
+	}
+
+	curve := *cs.Storage.Model().PriceCurve
+
+	return &curve
+}
+
+// SetPriceCurve allows to safely set and persist a configuration setting. Providing nil removes the price curve.
+func (cs *Service) SetPriceCurve(curve *model.PriceCurve) error {
+	if curve != nil {
+		if err := curve.Validate(); err != nil {
+			return err
+		}
+	}
+
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	cs.Storage.Model().ConfiguredAt = time.Now().Format(time.RFC3339)
+	cs.Storage.Model().PriceCurve = curve
+
+	return cs.Storage.Save()
+}
+
+// GetSolarMeterTopic allows to safely access a configuration setting.
+func (cs *Service) GetSolarMeterTopic() string {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	return cs.Storage.Model().SolarMeterTopic
+}
+
+// SetSolarMeterTopic allows to safely set and persist a configuration setting.
+func (cs *Service) SetSolarMeterTopic(topic string) error {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	cs.Storage.Model().ConfiguredAt = time.Now().Format(time.RFC3339)
+	cs.Storage.Model().SolarMeterTopic = topic
+
+	return cs.Storage.Save()
+}
+
+// GetSolarHysteresis allows to safely access a configuration setting.
+func (cs *Service) GetSolarHysteresis() float64 {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	if cs.Storage.Model().SolarHysteresis <= 0 {
+		return 1
+	}
+
+	return cs.Storage.Model().SolarHysteresis
+}
+
+// SetSolarHysteresis allows to safely set and persist a configuration setting.
+func (cs *Service) SetSolarHysteresis(hysteresis float64) error {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	cs.Storage.Model().ConfiguredAt = time.Now().Format(time.RFC3339)
+	cs.Storage.Model().SolarHysteresis = hysteresis
+
+	return cs.Storage.Save()
+}
+
+// GetLoadGuardMeterTopic allows to safely access a configuration setting.
+func (cs *Service) GetLoadGuardMeterTopic() string {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	return cs.Storage.Model().LoadGuardMeterTopic
+}
+
+// SetLoadGuardMeterTopic allows to safely set and persist a configuration setting.
+func (cs *Service) SetLoadGuardMeterTopic(topic string) error {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	cs.Storage.Model().ConfiguredAt = time.Now().Format(time.RFC3339)
+	cs.Storage.Model().LoadGuardMeterTopic = topic
+
+	return cs.Storage.Save()
+}
+
+// GetMainFuseRating allows to safely access a configuration setting. Returns 0 if the load guard is disabled.
+func (cs *Service) GetMainFuseRating() float64 {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	return cs.Storage.Model().MainFuseRating
+}
+
+// SetMainFuseRating allows to safely set and persist a configuration setting. Providing 0 disables the load guard.
+func (cs *Service) SetMainFuseRating(rating float64) error {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	cs.Storage.Model().ConfiguredAt = time.Now().Format(time.RFC3339)
+	cs.Storage.Model().MainFuseRating = rating
+
+	return cs.Storage.Save()
+}
+
+// GetLoadGuardMargin allows to safely access a configuration setting.
+func (cs *Service) GetLoadGuardMargin() float64 {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	if cs.Storage.Model().LoadGuardMargin <= 0 {
+		return 2
+	}
+
+	return cs.Storage.Model().LoadGuardMargin
+}
+
+// SetLoadGuardMargin allows to safely set and persist a configuration setting.
+func (cs *Service) SetLoadGuardMargin(margin float64) error {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	cs.Storage.Model().ConfiguredAt = time.Now().Format(time.RFC3339)
+	cs.Storage.Model().LoadGuardMargin = margin
+
+	return cs.Storage.Save()
+}
+
+// GetPowerSharing allows to safely access a configuration setting. Returns nil if power sharing is disabled.
+func (cs *Service) GetPowerSharing() *model.PowerSharing {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	if cs.Storage.Model().PowerSharing == nil {
+		return nil
+	}
+
+	sharing := *cs.Storage.Model().PowerSharing
+
+	return &sharing
+}
+
+// SetPowerSharing allows to safely set and persist a configuration setting. Providing nil disables power sharing.
+func (cs *Service) SetPowerSharing(sharing *model.PowerSharing) error {
+	if sharing != nil {
+		if err := sharing.Validate(); err != nil {
+			return err
+		}
+	}
+
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	cs.Storage.Model().ConfiguredAt = time.Now().Format(time.RFC3339)
+	cs.Storage.Model().PowerSharing = sharing
+
+	return cs.Storage.Save()
+}
+
 // GetAuthenticatorBackoffCfg allows to safely access api backoff settings.
 func (cs *Service) GetAuthenticatorBackoffCfg() BackoffCfg {
 	cs.lock.RLock()
@@ -595,3 +940,88 @@ func (cs *Service) SetAuthenticatorBackoffCfg(cfg BackoffCfg) error {
 
 	return cs.Storage.Save()
 }
+
+// GetHTTPRetryCfg allows to safely access retry settings of the HTTP client.
+func (cs *Service) GetHTTPRetryCfg() HTTPRetryCfg {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	initial, err := time.ParseDuration(cs.Storage.Model().HTTPRetry.InitialBackoff)
+	if err != nil {
+		initial = 1 * time.Second
+	}
+
+	repeated, err := time.ParseDuration(cs.Storage.Model().HTTPRetry.RepeatedBackoff)
+	if err != nil {
+		repeated = 2 * time.Second
+	}
+
+	final, err := time.ParseDuration(cs.Storage.Model().HTTPRetry.FinalBackoff)
+	if err != nil {
+		final = 4 * time.Second
+	}
+
+	// Configs stored before retries were introduced have no attempts set, while a single attempt disables retries.
+	maxAttempts := cs.Storage.Model().HTTPRetry.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = 3
+	}
+
+	return HTTPRetryCfg{
+		BackoffCfg: BackoffCfg{
+			InitialBackoff:       initial,
+			RepeatedBackoff:      repeated,
+			FinalBackoff:         final,
+			InitialFailureCount:  cs.Storage.Model().HTTPRetry.InitialFailureCount,
+			RepeatedFailureCount: cs.Storage.Model().HTTPRetry.RepeatedFailureCount,
+		},
+		MaxAttempts: maxAttempts,
+	}
+}
+
+// SetHTTPRetryCfg allows to safely set and persist retry settings of the HTTP client.
+func (cs *Service) SetHTTPRetryCfg(cfg HTTPRetryCfg) error {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	cs.Storage.Model().ConfiguredAt = time.Now().Format(time.RFC3339)
+	cs.Storage.Model().HTTPRetry = httpRetryCfg{
+		backoffCfg: backoffCfg{
+			InitialBackoff:       cfg.InitialBackoff.String(),
+			RepeatedBackoff:      cfg.RepeatedBackoff.String(),
+			FinalBackoff:         cfg.FinalBackoff.String(),
+			InitialFailureCount:  cfg.InitialFailureCount,
+			RepeatedFailureCount: cfg.RepeatedFailureCount,
+		},
+		MaxAttempts: cfg.MaxAttempts,
+	}
+
+	return cs.Storage.Save()
+}
+
+// GetRateLimitsCfg allows to safely access rate limits of the Easee API.
+func (cs *Service) GetRateLimitsCfg() RateLimitsCfg {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+
+	if cs.Storage.Model().RateLimits == nil {
+		return RateLimitsCfg{
+			Commands: RateLimitCfg{RequestsPerMinute: 10, Burst: 5},
+			Settings: RateLimitCfg{RequestsPerMinute: 30, Burst: 10},
+			Reads:    RateLimitCfg{RequestsPerMinute: 60, Burst: 20},
+		}
+	}
+
+	return *cs.Storage.Model().RateLimits
+}
+
+// SetRateLimitsCfg allows to safely set and persist rate limits of the Easee API.
+func (cs *Service) SetRateLimitsCfg(cfg RateLimitsCfg) error {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	cs.Storage.Model().ConfiguredAt = time.Now().Format(time.RFC3339)
+	cs.Storage.Model().RateLimits = &cfg
+
+	return cs.Storage.Save()
+}
@@ -1,11 +1,15 @@
 package db
 
 import (
+	"fmt"
 	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/futurehomeno/cliffhanger/database"
+	"github.com/michalkurzeja/go-clock"
 	"github.com/pkg/errors"
 
 	"github.com/futurehomeno/edge-easee-adapter/internal/model"
@@ -13,6 +17,10 @@ import (
 
 const (
 	bucketNamePrefix = "charging-sessions:"
+	indexBucketName  = "charging-sessions-index"
+
+	// sessionKeyWidth is a width of zero-padded session keys, which keeps lexical order of keys equal to the numeric order of session IDs.
+	sessionKeyWidth = 20
 )
 
 // ChargingSessionStorage is service used to store charging sessions.
@@ -30,18 +38,50 @@ type ChargingSessionStorage interface {
 	RegisterSessionStop(chargerID string, session model.StopChargingSession) error
 	// LatestSessionsByChargerID returns latest and previous charging sessions by chargerID.
 	LatestSessionsByChargerID(chargerID string) (ChargingSessions, error)
+	// SessionsByChargerID returns charging sessions started within the [from, to) time range, latest first.
+	// Results are paginated with offset and limit, a non-positive limit means no limit.
+	SessionsByChargerID(chargerID string, from, to time.Time, limit, offset int) (ChargingSessions, error)
+	// MergeSessions stores charging sessions missing in the storage and completes stored sessions which are still in progress.
+	// Returns the number of stored or updated sessions.
+	MergeSessions(chargerID string, sessions ChargingSessions) (int, error)
+	// ApplyRetentionPolicy removes charging sessions exceeding the retention policy for all chargers.
+	ApplyRetentionPolicy(policy RetentionPolicy) error
+	// UpdateSessionCost adds the cost of energy consumed within the charging session since the last update up to the provided meter value.
+	UpdateSessionCost(chargerID string, sessionID int64, meterValue float64, at time.Time, pricer Pricer) error
+}
+
+// Pricer provides energy prices used to calculate the cost of charging sessions.
+type Pricer interface {
+	// PriceAt returns the energy price valid at the provided time. Returns false if the price is unknown.
+	PriceAt(at time.Time) (float64, bool)
+}
+
+// RetentionPolicy defines how long and how many charging sessions are kept per charger.
+// Non-positive values disable the respective limit. Latest and previous sessions are always kept.
+type RetentionPolicy struct {
+	MaxAge   time.Duration
+	MaxCount int
 }
 
 type sessionStorage struct {
+	mu sync.Mutex
 	db database.Database
 }
 
 func NewSessionStorage(db database.Database) ChargingSessionStorage {
-	return &sessionStorage{db}
+	return &sessionStorage{db: db}
 }
 
 func (s *sessionStorage) Start() error {
-	return s.db.Start()
+	if err := s.db.Start(); err != nil {
+		return err
+	}
+
+	if err := s.buildMissingIndexes(); err != nil {
+		return errors.Wrap(err, "start: can't build charging sessions index")
+	}
+
+	return nil
 }
 
 func (s *sessionStorage) Stop() error {
@@ -53,71 +93,156 @@ func (s *sessionStorage) Reset() error {
 }
 
 func (s *sessionStorage) RegisterSessionStart(chargerID string, session model.StartChargingSession) error {
-	sessions, err := s.LatestSessionsByChargerID(chargerID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx, err := s.index(chargerID)
 	if err != nil {
-		return errors.Wrap(err, "register start session: can't get last charging session")
+		return errors.Wrap(err, "register start session: can't get charging sessions index")
 	}
 
 	bucket := s.bucketName(chargerID)
-	latest := sessions.Latest()
 
-	if latest != nil && latest.Stop.IsZero() {
+	latest, err := s.session(bucket, idx.Latest)
+	if err != nil {
+		return errors.Wrap(err, "register start session: can't get last charging session")
+	}
+
+	if latest != nil && latest.ID != session.ID && latest.Stop.IsZero() {
 		latest.Stop = session.Start
 
-		err = s.db.Set(bucket, latest.IDString(), latest)
+		err = s.db.Set(bucket, sessionKey(latest.ID), latest)
 		if err != nil {
 			return errors.Wrap(err, "register start session: can't update previous charging session")
 		}
 	}
 
-	return s.db.Set(bucket, session.IDString(), ChargingSession{
-		ID:    session.ID,
-		Start: session.Start,
+	err = s.db.Set(bucket, sessionKey(session.ID), ChargingSession{
+		ID:              session.ID,
+		Start:           session.Start,
+		MeterValueStart: session.MeterValue,
+		AuthToken:       session.Auth,
 	})
+	if err != nil {
+		return errors.Wrap(err, "register start session: can't save charging session")
+	}
+
+	return s.updateIndex(chargerID, idx, session.ID)
 }
 
 func (s *sessionStorage) RegisterSessionStop(chargerID string, session model.StopChargingSession) error {
-	return s.db.Set(s.bucketName(chargerID), session.IDString(), ChargingSession{
-		ID:     session.ID,
-		Start:  session.Start,
-		Stop:   session.Stop,
-		Energy: session.Energy,
-	})
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx, err := s.index(chargerID)
+	if err != nil {
+		return errors.Wrap(err, "register stop session: can't get charging sessions index")
+	}
+
+	bucket := s.bucketName(chargerID)
+
+	stored, err := s.session(bucket, session.ID)
+	if err != nil {
+		return errors.Wrap(err, "register stop session: can't get charging session")
+	}
+
+	stopped := ChargingSession{
+		ID:              session.ID,
+		Start:           session.Start,
+		Stop:            session.Stop,
+		Energy:          session.Energy,
+		MeterValueStart: session.MeterValueStart,
+		MeterValueStop:  session.MeterValueStop,
+		AuthToken:       session.Auth,
+	}
+
+	// Cost accumulated while charging must be kept.
+	if stored != nil {
+		stopped.Cost = stored.Cost
+		stopped.CostMeterValue = stored.CostMeterValue
+		stopped.CostUpdatedAt = stored.CostUpdatedAt
+
+		if stopped.AuthToken == "" {
+			stopped.AuthToken = stored.AuthToken
+		}
+	}
+
+	err = s.db.Set(bucket, sessionKey(session.ID), stopped)
+	if err != nil {
+		return errors.Wrap(err, "register stop session: can't save charging session")
+	}
+
+	return s.updateIndex(chargerID, idx, session.ID)
 }
 
 func (s *sessionStorage) LatestSessionsByChargerID(chargerID string) (ChargingSessions, error) {
-	bucket := s.bucketName(chargerID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	stringKeys, err := s.db.Keys(bucket)
+	idx, err := s.index(chargerID)
 	if err != nil {
 		return nil, err
 	}
 
-	keys := make([]int, 0, len(stringKeys))
+	bucket := s.bucketName(chargerID)
+	sessions := make(ChargingSessions, 0, 2) // latest and previous
 
-	for _, k := range stringKeys {
-		key, _ := strconv.Atoi(k)
-		keys = append(keys, key)
+	for _, id := range []int64{idx.Latest, idx.Previous} {
+		session, err := s.session(bucket, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if session == nil {
+			break
+		}
+
+		sessions = append(sessions, session)
 	}
 
-	// Sort keys (session IDs) in descending order.
-	sort.Slice(keys, func(i, j int) bool {
-		return keys[i] > keys[j]
-	})
+	return sessions, nil
+}
 
-	sessions := make(ChargingSessions, 0, 2) // latest and previous
+func (s *sessionStorage) SessionsByChargerID(chargerID string, from, to time.Time, limit, offset int) (ChargingSessions, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	for _, k := range keys {
+	// Ensures legacy keys are migrated, so the keys are sorted.
+	if _, err := s.index(chargerID); err != nil {
+		return nil, err
+	}
+
+	bucket := s.bucketName(chargerID)
+
+	keys, err := s.db.Keys(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions ChargingSessions
+
+	// Iterate over keys (session IDs) in descending order.
+	for i := len(keys) - 1; i >= 0; i-- {
 		var session *ChargingSession
 
-		ok, err := s.db.Get(bucket, strconv.Itoa(k), &session)
-		if !ok || err != nil {
-			return nil, err
+		ok, err := s.db.Get(bucket, keys[i], &session)
+		if err != nil {
+			return nil, errors.Wrapf(err, "sessions by charger ID: can't get charging session %s", keys[i])
+		}
+
+		if !ok || session.Start.Before(from) || !session.Start.Before(to) {
+			continue
+		}
+
+		if offset > 0 {
+			offset--
+
+			continue
 		}
 
 		sessions = append(sessions, session)
 
-		if len(sessions) == 2 {
+		if limit > 0 && len(sessions) == limit {
 			break
 		}
 	}
@@ -125,21 +250,348 @@ func (s *sessionStorage) LatestSessionsByChargerID(chargerID string) (ChargingSe
 	return sessions, nil
 }
 
+func (s *sessionStorage) MergeSessions(chargerID string, sessions ChargingSessions) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx, err := s.index(chargerID)
+	if err != nil {
+		return 0, errors.Wrap(err, "merge sessions: can't get charging sessions index")
+	}
+
+	bucket := s.bucketName(chargerID)
+	merged := 0
+
+	for _, session := range sessions {
+		stored, err := s.session(bucket, session.ID)
+		if err != nil {
+			return merged, errors.Wrapf(err, "merge sessions: can't get charging session %d", session.ID)
+		}
+
+		switch {
+		case stored == nil:
+			stored = session
+		case stored.Stop.IsZero() && !session.Stop.IsZero():
+			stored.Stop = session.Stop
+			stored.Energy = session.Energy
+		default:
+			continue
+		}
+
+		if err := s.db.Set(bucket, sessionKey(stored.ID), stored); err != nil {
+			return merged, errors.Wrapf(err, "merge sessions: can't save charging session %d", stored.ID)
+		}
+
+		if err := s.updateIndex(chargerID, idx, stored.ID); err != nil {
+			return merged, errors.Wrap(err, "merge sessions: can't update charging sessions index")
+		}
+
+		merged++
+	}
+
+	return merged, nil
+}
+
+func (s *sessionStorage) UpdateSessionCost(chargerID string, sessionID int64, meterValue float64, at time.Time, pricer Pricer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket := s.bucketName(chargerID)
+
+	session, err := s.session(bucket, sessionID)
+	if err != nil {
+		return errors.Wrapf(err, "update session cost: can't get charging session %d", sessionID)
+	}
+
+	if session == nil {
+		return nil
+	}
+
+	since, sinceMeterValue := session.Start, session.MeterValueStart
+	if !session.CostUpdatedAt.IsZero() {
+		since, sinceMeterValue = session.CostUpdatedAt, session.CostMeterValue
+	}
+
+	// Sessions without a known starting meter value, e.g. reconciled from the API, can't be priced.
+	if sinceMeterValue <= 0 || meterValue <= sinceMeterValue || !at.After(since) {
+		return nil
+	}
+
+	// Energy readings are hourly, so the whole consumption since the last update is priced at the start of the interval.
+	// Consumption without a known price is kept unpriced until the price is known, e.g. once hourly prices are updated.
+	price, ok := pricer.PriceAt(since)
+	if !ok {
+		return nil
+	}
+
+	session.Cost += (meterValue - sinceMeterValue) * price
+	session.CostMeterValue = meterValue
+	session.CostUpdatedAt = at
+
+	if err := s.db.Set(bucket, sessionKey(session.ID), session); err != nil {
+		return errors.Wrapf(err, "update session cost: can't save charging session %d", sessionID)
+	}
+
+	return nil
+}
+
+func (s *sessionStorage) ApplyRetentionPolicy(policy RetentionPolicy) error {
+	if policy.MaxAge <= 0 && policy.MaxCount <= 0 {
+		return nil
+	}
+
+	// Every charger with stored sessions is indexed, because the missing indexes are built on start.
+	chargerIDs, err := s.db.Keys(indexBucketName)
+	if err != nil {
+		return errors.Wrap(err, "apply retention policy: can't get charger IDs")
+	}
+
+	for _, chargerID := range chargerIDs {
+		if err := s.applyRetentionPolicy(chargerID, policy); err != nil {
+			return errors.Wrapf(err, "apply retention policy: charger %s", chargerID)
+		}
+	}
+
+	return nil
+}
+
+func (s *sessionStorage) applyRetentionPolicy(chargerID string, policy RetentionPolicy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx, err := s.index(chargerID)
+	if err != nil {
+		return err
+	}
+
+	bucket := s.bucketName(chargerID)
+
+	keys, err := s.db.Keys(bucket)
+	if err != nil {
+		return err
+	}
+
+	cutoff := clock.Now().Add(-policy.MaxAge)
+
+	// Keys are sorted in ascending order, so the oldest sessions are removed first.
+	for i, key := range keys {
+		id, _ := strconv.ParseInt(key, 10, 64)
+		if idx.contains(id) {
+			break
+		}
+
+		if policy.MaxCount <= 0 || len(keys)-i <= policy.MaxCount {
+			if policy.MaxAge <= 0 {
+				break
+			}
+
+			session, err := s.session(bucket, id)
+			if err != nil {
+				return err
+			}
+
+			if session != nil && !session.finishedAt().Before(cutoff) {
+				break
+			}
+		}
+
+		if err := s.db.Delete(bucket, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildMissingIndexes builds the index of chargers with sessions stored before the index was introduced.
+func (s *sessionStorage) buildMissingIndexes() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Keys of all session buckets are returned in the "<chargerID>:<sessionKey>" format.
+	keys, err := s.db.Keys(strings.TrimSuffix(bucketNamePrefix, ":"))
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]struct{})
+
+	for _, key := range keys {
+		i := strings.LastIndex(key, ":")
+		if i < 0 {
+			continue
+		}
+
+		chargerID := key[:i]
+		if _, ok := seen[chargerID]; ok {
+			continue
+		}
+
+		seen[chargerID] = struct{}{}
+
+		if _, err := s.index(chargerID); err != nil {
+			return errors.Wrapf(err, "charger %s", chargerID)
+		}
+	}
+
+	return nil
+}
+
+// index returns the charging sessions index of the charger, building it if it does not exist yet.
+func (s *sessionStorage) index(chargerID string) (*sessionIndex, error) {
+	idx := &sessionIndex{}
+
+	ok, err := s.db.Get(indexBucketName, chargerID, idx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return idx, nil
+	}
+
+	return s.rebuildIndex(chargerID)
+}
+
+// rebuildIndex migrates legacy session keys to the zero-padded format and builds the charging sessions index from scratch.
+func (s *sessionStorage) rebuildIndex(chargerID string) (*sessionIndex, error) {
+	bucket := s.bucketName(chargerID)
+
+	stringKeys, err := s.db.Keys(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(stringKeys))
+
+	for _, k := range stringKeys {
+		id, err := strconv.ParseInt(k, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		ids = append(ids, id)
+
+		if len(k) == sessionKeyWidth {
+			continue
+		}
+
+		if err := s.migrateKey(bucket, k, id); err != nil {
+			return nil, errors.Wrapf(err, "can't migrate charging session %s", k)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] > ids[j]
+	})
+
+	idx := &sessionIndex{}
+
+	if len(ids) > 0 {
+		idx.Latest = ids[0]
+	}
+
+	if len(ids) > 1 {
+		idx.Previous = ids[1]
+	}
+
+	if err := s.db.Set(indexBucketName, chargerID, idx); err != nil {
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+func (s *sessionStorage) migrateKey(bucket, key string, id int64) error {
+	var session *ChargingSession
+
+	ok, err := s.db.Get(bucket, key, &session)
+	if err != nil || !ok {
+		return err
+	}
+
+	if err := s.db.Set(bucket, sessionKey(id), session); err != nil {
+		return err
+	}
+
+	return s.db.Delete(bucket, key)
+}
+
+func (s *sessionStorage) updateIndex(chargerID string, idx *sessionIndex, id int64) error {
+	switch {
+	case idx.contains(id):
+		return nil
+	case id > idx.Latest:
+		idx.Previous = idx.Latest
+		idx.Latest = id
+	case id > idx.Previous:
+		idx.Previous = id
+	default:
+		return nil
+	}
+
+	return s.db.Set(indexBucketName, chargerID, idx)
+}
+
+// session returns a charging session by its ID or nil if it does not exist.
+func (s *sessionStorage) session(bucket string, id int64) (*ChargingSession, error) {
+	if id == 0 {
+		return nil, nil
+	}
+
+	var session *ChargingSession
+
+	ok, err := s.db.Get(bucket, sessionKey(id), &session)
+	if !ok || err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
 func (s *sessionStorage) bucketName(chargerID string) string {
 	return bucketNamePrefix + chargerID
 }
 
+func sessionKey(id int64) string {
+	return fmt.Sprintf("%0*d", sessionKeyWidth, id)
+}
+
+// sessionIndex keeps IDs of the latest and previous charging sessions of a charger.
+type sessionIndex struct {
+	Latest   int64 `json:"latest"`
+	Previous int64 `json:"previous"`
+}
+
+func (i *sessionIndex) contains(id int64) bool {
+	return id != 0 && (id == i.Latest || id == i.Previous)
+}
+
 type ChargingSession struct {
-	ID     int64     `json:"id"`
-	Start  time.Time `json:"start"`
-	Stop   time.Time `json:"stop"`
-	Energy float64   `json:"energy"`
+	ID              int64     `json:"id"`
+	Start           time.Time `json:"start"`
+	Stop            time.Time `json:"stop"`
+	Energy          float64   `json:"energy"`
+	MeterValueStart float64   `json:"meterValueStart"`
+	MeterValueStop  float64   `json:"meterValueStop"`
+	Cost            float64   `json:"cost"`
+	CostMeterValue  float64   `json:"costMeterValue"`
+	CostUpdatedAt   time.Time `json:"costUpdatedAt,omitzero"`
+	AuthToken       string    `json:"authToken,omitempty"`
 }
 
 func (s *ChargingSession) IDString() string {
 	return strconv.FormatInt(s.ID, 10)
 }
 
+func (s *ChargingSession) finishedAt() time.Time {
+	if s.Stop.IsZero() {
+		return s.Start
+	}
+
+	return s.Stop
+}
+
 type ChargingSessions []*ChargingSession
 
 func (c ChargingSessions) Latest() *ChargingSession {
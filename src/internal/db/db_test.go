@@ -1,10 +1,12 @@
 package db_test
 
 import (
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/futurehomeno/cliffhanger/database"
+	"github.com/michalkurzeja/go-clock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -18,7 +20,8 @@ type SessionStorageSuite struct {
 
 	chargerID string
 
-	storage db.ChargingSessionStorage
+	database database.Database
+	storage  db.ChargingSessionStorage
 }
 
 func TestSessionStorageSuite(t *testing.T) { //nolint:paralleltest
@@ -33,6 +36,7 @@ func (suite *SessionStorageSuite) SetupTest() {
 	fileDB, err := database.NewDatabase(suite.T().TempDir())
 	suite.Require().NoError(err)
 
+	suite.database = fileDB
 	suite.storage = db.NewSessionStorage(fileDB)
 }
 
@@ -42,6 +46,7 @@ func (suite *SessionStorageSuite) TestRegister_StartAndStopSession() {
 
 	err := suite.storage.RegisterSessionStart(suite.chargerID, model.StartChargingSession{
 		ID:         1,
+		Auth:       "nfc-AB12CD34",
 		Start:      startTime,
 		MeterValue: 10,
 	})
@@ -52,10 +57,13 @@ func (suite *SessionStorageSuite) TestRegister_StartAndStopSession() {
 
 	suite.NotEmpty(got)
 	suite.Equal(&db.ChargingSession{
-		ID:    1,
-		Start: startTime,
+		ID:              1,
+		Start:           startTime,
+		MeterValueStart: 10,
+		AuthToken:       "nfc-AB12CD34",
 	}, got.Latest())
 
+	// Authorizing token is kept even if it's missing in the stop observation.
 	err = suite.storage.RegisterSessionStop(suite.chargerID, model.StopChargingSession{
 		ID:              1,
 		Start:           startTime,
@@ -71,10 +79,13 @@ func (suite *SessionStorageSuite) TestRegister_StartAndStopSession() {
 
 	suite.NotEmpty(got)
 	suite.Equal(&db.ChargingSession{
-		ID:     1,
-		Start:  startTime,
-		Stop:   stopTime,
-		Energy: 10,
+		ID:              1,
+		Start:           startTime,
+		Stop:            stopTime,
+		Energy:          10,
+		MeterValueStart: 10,
+		MeterValueStop:  20,
+		AuthToken:       "nfc-AB12CD34",
 	}, got.Latest())
 
 	suite.Nil(got.Previous())
@@ -96,8 +107,9 @@ func (suite *SessionStorageSuite) TestRegister_TwoConsecutiveSessionStarts() {
 
 	suite.NotEmpty(got)
 	suite.Equal(&db.ChargingSession{
-		ID:    1,
-		Start: startTime1,
+		ID:              1,
+		Start:           startTime1,
+		MeterValueStart: 10,
 	}, got.Latest())
 
 	err = suite.storage.RegisterSessionStart(suite.chargerID, model.StartChargingSession{
@@ -112,14 +124,16 @@ func (suite *SessionStorageSuite) TestRegister_TwoConsecutiveSessionStarts() {
 
 	suite.NotEmpty(got)
 	suite.Equal(&db.ChargingSession{
-		ID:    1,
-		Start: startTime1,
-		Stop:  startTime2, // Start time of a new session is treated as a stop time of the previous session.
+		ID:              1,
+		Start:           startTime1,
+		Stop:            startTime2, // Start time of a new session is treated as a stop time of the previous session.
+		MeterValueStart: 10,
 	}, got.Previous())
 
 	suite.Equal(&db.ChargingSession{
-		ID:    2,
-		Start: startTime2,
+		ID:              2,
+		Start:           startTime2,
+		MeterValueStart: 10,
 	}, got.Latest())
 }
 
@@ -142,10 +156,12 @@ func (suite *SessionStorageSuite) TestRegister_TwoConsecutiveSessionStops() {
 
 	suite.NotEmpty(got)
 	suite.Equal(&db.ChargingSession{
-		ID:     1,
-		Start:  timeStart,
-		Stop:   timeStop,
-		Energy: 10,
+		ID:              1,
+		Start:           timeStart,
+		Stop:            timeStop,
+		Energy:          10,
+		MeterValueStart: 10,
+		MeterValueStop:  20,
 	}, got.Latest())
 
 	err = suite.storage.RegisterSessionStop(suite.chargerID, model.StopChargingSession{
@@ -163,20 +179,416 @@ func (suite *SessionStorageSuite) TestRegister_TwoConsecutiveSessionStops() {
 
 	suite.NotEmpty(got)
 	suite.Equal(&db.ChargingSession{
-		ID:     1,
-		Start:  timeStart,
-		Stop:   timeStop,
-		Energy: 10,
+		ID:              1,
+		Start:           timeStart,
+		Stop:            timeStop,
+		Energy:          10,
+		MeterValueStart: 10,
+		MeterValueStop:  20,
 	}, got.Previous())
 
 	suite.Equal(&db.ChargingSession{
-		ID:     2,
-		Start:  timeStart.Add(2 * time.Hour),
-		Stop:   timeStop.Add(2 * time.Hour),
-		Energy: 5,
+		ID:              2,
+		Start:           timeStart.Add(2 * time.Hour),
+		Stop:            timeStop.Add(2 * time.Hour),
+		Energy:          5,
+		MeterValueStart: 20,
+		MeterValueStop:  25,
 	}, got.Latest())
 }
 
+func (suite *SessionStorageSuite) TestSessionsByChargerID() {
+	timeStart := time.Date(1997, time.February, 17, 18, 0, 0, 0, time.UTC)
+
+	for i := range 5 {
+		err := suite.storage.RegisterSessionStop(suite.chargerID, model.StopChargingSession{
+			ID:              int64(i + 1),
+			Energy:          5,
+			MeterValueStart: float64(i * 5),
+			MeterValueStop:  float64((i + 1) * 5),
+			Start:           timeStart.Add(time.Duration(i) * 24 * time.Hour),
+			Stop:            timeStart.Add(time.Duration(i)*24*time.Hour + time.Hour),
+		})
+		suite.Require().NoError(err)
+	}
+
+	tests := []struct {
+		name   string
+		from   time.Time
+		to     time.Time
+		limit  int
+		offset int
+		want   []int64
+	}{
+		{
+			name: "all sessions",
+			from: timeStart,
+			to:   timeStart.Add(5 * 24 * time.Hour),
+			want: []int64{5, 4, 3, 2, 1},
+		},
+		{
+			name: "time range",
+			from: timeStart.Add(24 * time.Hour),
+			to:   timeStart.Add(3 * 24 * time.Hour),
+			want: []int64{3, 2},
+		},
+		{
+			name:  "limit",
+			from:  timeStart,
+			to:    timeStart.Add(5 * 24 * time.Hour),
+			limit: 2,
+			want:  []int64{5, 4},
+		},
+		{
+			name:   "limit and offset",
+			from:   timeStart,
+			to:     timeStart.Add(5 * 24 * time.Hour),
+			limit:  2,
+			offset: 2,
+			want:   []int64{3, 2},
+		},
+		{
+			name:   "offset past the end",
+			from:   timeStart,
+			to:     timeStart.Add(5 * 24 * time.Hour),
+			offset: 10,
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := suite.storage.SessionsByChargerID(suite.chargerID, tt.from, tt.to, tt.limit, tt.offset)
+			suite.NoError(err)
+
+			var ids []int64
+			for _, s := range got {
+				ids = append(ids, s.ID)
+			}
+
+			suite.Equal(tt.want, ids)
+		})
+	}
+
+	got, err := suite.storage.SessionsByChargerID(suite.chargerID, timeStart, timeStart.Add(time.Hour), 0, 0)
+	suite.NoError(err)
+	suite.Equal(db.ChargingSessions{
+		{
+			ID:              1,
+			Start:           timeStart,
+			Stop:            timeStart.Add(time.Hour),
+			Energy:          5,
+			MeterValueStart: 0,
+			MeterValueStop:  5,
+		},
+	}, got)
+}
+
+func (suite *SessionStorageSuite) TestLegacyKeysMigration() {
+	timeStart := time.Date(1997, time.February, 17, 18, 0, 0, 0, time.UTC)
+
+	// Legacy keys are not zero-padded, so their lexical order differs from the numeric one.
+	for _, id := range []int64{9, 10, 11} {
+		err := suite.database.Set("charging-sessions:"+suite.chargerID, strconv.FormatInt(id, 10), db.ChargingSession{
+			ID:     id,
+			Start:  timeStart.Add(time.Duration(id) * time.Hour),
+			Energy: float64(id),
+		})
+		suite.Require().NoError(err)
+	}
+
+	got, err := suite.storage.LatestSessionsByChargerID(suite.chargerID)
+	suite.NoError(err)
+	suite.Require().Len(got, 2)
+	suite.Equal(int64(11), got.Latest().ID)
+	suite.Equal(int64(10), got.Previous().ID)
+
+	keys, err := suite.database.Keys("charging-sessions:" + suite.chargerID)
+	suite.NoError(err)
+	suite.Equal([]string{
+		"00000000000000000009",
+		"00000000000000000010",
+		"00000000000000000011",
+	}, keys)
+
+	sessions, err := suite.storage.SessionsByChargerID(suite.chargerID, timeStart, timeStart.Add(24*time.Hour), 0, 0)
+	suite.NoError(err)
+	suite.Len(sessions, 3)
+	suite.Equal(int64(11), sessions.Latest().ID)
+}
+
+func (suite *SessionStorageSuite) TestMergeSessions() {
+	timeStart := time.Date(1997, time.February, 17, 18, 0, 0, 0, time.UTC)
+
+	err := suite.storage.RegisterSessionStop(suite.chargerID, model.StopChargingSession{
+		ID:              1,
+		Energy:          10,
+		MeterValueStart: 10,
+		MeterValueStop:  20,
+		Start:           timeStart,
+		Stop:            timeStart.Add(time.Hour),
+	})
+	suite.Require().NoError(err)
+
+	err = suite.storage.RegisterSessionStart(suite.chargerID, model.StartChargingSession{
+		ID:         2,
+		Start:      timeStart.Add(2 * time.Hour),
+		MeterValue: 20,
+	})
+	suite.Require().NoError(err)
+
+	merged, err := suite.storage.MergeSessions(suite.chargerID, db.ChargingSessions{
+		{
+			ID:    4,
+			Start: timeStart.Add(5 * time.Hour),
+		},
+		{
+			ID:     3,
+			Start:  timeStart.Add(4 * time.Hour),
+			Stop:   timeStart.Add(4*time.Hour + 30*time.Minute),
+			Energy: 3,
+		},
+		{
+			ID:     2,
+			Start:  timeStart.Add(2 * time.Hour),
+			Stop:   timeStart.Add(3 * time.Hour),
+			Energy: 5,
+		},
+		{
+			ID:     1,
+			Start:  timeStart,
+			Stop:   timeStart.Add(time.Hour),
+			Energy: 99,
+		},
+	})
+	suite.NoError(err)
+	suite.Equal(3, merged)
+
+	got, err := suite.storage.SessionsByChargerID(suite.chargerID, timeStart, timeStart.Add(24*time.Hour), 0, 0)
+	suite.NoError(err)
+	suite.Equal(db.ChargingSessions{
+		{
+			ID:    4,
+			Start: timeStart.Add(5 * time.Hour),
+		},
+		{
+			ID:     3,
+			Start:  timeStart.Add(4 * time.Hour),
+			Stop:   timeStart.Add(4*time.Hour + 30*time.Minute),
+			Energy: 3,
+		},
+		{
+			ID:              2,
+			Start:           timeStart.Add(2 * time.Hour),
+			Stop:            timeStart.Add(3 * time.Hour),
+			Energy:          5,
+			MeterValueStart: 20,
+		},
+		{
+			ID:              1,
+			Start:           timeStart,
+			Stop:            timeStart.Add(time.Hour),
+			Energy:          10,
+			MeterValueStart: 10,
+			MeterValueStop:  20,
+		},
+	}, got)
+
+	latest, err := suite.storage.LatestSessionsByChargerID(suite.chargerID)
+	suite.NoError(err)
+	suite.Equal(int64(4), latest.Latest().ID)
+	suite.Equal(int64(3), latest.Previous().ID)
+}
+
+func (suite *SessionStorageSuite) TestApplyRetentionPolicy() {
+	timeStart := time.Date(1997, time.February, 17, 18, 0, 0, 0, time.UTC)
+
+	clock.Mock(timeStart.Add(10 * 24 * time.Hour))
+	suite.T().Cleanup(clock.Restore)
+
+	registerSessions := func(chargerID string) {
+		for i := range 5 {
+			err := suite.storage.RegisterSessionStop(chargerID, model.StopChargingSession{
+				ID:     int64(i + 1),
+				Energy: 5,
+				Start:  timeStart.Add(time.Duration(i) * 24 * time.Hour),
+				Stop:   timeStart.Add(time.Duration(i)*24*time.Hour + time.Hour),
+			})
+			suite.Require().NoError(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		policy db.RetentionPolicy
+		want   []int64
+	}{
+		{
+			name:   "no limits",
+			policy: db.RetentionPolicy{},
+			want:   []int64{5, 4, 3, 2, 1},
+		},
+		{
+			name:   "max count",
+			policy: db.RetentionPolicy{MaxCount: 3},
+			want:   []int64{5, 4, 3},
+		},
+		{
+			name:   "max age",
+			policy: db.RetentionPolicy{MaxAge: 7 * 24 * time.Hour},
+			want:   []int64{5, 4},
+		},
+		{
+			name:   "max count and max age",
+			policy: db.RetentionPolicy{MaxAge: 7 * 24 * time.Hour, MaxCount: 4},
+			want:   []int64{5, 4},
+		},
+		{
+			name:   "latest and previous sessions are always kept",
+			policy: db.RetentionPolicy{MaxAge: time.Hour, MaxCount: 1},
+			want:   []int64{5, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.SetupTest()
+
+			registerSessions(suite.chargerID)
+			registerSessions("YY12345")
+
+			err := suite.storage.ApplyRetentionPolicy(tt.policy)
+			suite.NoError(err)
+
+			for _, chargerID := range []string{suite.chargerID, "YY12345"} {
+				got, err := suite.storage.SessionsByChargerID(chargerID, timeStart, clock.Now(), 0, 0)
+				suite.NoError(err)
+
+				var ids []int64
+				for _, s := range got {
+					ids = append(ids, s.ID)
+				}
+
+				suite.Equal(tt.want, ids)
+			}
+		})
+	}
+}
+
+func (suite *SessionStorageSuite) TestApplyRetentionPolicy_WithoutIndex() {
+	timeStart := time.Date(1997, time.February, 17, 18, 0, 0, 0, time.UTC)
+
+	// Sessions stored before the index was introduced are not listed in the index bucket.
+	for _, id := range []int64{9, 10, 11, 12} {
+		err := suite.database.Set("charging-sessions:"+suite.chargerID, strconv.FormatInt(id, 10), db.ChargingSession{
+			ID:     id,
+			Start:  timeStart.Add(time.Duration(id) * time.Hour),
+			Stop:   timeStart.Add(time.Duration(id)*time.Hour + 30*time.Minute),
+			Energy: float64(id),
+		})
+		suite.Require().NoError(err)
+	}
+
+	// The missing index is built on start.
+	err := suite.storage.Start()
+	suite.Require().NoError(err)
+
+	err = suite.storage.ApplyRetentionPolicy(db.RetentionPolicy{MaxCount: 2})
+	suite.NoError(err)
+
+	keys, err := suite.database.Keys("charging-sessions:" + suite.chargerID)
+	suite.NoError(err)
+	suite.Equal([]string{
+		"00000000000000000011",
+		"00000000000000000012",
+	}, keys)
+}
+
+func (suite *SessionStorageSuite) TestUpdateSessionCost() {
+	timeStart := time.Date(1997, time.February, 17, 18, 30, 0, 0, time.UTC)
+	tariff := &model.Tariff{
+		Type: model.TariffTypeHourly,
+		Prices: []model.HourlyPrice{
+			{Start: timeStart.Truncate(time.Hour), Price: 1},
+			{Start: timeStart.Truncate(time.Hour).Add(time.Hour), Price: 2},
+		},
+	}
+
+	err := suite.storage.RegisterSessionStart(suite.chargerID, model.StartChargingSession{
+		ID:         1,
+		Start:      timeStart,
+		MeterValue: 10,
+	})
+	suite.Require().NoError(err)
+
+	// 5 kWh charged within the first hour at the price of 1.
+	err = suite.storage.UpdateSessionCost(suite.chargerID, 1, 15, timeStart.Add(30*time.Minute), tariff)
+	suite.NoError(err)
+
+	// Outdated readings are ignored.
+	err = suite.storage.UpdateSessionCost(suite.chargerID, 1, 12, timeStart.Add(45*time.Minute), tariff)
+	suite.NoError(err)
+
+	err = suite.storage.RegisterSessionStop(suite.chargerID, model.StopChargingSession{
+		ID:              1,
+		Start:           timeStart,
+		Stop:            timeStart.Add(time.Hour),
+		MeterValueStart: 10,
+		MeterValueStop:  18,
+		Energy:          8,
+	})
+	suite.NoError(err)
+
+	// 3 kWh charged within the second hour at the price of 2.
+	err = suite.storage.UpdateSessionCost(suite.chargerID, 1, 18, timeStart.Add(time.Hour), tariff)
+	suite.NoError(err)
+
+	got, err := suite.storage.LatestSessionsByChargerID(suite.chargerID)
+	suite.NoError(err)
+	suite.Equal(&db.ChargingSession{
+		ID:              1,
+		Start:           timeStart,
+		Stop:            timeStart.Add(time.Hour),
+		Energy:          8,
+		MeterValueStart: 10,
+		MeterValueStop:  18,
+		Cost:            11,
+		CostMeterValue:  18,
+		CostUpdatedAt:   timeStart.Add(time.Hour),
+	}, got.Latest())
+
+	// Missing sessions are ignored.
+	err = suite.storage.UpdateSessionCost(suite.chargerID, 2, 20, timeStart.Add(2*time.Hour), tariff)
+	suite.NoError(err)
+}
+
+func (suite *SessionStorageSuite) TestUpdateSessionCost_UnknownPrice() {
+	timeStart := time.Date(1997, time.February, 17, 18, 0, 0, 0, time.UTC)
+	tariff := &model.Tariff{Type: model.TariffTypeHourly, Prices: []model.HourlyPrice{{Start: timeStart.Add(-time.Hour), Price: 1}}}
+
+	err := suite.storage.RegisterSessionStart(suite.chargerID, model.StartChargingSession{
+		ID:         1,
+		Start:      timeStart,
+		MeterValue: 10,
+	})
+	suite.Require().NoError(err)
+
+	// Consumption without a known price is not skipped.
+	err = suite.storage.UpdateSessionCost(suite.chargerID, 1, 15, timeStart.Add(30*time.Minute), tariff)
+	suite.NoError(err)
+
+	tariff.Prices = append(tariff.Prices, model.HourlyPrice{Start: timeStart, Price: 2})
+
+	err = suite.storage.UpdateSessionCost(suite.chargerID, 1, 16, timeStart.Add(45*time.Minute), tariff)
+	suite.NoError(err)
+
+	got, err := suite.storage.LatestSessionsByChargerID(suite.chargerID)
+	suite.NoError(err)
+	suite.InDelta(12, got.Latest().Cost, 0.001)
+	suite.InDelta(16, got.Latest().CostMeterValue, 0)
+	suite.Equal(timeStart.Add(45*time.Minute), got.Latest().CostUpdatedAt)
+}
+
 func (suite *SessionStorageSuite) TestGetSessionNonExistChargerID() {
 	result, err := suite.storage.LatestSessionsByChargerID(suite.chargerID)
 
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"github.com/futurehomeno/cliffhanger/database"
+	"github.com/pkg/errors"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+const (
+	departureBucketName     = "departure-targets"
+	departureModeBucketName = "departure-modes"
+)
+
+// DepartureTargetStorage is service used to store departure targets.
+type DepartureTargetStorage interface {
+	// DepartureTargetByChargerID returns the departure target of the charger, nil is returned if none is set.
+	DepartureTargetByChargerID(chargerID string) (*model.DepartureTarget, error)
+	// SetDepartureTarget stores the departure target of the charger, replacing the existing one.
+	SetDepartureTarget(chargerID string, target model.DepartureTarget) error
+	// DepartureModeByChargerID returns the active charging mode following the departure target, empty if none is active.
+	DepartureModeByChargerID(chargerID string) (string, error)
+	// SetDepartureMode stores the active charging mode following the departure target, an empty mode clears it.
+	SetDepartureMode(chargerID, mode string) error
+}
+
+type departureStorage struct {
+	db database.Database
+}
+
+// NewDepartureStorage returns a new instance of DepartureTargetStorage.
+// Lifecycle of the database is expected to be managed by its owner.
+func NewDepartureStorage(db database.Database) DepartureTargetStorage {
+	return &departureStorage{db: db}
+}
+
+func (s *departureStorage) DepartureTargetByChargerID(chargerID string) (*model.DepartureTarget, error) {
+	target := &model.DepartureTarget{}
+
+	ok, err := s.db.Get(departureBucketName, chargerID, target)
+	if err != nil {
+		return nil, errors.Wrap(err, "departure target by charger ID: can't get departure target")
+	}
+
+	if !ok {
+		return nil, nil
+	}
+
+	return target, nil
+}
+
+func (s *departureStorage) SetDepartureTarget(chargerID string, target model.DepartureTarget) error {
+	if err := s.db.Set(departureBucketName, chargerID, target); err != nil {
+		return errors.Wrap(err, "set departure target: can't save departure target")
+	}
+
+	return nil
+}
+
+func (s *departureStorage) DepartureModeByChargerID(chargerID string) (string, error) {
+	var mode string
+
+	if _, err := s.db.Get(departureModeBucketName, chargerID, &mode); err != nil {
+		return "", errors.Wrap(err, "departure mode by charger ID: can't get departure mode")
+	}
+
+	return mode, nil
+}
+
+func (s *departureStorage) SetDepartureMode(chargerID, mode string) error {
+	if mode == "" {
+		stored, err := s.DepartureModeByChargerID(chargerID)
+		if err != nil || stored == "" {
+			return err
+		}
+
+		if err := s.db.Delete(departureModeBucketName, chargerID); err != nil {
+			return errors.Wrap(err, "set departure mode: can't clear departure mode")
+		}
+
+		return nil
+	}
+
+	if err := s.db.Set(departureModeBucketName, chargerID, mode); err != nil {
+		return errors.Wrap(err, "set departure mode: can't save departure mode")
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package db_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/futurehomeno/cliffhanger/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/db"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+func TestDepartureStorage(t *testing.T) {
+	t.Parallel()
+
+	fileDB, err := database.NewDatabase(t.TempDir())
+	require.NoError(t, err)
+
+	storage := db.NewDepartureStorage(fileDB)
+	target := model.DepartureTarget{Energy: 40, DepartureAt: time.Date(2025, time.January, 21, 7, 0, 0, 0, time.UTC)}
+
+	got, err := storage.DepartureTargetByChargerID("XX12345")
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	err = storage.SetDepartureTarget("XX12345", target)
+	assert.NoError(t, err)
+
+	got, err = storage.DepartureTargetByChargerID("XX12345")
+	assert.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, target.Energy, got.Energy)
+	assert.True(t, target.DepartureAt.Equal(got.DepartureAt))
+
+	got, err = storage.DepartureTargetByChargerID("YY12345")
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	mode, err := storage.DepartureModeByChargerID("XX12345")
+	assert.NoError(t, err)
+	assert.Empty(t, mode)
+
+	err = storage.SetDepartureMode("XX12345", model.ChargingModeCheapest)
+	assert.NoError(t, err)
+
+	mode, err = storage.DepartureModeByChargerID("XX12345")
+	assert.NoError(t, err)
+	assert.Equal(t, model.ChargingModeCheapest, mode)
+
+	err = storage.SetDepartureMode("XX12345", "")
+	assert.NoError(t, err)
+
+	// Clearing the mode which is not set is a no-op.
+	err = storage.SetDepartureMode("XX12345", "")
+	assert.NoError(t, err)
+
+	mode, err = storage.DepartureModeByChargerID("XX12345")
+	assert.NoError(t, err)
+	assert.Empty(t, mode)
+}
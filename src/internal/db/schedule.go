@@ -0,0 +1,68 @@
+package db
+
+import (
+	"github.com/futurehomeno/cliffhanger/database"
+	"github.com/pkg/errors"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+const scheduleBucketName = "charging-schedules"
+
+// ChargingScheduleStorage is service used to store charging schedules.
+type ChargingScheduleStorage interface {
+	// ScheduleByChargerID returns the charging schedule of the charger, an empty schedule is returned if none is set.
+	ScheduleByChargerID(chargerID string) (model.ChargingSchedule, error)
+	// SetSchedule stores the charging schedule of the charger, replacing the existing one.
+	SetSchedule(chargerID string, schedule model.ChargingSchedule) error
+	// ClearSchedule removes the charging schedule of the charger.
+	ClearSchedule(chargerID string) error
+}
+
+type scheduleStorage struct {
+	db database.Database
+}
+
+// NewScheduleStorage returns a new instance of ChargingScheduleStorage.
+// Lifecycle of the database is expected to be managed by its owner.
+func NewScheduleStorage(db database.Database) ChargingScheduleStorage {
+	return &scheduleStorage{db: db}
+}
+
+func (s *scheduleStorage) ScheduleByChargerID(chargerID string) (model.ChargingSchedule, error) {
+	schedule := model.ChargingSchedule{}
+
+	_, err := s.db.Get(scheduleBucketName, chargerID, &schedule)
+	if err != nil {
+		return nil, errors.Wrap(err, "schedule by charger ID: can't get charging schedule")
+	}
+
+	return schedule, nil
+}
+
+func (s *scheduleStorage) SetSchedule(chargerID string, schedule model.ChargingSchedule) error {
+	if err := s.db.Set(scheduleBucketName, chargerID, schedule); err != nil {
+		return errors.Wrap(err, "set schedule: can't save charging schedule")
+	}
+
+	return nil
+}
+
+func (s *scheduleStorage) ClearSchedule(chargerID string) error {
+	var schedule model.ChargingSchedule
+
+	ok, err := s.db.Get(scheduleBucketName, chargerID, &schedule)
+	if err != nil {
+		return errors.Wrap(err, "clear schedule: can't get charging schedule")
+	}
+
+	if !ok {
+		return nil
+	}
+
+	if err := s.db.Delete(scheduleBucketName, chargerID); err != nil {
+		return errors.Wrap(err, "clear schedule: can't delete charging schedule")
+	}
+
+	return nil
+}
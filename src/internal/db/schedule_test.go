@@ -0,0 +1,49 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/futurehomeno/cliffhanger/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/db"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+func TestScheduleStorage(t *testing.T) {
+	t.Parallel()
+
+	fileDB, err := database.NewDatabase(t.TempDir())
+	require.NoError(t, err)
+
+	storage := db.NewScheduleStorage(fileDB)
+	schedule := model.ChargingSchedule{
+		{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "01:00", End: "06:00", Current: 16},
+	}
+
+	got, err := storage.ScheduleByChargerID("XX12345")
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	err = storage.SetSchedule("XX12345", schedule)
+	assert.NoError(t, err)
+
+	got, err = storage.ScheduleByChargerID("XX12345")
+	assert.NoError(t, err)
+	assert.Equal(t, schedule, got)
+
+	got, err = storage.ScheduleByChargerID("YY12345")
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	err = storage.ClearSchedule("XX12345")
+	assert.NoError(t, err)
+
+	err = storage.ClearSchedule("XX12345")
+	assert.NoError(t, err)
+
+	got, err = storage.ScheduleByChargerID("XX12345")
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
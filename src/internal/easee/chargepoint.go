@@ -0,0 +1,641 @@
+package easee
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/futurehomeno/cliffhanger/adapter"
+	"github.com/futurehomeno/cliffhanger/adapter/service/chargepoint"
+	"github.com/futurehomeno/fimpgo"
+	"github.com/futurehomeno/fimpgo/fimptype"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+// Constants defining adapter specific chargepoint commands and events.
+const (
+	CmdSessionHistoryGetReport  = "cmd.session_history.get_report"
+	EvtSessionHistoryReport     = "evt.session_history.report"
+	CmdScheduleSet              = "cmd.schedule.set"
+	CmdScheduleGetReport        = "cmd.schedule.get_report"
+	CmdScheduleClear            = "cmd.schedule.clear"
+	EvtScheduleReport           = "evt.schedule.report"
+	CmdDepartureTargetSet       = "cmd.departure_target.set"
+	CmdDepartureTargetGetReport = "cmd.departure_target.get_report"
+	EvtDepartureTargetReport    = "evt.departure_target.report"
+	CmdAuthorizationSet         = "cmd.authorization.set"
+	CmdDiagnosticsGetReport     = "cmd.diagnostics.get_report"
+	EvtDiagnosticsReport        = "evt.diagnostics.report"
+)
+
+// SessionHistoryQuery represents a query for the charging session history.
+type SessionHistoryQuery struct {
+	From   time.Time
+	To     time.Time
+	Limit  int
+	Offset int
+}
+
+// SessionHistoryEntry represents a single charging session in the session history report.
+type SessionHistoryEntry struct {
+	ID              int64     `json:"id"`
+	StartedAt       time.Time `json:"started_at"`
+	FinishedAt      time.Time `json:"finished_at,omitzero"`
+	Energy          float64   `json:"energy"`
+	MeterValueStart float64   `json:"meter_value_start"`
+	MeterValueStop  float64   `json:"meter_value_stop"`
+	Cost            float64   `json:"cost,omitempty"`
+	AuthToken       string    `json:"auth_token,omitempty"`
+}
+
+// SessionHistoryReport represents a charging session history report.
+type SessionHistoryReport []*SessionHistoryEntry
+
+// SessionHistoryController represents a controller able to provide the charging session history.
+type SessionHistoryController interface {
+	// ChargepointSessionHistoryReport returns charging sessions matching the provided query, latest first.
+	ChargepointSessionHistoryReport(query *SessionHistoryQuery) (SessionHistoryReport, error)
+}
+
+// Properties of the current session report describing the cost of charging sessions.
+const (
+	PropertyCost                = "cost"
+	PropertyPreviousSessionCost = "previous_session_cost"
+	PropertyCurrency            = "currency"
+)
+
+// PropertyReasonForNoCurrent is a property of the state report explaining why the charger is not offering current.
+const PropertyReasonForNoCurrent = "reason_for_no_current"
+
+// SessionCostReport represents the cost of the current and previous charging sessions.
+type SessionCostReport struct {
+	Cost                float64
+	PreviousSessionCost float64
+	Currency            string
+}
+
+// SessionCostController represents a controller able to provide the cost of charging sessions.
+type SessionCostController interface {
+	// ChargepointSessionCostReport returns the cost of the current and previous charging sessions.
+	// Returns nil if the tariff is not configured.
+	ChargepointSessionCostReport() (*SessionCostReport, error)
+}
+
+// ScheduleController represents a controller able to manage and execute weekly charging schedules.
+type ScheduleController interface {
+	// ChargepointSchedule returns the charging schedule.
+	ChargepointSchedule() (model.ChargingSchedule, error)
+	// SetChargepointSchedule validates and stores the charging schedule, replacing the existing one.
+	SetChargepointSchedule(schedule model.ChargingSchedule) error
+	// ClearChargepointSchedule removes the charging schedule.
+	ClearChargepointSchedule() error
+	// ExecuteChargepointSchedule starts or stops charging if a schedule window has started or ended.
+	ExecuteChargepointSchedule() error
+}
+
+// DepartureController represents a controller able to charge the required energy by the departure time.
+type DepartureController interface {
+	// ChargepointDepartureTarget returns the departure target or nil if it is not set.
+	ChargepointDepartureTarget() (*model.DepartureTarget, error)
+	// SetChargepointDepartureTarget validates and sets the departure target used by the departure and cheapest charging modes.
+	SetChargepointDepartureTarget(target model.DepartureTarget) error
+	// AdjustChargepointDepartureCharging adjusts the offered current of the ongoing departure or cheapest charging.
+	AdjustChargepointDepartureCharging() error
+}
+
+// SolarController represents a controller able to charge using the surplus of solar production.
+type SolarController interface {
+	// AdjustChargepointSolarCharging adjusts the offered current of the ongoing solar charging to the power in W
+	// imported from the grid, which is negative if the power is exported.
+	AdjustChargepointSolarCharging(gridPower float64) error
+	// ChargepointSolarChargingActive checks if the charger is charging in the solar mode.
+	ChargepointSolarChargingActive() bool
+}
+
+// LoadGuardController represents a controller able to limit the offered current to protect the main fuse.
+type LoadGuardController interface {
+	// AdjustChargepointLoadGuard limits or restores the offered current according to the per phase currents in A
+	// measured by the main meter. The headroom is shared equally by the provided number of chargers.
+	AdjustChargepointLoadGuard(houseCurrents [3]float64, chargers int) error
+}
+
+// AuthorizationController represents a controller able to approve or deny charging sessions awaiting authorization.
+type AuthorizationController interface {
+	// SetChargepointAuthorization approves the charging session awaiting authorization if authorized is true,
+	// otherwise it denies the pending session or stops the authorized one.
+	SetChargepointAuthorization(authorized bool) error
+}
+
+// MaintenanceController represents a controller able to execute remote maintenance commands of the charger.
+type MaintenanceController interface {
+	// RebootChargepoint reboots the charger.
+	RebootChargepoint() error
+	// UpdateChargepointFirmware updates the charger to the latest firmware available.
+	UpdateChargepointFirmware() error
+}
+
+// DiagnosticsReport represents errors and warnings reported by the charger.
+type DiagnosticsReport struct {
+	ErrorCode                     int    `json:"error_code"`
+	ReasonForNoCurrent            int    `json:"reason_for_no_current"`
+	ReasonForNoCurrentDescription string `json:"reason_for_no_current_description"`
+	GridWarning                   string `json:"grid_warning,omitempty"`
+}
+
+// DiagnosticsController represents a controller able to provide diagnostics of the charger.
+type DiagnosticsController interface {
+	// ChargepointDiagnosticsReport returns the current error, the reason for no current and the grid warning of the charger.
+	ChargepointDiagnosticsReport() (*DiagnosticsReport, error)
+}
+
+// ReasonForNoCurrentController represents a controller able to explain why the charger is not offering current.
+type ReasonForNoCurrentController interface {
+	// ChargepointReasonForNoCurrent returns the reason why the charger is not offering current.
+	ChargepointReasonForNoCurrent() model.NoCurrentReason
+}
+
+// ChargepointService extends the chargepoint service with adapter specific functionalities.
+type ChargepointService interface {
+	chargepoint.Service
+
+	// SendSessionHistoryReport sends the charging session history report for the provided query.
+	SendSessionHistoryReport(query *SessionHistoryQuery) error
+	// SetSchedule sets the charging schedule.
+	SetSchedule(schedule model.ChargingSchedule) error
+	// ClearSchedule removes the charging schedule.
+	ClearSchedule() error
+	// SendScheduleReport sends the charging schedule report.
+	SendScheduleReport() error
+	// ExecuteSchedule starts or stops charging according to the charging schedule.
+	ExecuteSchedule() error
+	// SetDepartureTarget sets the departure target.
+	SetDepartureTarget(target model.DepartureTarget) error
+	// SendDepartureTargetReport sends the departure target report.
+	SendDepartureTargetReport() error
+	// AdjustDepartureCharging adjusts the offered current of the ongoing departure charging.
+	AdjustDepartureCharging() error
+	// AdjustSolarCharging adjusts the offered current of the ongoing solar charging to the grid power in W.
+	AdjustSolarCharging(gridPower float64) error
+	// SolarChargingActive checks if the charger is charging in the solar mode.
+	SolarChargingActive() bool
+	// AdjustLoadGuard limits or restores the offered current according to the per phase currents of the main meter,
+	// sharing the headroom equally with the provided number of chargers.
+	AdjustLoadGuard(houseCurrents [3]float64, chargers int) error
+	// SetAuthorization approves or denies the charging session awaiting authorization.
+	SetAuthorization(authorized bool) error
+	// ExecuteMaintenance executes the remote maintenance command of the charger.
+	ExecuteMaintenance(command model.MaintenanceCommand) error
+	// SendDiagnosticsReport sends the diagnostics report of the charger.
+	SendDiagnosticsReport() error
+}
+
+// NewChargepointService returns a new instance of ChargepointService.
+func NewChargepointService(publisher adapter.ServicePublisher, cfg *chargepoint.Config) ChargepointService {
+	cfg.Specification.EnsureInterfaces(chargepointInterfaces()...)
+
+	historyController, _ := cfg.Controller.(SessionHistoryController)
+	scheduleController, _ := cfg.Controller.(ScheduleController)
+	departureController, _ := cfg.Controller.(DepartureController)
+	solarController, _ := cfg.Controller.(SolarController)
+	loadGuardController, _ := cfg.Controller.(LoadGuardController)
+	authorizationController, _ := cfg.Controller.(AuthorizationController)
+	maintenanceController, _ := cfg.Controller.(MaintenanceController)
+	diagnosticsController, _ := cfg.Controller.(DiagnosticsController)
+
+	if costController, ok := cfg.Controller.(SessionCostController); ok {
+		publisher = &sessionCostPublisher{
+			ServicePublisher: publisher,
+			controller:       costController,
+		}
+	}
+
+	if reasonController, ok := cfg.Controller.(ReasonForNoCurrentController); ok {
+		publisher = &reasonForNoCurrentPublisher{
+			ServicePublisher: publisher,
+			controller:       reasonController,
+		}
+	}
+
+	return &chargepointService{
+		Service:                 chargepoint.NewService(publisher, cfg),
+		historyController:       historyController,
+		scheduleController:      scheduleController,
+		departureController:     departureController,
+		solarController:         solarController,
+		loadGuardController:     loadGuardController,
+		authorizationController: authorizationController,
+		maintenanceController:   maintenanceController,
+		diagnosticsController:   diagnosticsController,
+	}
+}
+
+type chargepointService struct {
+	chargepoint.Service
+
+	historyController       SessionHistoryController
+	scheduleController      ScheduleController
+	departureController     DepartureController
+	solarController         SolarController
+	loadGuardController     LoadGuardController
+	authorizationController AuthorizationController
+	maintenanceController   MaintenanceController
+	diagnosticsController   DiagnosticsController
+	lock                    sync.Mutex
+}
+
+func (s *chargepointService) SendSessionHistoryReport(query *SessionHistoryQuery) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.historyController == nil {
+		return fmt.Errorf("%s: session history is not supported", s.Name())
+	}
+
+	report, err := s.historyController.ChargepointSessionHistoryReport(query)
+	if err != nil {
+		return fmt.Errorf("%s: failed to retrieve session history report: %w", s.Name(), err)
+	}
+
+	message := fimpgo.NewObjectMessage(
+		EvtSessionHistoryReport,
+		s.Name(),
+		report,
+		nil,
+		nil,
+		nil,
+	)
+
+	if err := s.SendMessage(message); err != nil {
+		return fmt.Errorf("%s: failed to send session history report: %w", s.Name(), err)
+	}
+
+	return nil
+}
+
+func (s *chargepointService) SetSchedule(schedule model.ChargingSchedule) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.scheduleController == nil {
+		return fmt.Errorf("%s: charging schedules are not supported", s.Name())
+	}
+
+	if err := s.scheduleController.SetChargepointSchedule(schedule); err != nil {
+		return fmt.Errorf("%s: failed to set charging schedule: %w", s.Name(), err)
+	}
+
+	return nil
+}
+
+func (s *chargepointService) ClearSchedule() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.scheduleController == nil {
+		return fmt.Errorf("%s: charging schedules are not supported", s.Name())
+	}
+
+	if err := s.scheduleController.ClearChargepointSchedule(); err != nil {
+		return fmt.Errorf("%s: failed to clear charging schedule: %w", s.Name(), err)
+	}
+
+	return nil
+}
+
+func (s *chargepointService) SendScheduleReport() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.scheduleController == nil {
+		return fmt.Errorf("%s: charging schedules are not supported", s.Name())
+	}
+
+	schedule, err := s.scheduleController.ChargepointSchedule()
+	if err != nil {
+		return fmt.Errorf("%s: failed to retrieve charging schedule: %w", s.Name(), err)
+	}
+
+	message := fimpgo.NewObjectMessage(
+		EvtScheduleReport,
+		s.Name(),
+		schedule,
+		nil,
+		nil,
+		nil,
+	)
+
+	if err := s.SendMessage(message); err != nil {
+		return fmt.Errorf("%s: failed to send charging schedule report: %w", s.Name(), err)
+	}
+
+	return nil
+}
+
+func (s *chargepointService) ExecuteSchedule() error {
+	if s.scheduleController == nil {
+		return nil
+	}
+
+	if err := s.scheduleController.ExecuteChargepointSchedule(); err != nil {
+		return fmt.Errorf("%s: failed to execute charging schedule: %w", s.Name(), err)
+	}
+
+	return nil
+}
+
+func (s *chargepointService) SetDepartureTarget(target model.DepartureTarget) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.departureController == nil {
+		return fmt.Errorf("%s: departure charging is not supported", s.Name())
+	}
+
+	if err := s.departureController.SetChargepointDepartureTarget(target); err != nil {
+		return fmt.Errorf("%s: failed to set departure target: %w", s.Name(), err)
+	}
+
+	return nil
+}
+
+func (s *chargepointService) SendDepartureTargetReport() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.departureController == nil {
+		return fmt.Errorf("%s: departure charging is not supported", s.Name())
+	}
+
+	target, err := s.departureController.ChargepointDepartureTarget()
+	if err != nil {
+		return fmt.Errorf("%s: failed to retrieve departure target: %w", s.Name(), err)
+	}
+
+	message := fimpgo.NewObjectMessage(
+		EvtDepartureTargetReport,
+		s.Name(),
+		target,
+		nil,
+		nil,
+		nil,
+	)
+
+	if err := s.SendMessage(message); err != nil {
+		return fmt.Errorf("%s: failed to send departure target report: %w", s.Name(), err)
+	}
+
+	return nil
+}
+
+func (s *chargepointService) AdjustDepartureCharging() error {
+	if s.departureController == nil {
+		return nil
+	}
+
+	if err := s.departureController.AdjustChargepointDepartureCharging(); err != nil {
+		return fmt.Errorf("%s: failed to adjust departure charging: %w", s.Name(), err)
+	}
+
+	return nil
+}
+
+func (s *chargepointService) AdjustSolarCharging(gridPower float64) error {
+	if s.solarController == nil {
+		return nil
+	}
+
+	if err := s.solarController.AdjustChargepointSolarCharging(gridPower); err != nil {
+		return fmt.Errorf("%s: failed to adjust solar charging: %w", s.Name(), err)
+	}
+
+	return nil
+}
+
+func (s *chargepointService) SolarChargingActive() bool {
+	if s.solarController == nil {
+		return false
+	}
+
+	return s.solarController.ChargepointSolarChargingActive()
+}
+
+func (s *chargepointService) AdjustLoadGuard(houseCurrents [3]float64, chargers int) error {
+	if s.loadGuardController == nil {
+		return nil
+	}
+
+	if err := s.loadGuardController.AdjustChargepointLoadGuard(houseCurrents, chargers); err != nil {
+		return fmt.Errorf("%s: failed to adjust load guard: %w", s.Name(), err)
+	}
+
+	return nil
+}
+
+func (s *chargepointService) SetAuthorization(authorized bool) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.authorizationController == nil {
+		return fmt.Errorf("%s: charging authorization is not supported", s.Name())
+	}
+
+	if err := s.authorizationController.SetChargepointAuthorization(authorized); err != nil {
+		return fmt.Errorf("%s: failed to set charging authorization: %w", s.Name(), err)
+	}
+
+	return nil
+}
+
+func (s *chargepointService) ExecuteMaintenance(command model.MaintenanceCommand) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.maintenanceController == nil {
+		return fmt.Errorf("%s: maintenance commands are not supported", s.Name())
+	}
+
+	var err error
+
+	switch command {
+	case model.MaintenanceCommandReboot:
+		err = s.maintenanceController.RebootChargepoint()
+	case model.MaintenanceCommandUpdateFirmware:
+		err = s.maintenanceController.UpdateChargepointFirmware()
+	default:
+		return fmt.Errorf("%s: unsupported maintenance command: %s", s.Name(), command)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: failed to execute %s maintenance command: %w", s.Name(), command, err)
+	}
+
+	return nil
+}
+
+func (s *chargepointService) SendDiagnosticsReport() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.diagnosticsController == nil {
+		return fmt.Errorf("%s: diagnostics are not supported", s.Name())
+	}
+
+	report, err := s.diagnosticsController.ChargepointDiagnosticsReport()
+	if err != nil {
+		return fmt.Errorf("%s: failed to retrieve diagnostics report: %w", s.Name(), err)
+	}
+
+	message := fimpgo.NewObjectMessage(
+		EvtDiagnosticsReport,
+		s.Name(),
+		report,
+		nil,
+		nil,
+		nil,
+	)
+
+	if err := s.SendMessage(message); err != nil {
+		return fmt.Errorf("%s: failed to send diagnostics report: %w", s.Name(), err)
+	}
+
+	return nil
+}
+
+// sessionCostPublisher amends current session reports with the cost of charging sessions.
+type sessionCostPublisher struct {
+	adapter.ServicePublisher
+
+	controller SessionCostController
+}
+
+func (p *sessionCostPublisher) PublishServiceMessage(service adapter.Service, message *fimpgo.FimpMessage) error {
+	if message.Type == chargepoint.EvtCurrentSessionReport {
+		p.addCostProperties(service, message)
+	}
+
+	return p.ServicePublisher.PublishServiceMessage(service, message)
+}
+
+func (p *sessionCostPublisher) addCostProperties(service adapter.Service, message *fimpgo.FimpMessage) {
+	report, err := p.controller.ChargepointSessionCostReport()
+	if err != nil {
+		log.WithError(err).Warnf("%s: failed to retrieve session cost report", service.Name())
+
+		return
+	}
+
+	if report == nil {
+		return
+	}
+
+	if message.Properties == nil {
+		message.Properties = make(fimpgo.Props)
+	}
+
+	message.Properties[PropertyCost] = strconv.FormatFloat(report.Cost, 'f', 2, 64)
+	message.Properties[PropertyPreviousSessionCost] = strconv.FormatFloat(report.PreviousSessionCost, 'f', 2, 64)
+
+	if report.Currency != "" {
+		message.Properties[PropertyCurrency] = report.Currency
+	}
+}
+
+// reasonForNoCurrentPublisher amends state reports with the reason why the charger is not offering current.
+type reasonForNoCurrentPublisher struct {
+	adapter.ServicePublisher
+
+	controller ReasonForNoCurrentController
+}
+
+func (p *reasonForNoCurrentPublisher) PublishServiceMessage(service adapter.Service, message *fimpgo.FimpMessage) error {
+	if message.Type == chargepoint.EvtStateReport {
+		if message.Properties == nil {
+			message.Properties = make(fimpgo.Props)
+		}
+
+		message.Properties[PropertyReasonForNoCurrent] = p.controller.ChargepointReasonForNoCurrent().String()
+	}
+
+	return p.ServicePublisher.PublishServiceMessage(service, message)
+}
+
+// chargepointInterfaces returns adapter specific chargepoint interfaces.
+func chargepointInterfaces() []fimptype.Interface {
+	return []fimptype.Interface{
+		{
+			Type:      fimptype.TypeIn,
+			MsgType:   CmdSessionHistoryGetReport,
+			ValueType: fimpgo.VTypeStrMap,
+			Version:   "1",
+		},
+		{
+			Type:      fimptype.TypeOut,
+			MsgType:   EvtSessionHistoryReport,
+			ValueType: fimpgo.VTypeObject,
+			Version:   "1",
+		},
+		{
+			Type:      fimptype.TypeIn,
+			MsgType:   CmdScheduleSet,
+			ValueType: fimpgo.VTypeObject,
+			Version:   "1",
+		},
+		{
+			Type:      fimptype.TypeIn,
+			MsgType:   CmdScheduleGetReport,
+			ValueType: fimpgo.VTypeNull,
+			Version:   "1",
+		},
+		{
+			Type:      fimptype.TypeIn,
+			MsgType:   CmdScheduleClear,
+			ValueType: fimpgo.VTypeNull,
+			Version:   "1",
+		},
+		{
+			Type:      fimptype.TypeOut,
+			MsgType:   EvtScheduleReport,
+			ValueType: fimpgo.VTypeObject,
+			Version:   "1",
+		},
+		{
+			Type:      fimptype.TypeIn,
+			MsgType:   CmdDepartureTargetSet,
+			ValueType: fimpgo.VTypeObject,
+			Version:   "1",
+		},
+		{
+			Type:      fimptype.TypeIn,
+			MsgType:   CmdDepartureTargetGetReport,
+			ValueType: fimpgo.VTypeNull,
+			Version:   "1",
+		},
+		{
+			Type:      fimptype.TypeOut,
+			MsgType:   EvtDepartureTargetReport,
+			ValueType: fimpgo.VTypeObject,
+			Version:   "1",
+		},
+		{
+			Type:      fimptype.TypeIn,
+			MsgType:   CmdAuthorizationSet,
+			ValueType: fimpgo.VTypeBool,
+			Version:   "1",
+		},
+		{
+			Type:      fimptype.TypeIn,
+			MsgType:   CmdDiagnosticsGetReport,
+			ValueType: fimpgo.VTypeNull,
+			Version:   "1",
+		},
+		{
+			Type:      fimptype.TypeOut,
+			MsgType:   EvtDiagnosticsReport,
+			ValueType: fimpgo.VTypeObject,
+			Version:   "1",
+		},
+	}
+}
@@ -0,0 +1,213 @@
+package easee
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/futurehomeno/cliffhanger/adapter"
+	"github.com/futurehomeno/cliffhanger/adapter/service/numericmeter"
+	"github.com/futurehomeno/fimpgo"
+	"github.com/futurehomeno/fimpgo/fimptype"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/api"
+	"github.com/futurehomeno/edge-easee-adapter/internal/config"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+// Constants defining the circuit service, its commands, events and properties.
+const (
+	Circuit = "circuit"
+
+	CmdCurrentSet       = "cmd.current.set"
+	CmdCurrentGetReport = "cmd.current.get_report"
+	EvtCurrentReport    = "evt.current.report"
+
+	PropertyFuseRating = "fuse_rating"
+)
+
+// Keys of the circuit current value, matching phase currents of extended meter reports.
+const (
+	CircuitCurrentPhase1 = string(numericmeter.ValueCurrentPhase1)
+	CircuitCurrentPhase2 = string(numericmeter.ValueCurrentPhase2)
+	CircuitCurrentPhase3 = string(numericmeter.ValueCurrentPhase3)
+)
+
+// CircuitController represents a controller of the dynamic current of the circuit.
+type CircuitController interface {
+	// CircuitDynamicCurrent returns the dynamic current per phase of the circuit.
+	CircuitDynamicCurrent() (*model.CircuitCurrent, error)
+	// SetCircuitDynamicCurrent validates and sets the dynamic current per phase of the circuit.
+	SetCircuitDynamicCurrent(current model.CircuitCurrent) error
+}
+
+// CircuitService represents a service limiting the current of all chargers connected to the circuit.
+type CircuitService interface {
+	adapter.Service
+
+	// SetDynamicCurrent sets the dynamic current per phase of the circuit.
+	SetDynamicCurrent(current model.CircuitCurrent) error
+	// SendDynamicCurrentReport sends the dynamic current report of the circuit.
+	SendDynamicCurrentReport() error
+}
+
+// NewCircuitService returns a new instance of CircuitService.
+func NewCircuitService(publisher adapter.ServicePublisher, specification *fimptype.Service, controller CircuitController) CircuitService {
+	return &circuitService{
+		Service:    adapter.NewService(publisher, specification),
+		controller: controller,
+	}
+}
+
+type circuitService struct {
+	adapter.Service
+
+	controller CircuitController
+	lock       sync.Mutex
+}
+
+func (s *circuitService) SetDynamicCurrent(current model.CircuitCurrent) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.controller.SetCircuitDynamicCurrent(current); err != nil {
+		return fmt.Errorf("%s: failed to set dynamic current: %w", s.Name(), err)
+	}
+
+	return nil
+}
+
+func (s *circuitService) SendDynamicCurrentReport() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	current, err := s.controller.CircuitDynamicCurrent()
+	if err != nil {
+		return fmt.Errorf("%s: failed to retrieve dynamic current: %w", s.Name(), err)
+	}
+
+	message := fimpgo.NewFloatMapMessage(
+		EvtCurrentReport,
+		s.Name(),
+		map[string]float64{
+			CircuitCurrentPhase1: current.Phase1,
+			CircuitCurrentPhase2: current.Phase2,
+			CircuitCurrentPhase3: current.Phase3,
+		},
+		nil,
+		nil,
+		nil,
+	)
+
+	if err := s.SendMessage(message); err != nil {
+		return fmt.Errorf("%s: failed to send dynamic current report: %w", s.Name(), err)
+	}
+
+	return nil
+}
+
+// CircuitSpecification returns the specification of the circuit service.
+func CircuitSpecification(resourceName, resourceAddress, address string, groups []string, fuseRating float64) *fimptype.Service {
+	return &fimptype.Service{
+		Address: fmt.Sprintf("/rt:dev/rn:%s/ad:%s/sv:%s/ad:%s", resourceName, resourceAddress, Circuit, address),
+		Name:    Circuit,
+		Groups:  groups,
+		Enabled: true,
+		Props: map[string]interface{}{
+			PropertyFuseRating: fuseRating,
+		},
+		Interfaces: []fimptype.Interface{
+			{
+				Type:      fimptype.TypeIn,
+				MsgType:   CmdCurrentSet,
+				ValueType: fimpgo.VTypeFloatMap,
+				Version:   "1",
+			},
+			{
+				Type:      fimptype.TypeIn,
+				MsgType:   CmdCurrentGetReport,
+				ValueType: fimpgo.VTypeNull,
+				Version:   "1",
+			},
+			{
+				Type:      fimptype.TypeOut,
+				MsgType:   EvtCurrentReport,
+				ValueType: fimpgo.VTypeFloatMap,
+				Version:   "1",
+			},
+		},
+	}
+}
+
+type circuitController struct {
+	client     api.Client
+	cfgService *config.Service
+	siteID     int64
+	circuitID  int64
+	fuseRating float64
+}
+
+// NewCircuitController returns a new instance of CircuitController.
+func NewCircuitController(client api.Client, cfgService *config.Service, siteID, circuitID int64, fuseRating float64) CircuitController {
+	return &circuitController{
+		client:     client,
+		cfgService: cfgService,
+		siteID:     siteID,
+		circuitID:  circuitID,
+		fuseRating: fuseRating,
+	}
+}
+
+func (c *circuitController) CircuitDynamicCurrent() (*model.CircuitCurrent, error) {
+	ctx, cancel := c.requestContext()
+	defer cancel()
+
+	return c.client.CircuitDynamicCurrent(ctx, c.siteID, c.circuitID)
+}
+
+func (c *circuitController) SetCircuitDynamicCurrent(current model.CircuitCurrent) error {
+	if err := current.Validate(c.fuseRating); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.requestContext()
+	defer cancel()
+
+	return c.client.UpdateCircuitDynamicCurrent(ctx, c.siteID, c.circuitID, current)
+}
+
+// requestContext returns a context limiting the time a request to the API can take.
+func (c *circuitController) requestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.cfgService.GetCommandTimeout())
+}
+
+// circuitConnector is a connector of circuits, which are available as long as the Easee API is.
+type circuitConnector struct {
+	client api.Client
+}
+
+// NewCircuitConnector returns a new instance of adapter.Connector for circuits.
+func NewCircuitConnector(client api.Client) adapter.Connector {
+	return &circuitConnector{
+		client: client,
+	}
+}
+
+func (c *circuitConnector) Connectivity() *adapter.ConnectivityDetails {
+	return &adapter.ConnectivityDetails{
+		ConnectionStatus: adapter.ConnectionStatusUp,
+		ConnectionType:   adapter.ConnectionTypeIndirect,
+	}
+}
+
+func (c *circuitConnector) Ping() *adapter.PingDetails {
+	if err := c.client.Ping(context.Background()); err != nil {
+		return &adapter.PingDetails{
+			Status: adapter.PingResultFailed,
+		}
+	}
+
+	return &adapter.PingDetails{
+		Status: adapter.PingResultSuccess,
+	}
+}
@@ -1,6 +1,8 @@
 package easee
 
 import (
+	"context"
+
 	"github.com/futurehomeno/cliffhanger/adapter"
 	log "github.com/sirupsen/logrus"
 
@@ -8,6 +10,7 @@ import (
 	"github.com/futurehomeno/edge-easee-adapter/internal/cache"
 	"github.com/futurehomeno/edge-easee-adapter/internal/config"
 	"github.com/futurehomeno/edge-easee-adapter/internal/db"
+	"github.com/futurehomeno/edge-easee-adapter/internal/sharing"
 	"github.com/futurehomeno/edge-easee-adapter/internal/signalr"
 )
 
@@ -16,41 +19,69 @@ type connector struct {
 	httpClient api.Client
 	confSrv    *config.Service
 
-	chargerID      string
-	cache          cache.Cache
-	sessionStorage db.ChargingSessionStorage
+	chargerID       string
+	product         string
+	thingState      adapter.ThingState
+	cache           cache.Cache
+	sessionStorage  db.ChargingSessionStorage
+	controller      Controller
+	allocator       sharing.Allocator
+	firmwareMonitor FirmwareMonitor
+	notifier        api.Notifier
+	publisher       adapter.ThingPublisher
 }
 
 func NewConnector(
 	manager signalr.Manager,
 	httpClient api.Client,
 	chargerID string,
+	product string,
+	thingState adapter.ThingState,
 	cache cache.Cache,
 	confSrv *config.Service,
 	sessionStorage db.ChargingSessionStorage,
+	controller Controller,
+	allocator sharing.Allocator,
+	firmwareMonitor FirmwareMonitor,
+	notifier api.Notifier,
+	publisher adapter.ThingPublisher,
 ) adapter.Connector {
 	return &connector{
-		manager:        manager,
-		httpClient:     httpClient,
-		chargerID:      chargerID,
-		cache:          cache,
-		confSrv:        confSrv,
-		sessionStorage: sessionStorage,
+		manager:         manager,
+		httpClient:      httpClient,
+		chargerID:       chargerID,
+		product:         product,
+		thingState:      thingState,
+		cache:           cache,
+		confSrv:         confSrv,
+		sessionStorage:  sessionStorage,
+		controller:      controller,
+		allocator:       allocator,
+		firmwareMonitor: firmwareMonitor,
+		notifier:        notifier,
+		publisher:       publisher,
 	}
 }
 
 func (c *connector) Connect(thing adapter.Thing) {
-	handler, err := signalr.NewObservationsHandler(thing, c.cache, c.confSrv, c.sessionStorage, c.chargerID)
+	reporter := &maintenanceReporter{publisher: c.publisher, thing: thing}
+
+	handler, err := signalr.NewObservationsHandler(thing, c.cache, c.confSrv, c.sessionStorage, c.chargerID, c.allocator, c.firmwareMonitor, c.notifier, reporter)
 	if err != nil {
 		log.WithError(err).Error("failed to create signalRManager callbacks")
 
 		return
 	}
 
+	c.allocator.Register(c.chargerID, c.cache, c.controller)
+	c.firmwareMonitor.Register(c.chargerID, c.product, thing, c.thingState)
 	c.manager.Register(c.chargerID, handler)
 }
 
 func (c *connector) Disconnect(_ adapter.Thing) {
+	c.allocator.Unregister(c.chargerID)
+	c.firmwareMonitor.Unregister(c.chargerID)
+
 	if err := c.manager.Unregister(c.chargerID); err != nil {
 		log.WithError(err).Error("failed to unregister charger within signalR manager")
 	}
@@ -70,7 +101,7 @@ func (c *connector) Connectivity() *adapter.ConnectivityDetails {
 }
 
 func (c *connector) Ping() *adapter.PingDetails {
-	if err := c.httpClient.Ping(); err != nil {
+	if err := c.httpClient.Ping(context.Background()); err != nil {
 		return &adapter.PingDetails{
 			Status: adapter.PingResultFailed,
 		}
@@ -1,15 +1,20 @@
 package easee
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/futurehomeno/cliffhanger/adapter/service/chargepoint"
 	"github.com/futurehomeno/cliffhanger/adapter/service/numericmeter"
 	"github.com/futurehomeno/cliffhanger/adapter/service/parameters"
+	"github.com/michalkurzeja/go-clock"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/futurehomeno/edge-easee-adapter/internal/api"
@@ -17,10 +22,17 @@ import (
 	"github.com/futurehomeno/edge-easee-adapter/internal/config"
 	"github.com/futurehomeno/edge-easee-adapter/internal/db"
 	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+	"github.com/futurehomeno/edge-easee-adapter/internal/params"
+	"github.com/futurehomeno/edge-easee-adapter/internal/sharing"
 	"github.com/futurehomeno/edge-easee-adapter/internal/signalr"
 )
 
-const maxCurrentValue = 32
+const (
+	maxCurrentValue = 32
+
+	// loadGuardHysteresis is the lowest increase of the current limited by the load guard in A.
+	loadGuardHysteresis = 1
+)
 
 var extendedReportMapping = map[numericmeter.Value]specFunc{
 	numericmeter.ValueCurrentPhase1: func(report numericmeter.ValuesReport, c cache.Cache) {
@@ -54,14 +66,26 @@ type specFunc func(report numericmeter.ValuesReport, c cache.Cache)
 // Controller represents a charger controller.
 type Controller interface {
 	chargepoint.Controller
-	chargepoint.PhaseModeAwareController
+	chargepoint.AdjustablePhaseModeController
 	chargepoint.AdjustableMaxCurrentController
 	chargepoint.AdjustableOfferedCurrentController
 	chargepoint.CableLockAwareController
 	parameters.Controller
 	numericmeter.Reporter
 	numericmeter.ExtendedReporter
+	SessionHistoryController
+	SessionCostController
+	ScheduleController
+	DepartureController
+	SolarController
+	LoadGuardController
+	sharing.CurrentLimiter
+	AuthorizationController
+	MaintenanceController
+	DiagnosticsController
+	ReasonForNoCurrentController
 	UpdateState(chargerID string, state *State) error
+	ResumeDepartureCharging() error
 }
 
 // NewController returns a new instance of Controller.
@@ -72,53 +96,163 @@ func NewController(
 	cache cache.Cache,
 	cfgService *config.Service,
 	sessionStorage db.ChargingSessionStorage,
+	scheduleStorage db.ChargingScheduleStorage,
+	departureStorage db.DepartureTargetStorage,
 ) Controller {
 	return &controller{
-		client:         client,
-		manager:        manager,
-		cache:          cache,
-		cfgService:     cfgService,
-		chargerID:      chargerID,
-		sessionStorage: sessionStorage,
+		client:           client,
+		manager:          manager,
+		cache:            cache,
+		cfgService:       cfgService,
+		chargerID:        chargerID,
+		sessionStorage:   sessionStorage,
+		scheduleStorage:  scheduleStorage,
+		departureStorage: departureStorage,
+		currentLimits:    make(map[currentLimit]float64),
+		offeredCurrent:   -1,
 	}
 }
 
 type controller struct {
-	client         api.Client
-	manager        signalr.Manager
-	cache          cache.Cache
-	cfgService     *config.Service
-	chargerID      string
-	sessionStorage db.ChargingSessionStorage
+	client           api.Client
+	manager          signalr.Manager
+	cache            cache.Cache
+	cfgService       *config.Service
+	chargerID        string
+	sessionStorage   db.ChargingSessionStorage
+	scheduleStorage  db.ChargingScheduleStorage
+	departureStorage db.DepartureTargetStorage
+
+	// scheduleWindow is a start of the schedule window in which charging was started by the schedule.
+	scheduleWindow time.Time
+	scheduleLock   sync.Mutex
+
+	supportedMaxCurrent atomic.Int64
+
+	// departureTarget is the stored departure target followed by the active departure charging mode.
+	departureTarget     *model.DepartureTarget
+	departureMode       string
+	departureCurrent    int64
+	departureAdjustedAt time.Time
+	departureLock       sync.Mutex
+
+	solarActive     bool
+	solarCurrent    int64
+	solarAdjustedAt time.Time
+	solarLock       sync.Mutex
+
+	// loadGuardCurrent is the current limit imposed by the load guard, 0 if charging is paused by it.
+	loadGuardActive     bool
+	loadGuardCurrent    int64
+	loadGuardAdjustedAt time.Time
+	loadGuardLock       sync.Mutex
+
+	// requestedCurrent is the current requested by the user or the active charging mode, offered limited by currentLimits.
+	// offeredCurrent is the current last offered to the charger, -1 if it is not known.
+	// offeredCurrentChangedAt is the time the dynamic current was last changed.
+	// queuedOfferedCurrent is set while a change made within the wait time of the last change waits to be applied.
+	requestedCurrent        float64
+	currentLimits           map[currentLimit]float64
+	offeredCurrent          float64
+	offeredCurrentChangedAt time.Time
+	queuedOfferedCurrent    *queuedCurrent
+	offeredCurrentLock      sync.Mutex
 }
 
-func (c *controller) SetParameter(p *parameters.Parameter) error {
-	if p.ID != model.CableAlwaysLockedParameter {
-		return fmt.Errorf("parameter: %v not supported", p.ID)
-	}
+// currentLimit represents a source of a limit of the offered current.
+type currentLimit string
+
+const (
+	// limitLoadGuard is the limit imposed by the load guard to protect the main fuse.
+	limitLoadGuard currentLimit = "load_guard"
+	// limitSharing is the share of the current budget allocated by the power sharing.
+	limitSharing currentLimit = "sharing"
+)
+
+// queuedCurrent represents a change of the dynamic current waiting to be applied.
+type queuedCurrent struct {
+	// requested is true if the change has been requested, rather than caused by a change of the limits.
+	requested bool
+}
 
-	val, err := p.BoolValue()
+func (c *controller) SetParameter(p *parameters.Parameter) error {
+	param, err := params.ByID(p.ID)
 	if err != nil {
 		return err
 	}
 
-	return c.client.SetCableAlwaysLocked(c.chargerID, val)
+	ctx, cancel := c.commandContext()
+	defer cancel()
+
+	return param.Set(ctx, c.client, c.chargerID, p)
 }
 
 func (c *controller) GetParameter(id string) (*parameters.Parameter, error) {
-	if id != model.CableAlwaysLockedParameter {
-		return nil, fmt.Errorf("parameter: %v not supported", id)
+	param, err := params.ByID(id)
+	if err != nil {
+		return nil, err
 	}
 
-	alwaysLocked, _ := c.cache.CableAlwaysLocked()
+	ctx, cancel := c.requestContext()
+	defer cancel()
 
-	return parameters.NewBoolParameter(id, alwaysLocked), nil
+	return param.Get(ctx, c.client, c.chargerID, c.cache)
 }
 
 func (c *controller) GetParameterSpecifications() ([]*parameters.ParameterSpecification, error) {
-	return []*parameters.ParameterSpecification{
-		parameterSpecificationCableAlwaysLocked(),
-	}, nil
+	specs := make([]*parameters.ParameterSpecification, 0, len(params.All()))
+
+	for _, p := range params.All() {
+		specs = append(specs, p.Specification())
+	}
+
+	return specs, nil
+}
+
+func (c *controller) SetChargepointAuthorization(authorized bool) error {
+	if err := c.checkConnection(); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.commandContext()
+	defer cancel()
+
+	if !authorized {
+		return c.client.DeauthorizeCharging(ctx, c.chargerID)
+	}
+
+	if state, _ := c.cache.ChargerState(); state != chargepoint.StateRequesting {
+		return fmt.Errorf("charger is not awaiting authorization, current state: %s", state)
+	}
+
+	return c.client.AuthorizeCharging(ctx, c.chargerID)
+}
+
+func (c *controller) RebootChargepoint() error {
+	ctx, cancel := c.commandContext()
+	defer cancel()
+
+	// A hung charger might not be connected, so the command is sent regardless of the connection state.
+	if err := c.client.RebootCharger(ctx, c.chargerID); err != nil {
+		return err
+	}
+
+	c.cache.SetPendingMaintenance(model.MaintenanceCommandReboot, clock.Now())
+
+	return nil
+}
+
+func (c *controller) UpdateChargepointFirmware() error {
+	ctx, cancel := c.commandContext()
+	defer cancel()
+
+	if err := c.client.UpdateFirmware(ctx, c.chargerID); err != nil {
+		return err
+	}
+
+	c.cache.SetPendingMaintenance(model.MaintenanceCommandUpdateFirmware, clock.Now())
+
+	return nil
 }
 
 func (c *controller) ChargepointCableLockReport() (*chargepoint.CableReport, error) {
@@ -174,13 +308,45 @@ func (c *controller) ChargepointPhaseModeReport() (chargepoint.PhaseMode, error)
 	return "", errors.New(errMsg)
 }
 
+func (c *controller) SetChargepointPhaseMode(mode chargepoint.PhaseMode) error {
+	gridType, _ := c.cache.GridType()
+	phases, _ := c.cache.Phases()
+
+	if !slices.Contains(model.SwitchablePhaseModes(gridType, phases), mode) {
+		return fmt.Errorf("phase mode %s is not supported by the installation", mode)
+	}
+
+	phaseMode, err := model.ToEaseePhaseMode(mode)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := c.commandContext()
+	defer cancel()
+
+	if err := c.client.UpdatePhaseMode(ctx, c.chargerID, phaseMode); err != nil {
+		return err
+	}
+
+	if !c.cache.WaitForPhaseMode(phaseMode, c.waitDuration(ctx)) {
+		log.WithField("charger_id", c.chargerID).
+			WithField("phase_mode", phaseMode).
+			Warn("phase mode change has not been confirmed by the charger")
+	}
+
+	return nil
+}
+
 func (c *controller) SetChargepointMaxCurrent(current int64) error {
-	err := c.client.UpdateMaxCurrent(c.chargerID, float64(current))
+	ctx, cancel := c.commandContext()
+	defer cancel()
+
+	err := c.client.UpdateMaxCurrent(ctx, c.chargerID, float64(current))
 	if err != nil {
 		return err
 	}
 
-	c.cache.WaitForMaxCurrent(current, c.cfgService.GetCurrentWaitDuration())
+	c.cache.WaitForMaxCurrent(current, c.waitDuration(ctx))
 
 	return nil
 }
@@ -196,19 +362,39 @@ func (c *controller) ChargepointMaxCurrentReport() (int64, error) {
 }
 
 func (c *controller) SetChargepointOfferedCurrent(current int64) error {
-	err := c.client.UpdateDynamicCurrent(c.chargerID, float64(current))
+	ctx, cancel := c.commandContext()
+	defer cancel()
+
+	offered, applied, err := c.offerCurrent(ctx, float64(current), false)
 	if err != nil {
 		return err
 	}
 
+	// The latest requested current is reported also while it is queued or limited.
 	c.cache.SetRequestedOfferedCurrent(current, time.Now())
 
-	c.cache.WaitForOfferedCurrent(current, c.cfgService.GetCurrentWaitDuration())
+	if applied {
+		c.cache.WaitForOfferedCurrent(int64(offered), c.waitDuration(ctx))
+	}
 
 	return nil
 }
 
 func (c *controller) StartChargepointCharging(settings *chargepoint.ChargingSettings) error {
+	switch mode := strings.ToLower(settings.Mode); mode {
+	case model.ChargingModeDeparture, model.ChargingModeCheapest:
+		c.stopSolarCharging()
+
+		return c.startDepartureCharging(mode)
+	case model.ChargingModeSolar:
+		c.stopDepartureCharging()
+
+		return c.startSolarCharging()
+	}
+
+	c.stopDepartureCharging()
+	c.stopSolarCharging()
+
 	maxCurrent, _ := c.cache.MaxCurrent()
 	startCurrent := float64(maxCurrent)
 
@@ -228,13 +414,430 @@ func (c *controller) StartChargepointCharging(settings *chargepoint.ChargingSett
 		return errors.New("invalid start current")
 	}
 
+	ctx, cancel := c.commandContext()
+	defer cancel()
+
 	// resume charing request is not used because it clears dynamic current value.
-	// update current will resume charging.
-	return c.client.UpdateDynamicCurrent(c.chargerID, startCurrent)
+	// update current will resume charging, unless it is paused by the load guard until the limit is lifted.
+	_, _, err := c.offerCurrent(ctx, startCurrent, true)
+
+	return err
 }
 
 func (c *controller) StopChargepointCharging() error {
-	return c.client.StopCharging(c.chargerID)
+	c.stopDepartureCharging()
+	c.stopSolarCharging()
+
+	ctx, cancel := c.commandContext()
+	defer cancel()
+
+	// Charging stopped on request is not resumed once the load guard limit is lifted.
+	_, _, err := c.offerCurrent(ctx, 0, true)
+
+	return err
+}
+
+func (c *controller) ChargepointDepartureTarget() (*model.DepartureTarget, error) {
+	return c.departureStorage.DepartureTargetByChargerID(c.chargerID)
+}
+
+func (c *controller) SetChargepointDepartureTarget(target model.DepartureTarget) error {
+	if err := target.Validate(clock.Now()); err != nil {
+		return err
+	}
+
+	c.departureLock.Lock()
+	defer c.departureLock.Unlock()
+
+	if err := c.departureStorage.SetDepartureTarget(c.chargerID, target); err != nil {
+		return fmt.Errorf("failed to save departure target: %w", err)
+	}
+
+	c.departureTarget = &target
+
+	// Ongoing departure charging is adjusted to the new target as soon as possible.
+	c.departureAdjustedAt = time.Time{}
+
+	return nil
+}
+
+func (c *controller) AdjustChargepointDepartureCharging() error {
+	c.departureLock.Lock()
+	defer c.departureLock.Unlock()
+
+	if c.departureMode == "" {
+		return nil
+	}
+
+	now := clock.Now()
+
+	state, _ := c.cache.ChargerState()
+	if state == chargepoint.StateDisconnected || !now.Before(c.departureTarget.DepartureAt) {
+		c.setDepartureMode("")
+
+		return nil
+	}
+
+	// Easee rejects offered current changes made more often than the configured wait time.
+	if now.Sub(c.departureAdjustedAt) < c.cfgService.GetOfferedCurrentWaitTime() {
+		return nil
+	}
+
+	current, done := c.plannedDepartureCurrent(now)
+
+	if done {
+		c.setDepartureMode("")
+
+		return c.offerManagedCurrent(0)
+	}
+
+	// Charging paused by the plan might be started again by the charger itself, e.g. after the car reconnects.
+	if current == c.departureCurrent && (current > 0 || state != chargepoint.StateCharging) {
+		return nil
+	}
+
+	if err := c.applyDepartureCurrent(current); err != nil {
+		return fmt.Errorf("failed to adjust departure charging current: %w", err)
+	}
+
+	c.departureAdjustedAt = now
+
+	return nil
+}
+
+func (c *controller) startDepartureCharging(mode string) error {
+	c.departureLock.Lock()
+	defer c.departureLock.Unlock()
+
+	target, err := c.departureStorage.DepartureTargetByChargerID(c.chargerID)
+	if err != nil {
+		return fmt.Errorf("failed to get departure target: %w", err)
+	}
+
+	if target == nil {
+		return errors.New("departure target is not set")
+	}
+
+	c.departureTarget = target
+
+	if mode == model.ChargingModeCheapest && c.cfgService.GetPriceCurve() == nil {
+		return errors.New("price curve is not set")
+	}
+
+	now := clock.Now()
+	previousMode := c.departureMode
+	c.departureMode = mode
+
+	current, done := c.plannedDepartureCurrent(now)
+	if done {
+		c.departureMode = previousMode
+
+		return errors.New("departure target energy has been already charged")
+	}
+
+	if err := c.applyDepartureCurrent(current); err != nil {
+		c.departureMode = previousMode
+
+		return err
+	}
+
+	c.departureAdjustedAt = now
+	c.setDepartureMode(mode)
+
+	return nil
+}
+
+func (c *controller) stopDepartureCharging() {
+	c.departureLock.Lock()
+	defer c.departureLock.Unlock()
+
+	if c.departureMode != "" {
+		c.setDepartureMode("")
+	}
+}
+
+// ResumeDepartureCharging restores the departure or cheapest charging mode which was active before the adapter restarted.
+// The offered current is adjusted by the next adjustment of the departure charging.
+func (c *controller) ResumeDepartureCharging() error {
+	c.departureLock.Lock()
+	defer c.departureLock.Unlock()
+
+	mode, err := c.departureStorage.DepartureModeByChargerID(c.chargerID)
+	if err != nil {
+		return fmt.Errorf("failed to get departure charging mode: %w", err)
+	}
+
+	if mode == "" {
+		return nil
+	}
+
+	target, err := c.departureStorage.DepartureTargetByChargerID(c.chargerID)
+	if err != nil {
+		return fmt.Errorf("failed to get departure target: %w", err)
+	}
+
+	if target == nil || !clock.Now().Before(target.DepartureAt) {
+		c.setDepartureMode("")
+
+		return nil
+	}
+
+	c.departureTarget = target
+	c.departureMode = mode
+	c.departureAdjustedAt = time.Time{}
+
+	return nil
+}
+
+// setDepartureMode sets and persists the active departure charging mode, so it is resumed after the restart.
+// A failure to persist the mode does not affect the ongoing charging. Must be called with the lock held.
+func (c *controller) setDepartureMode(mode string) {
+	c.departureMode = mode
+
+	if err := c.departureStorage.SetDepartureMode(c.chargerID, mode); err != nil {
+		log.WithError(err).
+			WithField("charger_id", c.chargerID).
+			Warn("failed to save departure charging mode")
+	}
+}
+
+// applyDepartureCurrent offers the planned current to the charger, charging is paused if the current is 0.
+func (c *controller) applyDepartureCurrent(current int64) error {
+	if err := c.offerManagedCurrent(current); err != nil {
+		return err
+	}
+
+	c.departureCurrent = current
+
+	return nil
+}
+
+// plannedDepartureCurrent returns the current per phase planned for the active departure charging mode.
+// Done is true if the target energy has been already charged.
+func (c *controller) plannedDepartureCurrent(now time.Time) (current int64, done bool) {
+	energy, _ := c.cache.EnergySession()
+	if energy >= c.departureTarget.Energy {
+		return 0, true
+	}
+
+	maxCurrent, powerPerAmpere := c.chargingCapacity()
+
+	if c.departureMode != model.ChargingModeCheapest {
+		return c.departureTarget.RequiredCurrent(now, energy, powerPerAmpere, maxCurrent), false
+	}
+
+	curve := c.cfgService.GetPriceCurve()
+	if curve == nil {
+		return maxCurrent, false
+	}
+
+	plan := c.departureTarget.CheapestPlan(now, energy, powerPerAmpere*float64(maxCurrent)/1000, curve.PriceAt)
+	if plan.Includes(now) {
+		return maxCurrent, false
+	}
+
+	return 0, false
+}
+
+func (c *controller) AdjustChargepointSolarCharging(gridPower float64) error {
+	c.solarLock.Lock()
+	defer c.solarLock.Unlock()
+
+	if !c.solarActive {
+		return nil
+	}
+
+	state, _ := c.cache.ChargerState()
+	if state == chargepoint.StateDisconnected {
+		c.solarActive = false
+
+		return nil
+	}
+
+	now := clock.Now()
+
+	// Easee rejects offered current changes made more often than the configured wait time.
+	if now.Sub(c.solarAdjustedAt) < c.cfgService.GetOfferedCurrentWaitTime() {
+		return nil
+	}
+
+	maxCurrent, powerPerAmpere := c.chargingCapacity()
+	current := model.SolarCurrent(gridPower, powerPerAmpere, c.measuredCurrent(), c.solarCurrent, maxCurrent, c.cfgService.GetSolarHysteresis())
+
+	// Charging paused due to insufficient surplus might be started again by the charger itself.
+	if current == c.solarCurrent && (current > 0 || state != chargepoint.StateCharging) {
+		return nil
+	}
+
+	if err := c.offerManagedCurrent(current); err != nil {
+		return fmt.Errorf("failed to adjust solar charging current: %w", err)
+	}
+
+	c.solarCurrent = current
+	c.solarAdjustedAt = now
+
+	return nil
+}
+
+func (c *controller) ChargepointSolarChargingActive() bool {
+	c.solarLock.Lock()
+	defer c.solarLock.Unlock()
+
+	return c.solarActive
+}
+
+func (c *controller) startSolarCharging() error {
+	if c.cfgService.GetSolarMeterTopic() == "" {
+		return errors.New("solar meter topic is not set")
+	}
+
+	c.solarLock.Lock()
+	defer c.solarLock.Unlock()
+
+	// Charging is resumed once the surplus reported by the meter is sufficient.
+	c.solarActive = true
+	c.solarCurrent = 0
+	c.solarAdjustedAt = time.Time{}
+
+	return nil
+}
+
+func (c *controller) stopSolarCharging() {
+	c.solarLock.Lock()
+	defer c.solarLock.Unlock()
+
+	c.solarActive = false
+}
+
+// offerManagedCurrent offers the current to the charger on behalf of the adapter managed charging modes.
+// Charging is paused if the current is 0.
+func (c *controller) offerManagedCurrent(current int64) error {
+	ctx, cancel := c.requestContext()
+	defer cancel()
+
+	_, _, err := c.offerCurrent(ctx, float64(current), false)
+
+	return err
+}
+
+func (c *controller) AdjustChargepointLoadGuard(houseCurrents [3]float64, chargers int) error {
+	fuseRating := c.cfgService.GetMainFuseRating()
+	if fuseRating <= 0 {
+		return nil
+	}
+
+	c.loadGuardLock.Lock()
+	defer c.loadGuardLock.Unlock()
+
+	state, _ := c.cache.ChargerState()
+	now := clock.Now()
+
+	if c.loadGuardActive && state == chargepoint.StateDisconnected {
+		c.loadGuardActive = false
+
+		return nil
+	}
+
+	phase1, _ := c.cache.Phase1Current()
+	phase2, _ := c.cache.Phase2Current()
+	phase3, _ := c.cache.Phase3Current()
+
+	limit := model.LoadGuardLimit(houseCurrents, [3]float64{phase1, phase2, phase3}, fuseRating, c.cfgService.GetLoadGuardMargin(), chargers)
+
+	if !c.loadGuardActive {
+		offered, _ := c.cache.OfferedCurrent()
+		if state != chargepoint.StateCharging || float64(offered) <= limit {
+			return nil
+		}
+
+		// Charging might have been started by the charger itself, so the current observed before limiting it is restored.
+		c.assumeRequestedCurrent(float64(offered))
+
+		return c.limitLoadGuardCurrent(limit, now)
+	}
+
+	// Lowering the current can't wait, while raising it respects the time Easee requires between changes.
+	lower := int64(math.Floor(limit)) < c.loadGuardCurrent
+	if !lower && now.Sub(c.loadGuardAdjustedAt) < c.cfgService.GetOfferedCurrentWaitTime() {
+		return nil
+	}
+
+	if limit >= c.requestedOfferedCurrent() {
+		ctx, cancel := c.requestContext()
+		defer cancel()
+
+		if err := c.limitOfferedCurrent(ctx, limitLoadGuard, -1); err != nil {
+			return fmt.Errorf("failed to restore current limited by the load guard: %w", err)
+		}
+
+		c.loadGuardActive = false
+
+		return nil
+	}
+
+	if !lower && limit < float64(c.loadGuardCurrent)+loadGuardHysteresis {
+		return nil
+	}
+
+	return c.limitLoadGuardCurrent(limit, now)
+}
+
+// limitLoadGuardCurrent offers the current limited by the load guard, charging is paused if the limit is below the minimum current.
+func (c *controller) limitLoadGuardCurrent(limit float64, now time.Time) error {
+	current := int64(math.Floor(limit))
+	if current < model.MinChargingCurrent {
+		current = 0
+	}
+
+	ctx, cancel := c.requestContext()
+	defer cancel()
+
+	if err := c.limitOfferedCurrent(ctx, limitLoadGuard, float64(current)); err != nil {
+		return fmt.Errorf("failed to limit current by the load guard: %w", err)
+	}
+
+	c.loadGuardActive = true
+	c.loadGuardCurrent = current
+	c.loadGuardAdjustedAt = now
+
+	return nil
+}
+
+func (c *controller) LimitChargepointSharedCurrent(current int64) error {
+	ctx, cancel := c.requestContext()
+	defer cancel()
+
+	return c.limitOfferedCurrent(ctx, limitSharing, float64(current))
+}
+
+// measuredCurrent returns the highest of the per phase currents drawn by the car.
+func (c *controller) measuredCurrent() float64 {
+	phase1, _ := c.cache.Phase1Current()
+	phase2, _ := c.cache.Phase2Current()
+	phase3, _ := c.cache.Phase3Current()
+
+	return max(phase1, phase2, phase3)
+}
+
+// chargingCapacity returns the maximum current per phase available for charging and the charging power in W per ampere.
+func (c *controller) chargingCapacity() (maxCurrent int64, powerPerAmpere float64) {
+	gridType, _ := c.cache.GridType()
+	phases, _ := c.cache.Phases()
+
+	if phaseMode, _ := c.cache.PhaseMode(); phaseMode == model.PhaseModeLockedToSinglePhase {
+		phases = 1
+	}
+
+	maxCurrent = c.supportedMaxCurrent.Load()
+	if maxCurrent <= 0 {
+		maxCurrent = maxCurrentValue
+	}
+
+	if current, _ := c.cache.MaxCurrent(); current > 0 {
+		maxCurrent = min(maxCurrent, current)
+	}
+
+	return maxCurrent, model.PowerPerAmpere(gridType, phases)
 }
 
 func (c *controller) ChargepointCurrentSessionReport() (*chargepoint.SessionReport, error) {
@@ -277,6 +880,181 @@ func (c *controller) ChargepointCurrentSessionReport() (*chargepoint.SessionRepo
 	return &ret, nil
 }
 
+func (c *controller) ChargepointSessionHistoryReport(query *SessionHistoryQuery) (SessionHistoryReport, error) {
+	sessions, err := c.sessionStorage.SessionsByChargerID(c.chargerID, query.From, query.To, query.Limit, query.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get charging sessions: %w", err)
+	}
+
+	report := make(SessionHistoryReport, 0, len(sessions))
+
+	for _, s := range sessions {
+		report = append(report, &SessionHistoryEntry{
+			ID:              s.ID,
+			StartedAt:       s.Start,
+			FinishedAt:      s.Stop,
+			Energy:          s.Energy,
+			MeterValueStart: s.MeterValueStart,
+			MeterValueStop:  s.MeterValueStop,
+			Cost:            s.Cost,
+			AuthToken:       s.AuthToken,
+		})
+	}
+
+	return report, nil
+}
+
+func (c *controller) ChargepointDiagnosticsReport() (*DiagnosticsReport, error) {
+	diagnostics := c.cache.Diagnostics()
+
+	return &DiagnosticsReport{
+		ErrorCode:                     diagnostics.ErrorCode,
+		ReasonForNoCurrent:            int(diagnostics.ReasonForNoCurrent),
+		ReasonForNoCurrentDescription: diagnostics.ReasonForNoCurrent.String(),
+		GridWarning:                   diagnostics.GridWarning(),
+	}, nil
+}
+
+func (c *controller) ChargepointReasonForNoCurrent() model.NoCurrentReason {
+	reason, _ := c.cache.ReasonForNoCurrent()
+
+	return reason
+}
+
+func (c *controller) ChargepointSessionCostReport() (*SessionCostReport, error) {
+	tariff := c.cfgService.GetTariff()
+	if tariff == nil {
+		return nil, nil
+	}
+
+	sessions, err := c.sessionStorage.LatestSessionsByChargerID(c.chargerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest charging sessions: %w", err)
+	}
+
+	report := &SessionCostReport{
+		Currency: tariff.Currency,
+	}
+
+	if latest := sessions.Latest(); latest != nil {
+		report.Cost = latest.Cost
+	}
+
+	if prev := sessions.Previous(); prev != nil {
+		report.PreviousSessionCost = prev.Cost
+	}
+
+	return report, nil
+}
+
+func (c *controller) ChargepointSchedule() (model.ChargingSchedule, error) {
+	return c.scheduleStorage.ScheduleByChargerID(c.chargerID)
+}
+
+func (c *controller) SetChargepointSchedule(schedule model.ChargingSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+
+	c.scheduleLock.Lock()
+	defer c.scheduleLock.Unlock()
+
+	// Windows of the replaced schedule do not apply to the new one.
+	c.scheduleWindow = time.Time{}
+
+	return c.scheduleStorage.SetSchedule(c.chargerID, schedule)
+}
+
+func (c *controller) ClearChargepointSchedule() error {
+	c.scheduleLock.Lock()
+	defer c.scheduleLock.Unlock()
+
+	c.scheduleWindow = time.Time{}
+
+	return c.scheduleStorage.ClearSchedule(c.chargerID)
+}
+
+func (c *controller) ExecuteChargepointSchedule() error {
+	schedule, err := c.scheduleStorage.ScheduleByChargerID(c.chargerID)
+	if err != nil {
+		return fmt.Errorf("failed to get charging schedule: %w", err)
+	}
+
+	if len(schedule) == 0 {
+		return nil
+	}
+
+	c.scheduleLock.Lock()
+	defer c.scheduleLock.Unlock()
+
+	// Charging modes managed by the adapter take over the charging from the schedule.
+	if c.managedChargingActive() {
+		c.scheduleWindow = time.Time{}
+
+		return nil
+	}
+
+	state, _ := c.cache.ChargerState()
+
+	window, ok := schedule.ActiveAt(clock.Now())
+	if !ok {
+		return c.finishScheduleWindow(state)
+	}
+
+	switch {
+	case c.scheduleWindow.Equal(window.Start):
+		return nil
+	case !c.scheduleWindow.IsZero() && state == chargepoint.StateCharging:
+		// Charging started within the previous window continues within the adjacent one.
+		c.scheduleWindow = window.Start
+
+		return nil
+	case state != chargepoint.StateReadyToCharge && state != chargepoint.StateFinished:
+		// Charging is started once the car is connected.
+		return nil
+	}
+
+	if window.Entry.Current > 0 {
+		err = c.SetChargepointOfferedCurrent(window.Entry.Current)
+	} else {
+		err = c.StartChargepointCharging(&chargepoint.ChargingSettings{Mode: model.ChargingModeNormal})
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to start scheduled charging: %w", err)
+	}
+
+	c.scheduleWindow = window.Start
+
+	return nil
+}
+
+// finishScheduleWindow stops charging started by the schedule once its window has ended.
+func (c *controller) finishScheduleWindow(state chargepoint.State) error {
+	if c.scheduleWindow.IsZero() {
+		return nil
+	}
+
+	if state == chargepoint.StateCharging || state == chargepoint.StateSuspendedByEV {
+		if err := c.StopChargepointCharging(); err != nil {
+			return fmt.Errorf("failed to stop scheduled charging: %w", err)
+		}
+	}
+
+	c.scheduleWindow = time.Time{}
+
+	return nil
+}
+
+// managedChargingActive checks if charging is managed by the departure, cheapest or solar charging mode.
+func (c *controller) managedChargingActive() bool {
+	c.departureLock.Lock()
+	departureActive := c.departureMode != ""
+	c.departureLock.Unlock()
+
+	return departureActive || c.ChargepointSolarChargingActive()
+}
+
 func (c *controller) ChargepointStateReport() (chargepoint.State, error) {
 	if err := c.checkConnection(); err != nil {
 		return "", err
@@ -332,14 +1110,17 @@ func (c *controller) MeterExtendedReport(values numericmeter.Values) (numericmet
 }
 
 func (c *controller) UpdateState(chargerID string, state *State) error {
-	configErr := c.updateChargerConfigState(chargerID, state)
-	siteErr := c.updateChargerSiteState(chargerID, state)
+	ctx, cancel := c.requestContext()
+	defer cancel()
+
+	configErr := c.updateChargerConfigState(ctx, chargerID, state)
+	siteErr := c.updateChargerSiteState(ctx, chargerID, state)
 
 	return errors.Join(configErr, siteErr)
 }
 
-func (c *controller) updateChargerConfigState(chargerID string, state *State) error {
-	cfg, err := c.client.ChargerConfig(chargerID)
+func (c *controller) updateChargerConfigState(ctx context.Context, chargerID string, state *State) error {
+	cfg, err := c.client.ChargerConfig(ctx, chargerID)
 	if err != nil {
 		if state.IsConfigUpdateNeeded() {
 			return fmt.Errorf("failed to fetch a charger config ID %s: %w", chargerID, err)
@@ -353,25 +1134,217 @@ func (c *controller) updateChargerConfigState(chargerID string, state *State) er
 	state.GridType = gridType
 	state.Phases = phases
 	state.PhaseMode = cfg.PhaseMode
+	state.OfflineMaxCurrent = &cfg.OfflineCurrent
 
 	return nil
 }
 
-func (c *controller) updateChargerSiteState(chargerID string, state *State) error {
-	siteInfo, err := c.client.ChargerSiteInfo(chargerID)
+func (c *controller) updateChargerSiteState(ctx context.Context, chargerID string, state *State) error {
+	siteInfo, err := c.client.ChargerSiteInfo(ctx, chargerID)
 	if err != nil {
 		if state.IsSiteUpdateNeeded() {
 			return fmt.Errorf("failed to fetch a charger site info ID %s: %w", chargerID, err)
 		}
 
+		c.supportedMaxCurrent.Store(state.SupportedMaxCurrent)
+
 		return nil
 	}
 
 	state.SupportedMaxCurrent = min(int64(math.Round(siteInfo.RatedCurrent)), maxCurrentValue)
+	c.supportedMaxCurrent.Store(state.SupportedMaxCurrent)
 
 	return nil
 }
 
+// offerCurrent requests the current to be offered to the charger, charging is paused if the current is 0.
+// All changes of the dynamic current are made through the controller, which offers the requested current limited by the adapter limits.
+// Changes made within the wait time of the last change are queued, unless they are applied immediately,
+// and only the latest state is applied once the wait time elapses. Returns the offered current and true if it has been applied.
+func (c *controller) offerCurrent(ctx context.Context, current float64, immediate bool) (float64, bool, error) {
+	c.offeredCurrentLock.Lock()
+	defer c.offeredCurrentLock.Unlock()
+
+	c.requestedCurrent = current
+
+	return c.applyOfferedCurrent(ctx, immediate, true)
+}
+
+// limitOfferedCurrent imposes the limit on the offered current, a negative limit lifts it.
+// Lowering the limit is applied right away, regardless of the wait time of the last change.
+func (c *controller) limitOfferedCurrent(ctx context.Context, limit currentLimit, current float64) error {
+	c.offeredCurrentLock.Lock()
+	defer c.offeredCurrentLock.Unlock()
+
+	previous, limited := c.currentLimits[limit]
+	immediate := current >= 0 && (!limited || current < previous)
+
+	if current < 0 {
+		delete(c.currentLimits, limit)
+	} else {
+		c.currentLimits[limit] = current
+	}
+
+	_, _, err := c.applyOfferedCurrent(ctx, immediate, false)
+
+	return err
+}
+
+// requestedOfferedCurrent returns the current requested to be offered before applying the limits.
+func (c *controller) requestedOfferedCurrent() float64 {
+	c.offeredCurrentLock.Lock()
+	defer c.offeredCurrentLock.Unlock()
+
+	return c.requestedCurrent
+}
+
+// assumeRequestedCurrent sets the requested current to the one offered by the charger, unless a requested change is queued.
+func (c *controller) assumeRequestedCurrent(current float64) {
+	c.offeredCurrentLock.Lock()
+	defer c.offeredCurrentLock.Unlock()
+
+	if c.queuedOfferedCurrent != nil && c.queuedOfferedCurrent.requested {
+		return
+	}
+
+	c.requestedCurrent = current
+}
+
+// applyOfferedCurrent applies the requested current limited by the adapter limits or queues it until the wait time elapses.
+// Changes caused by the limits are skipped if the limited current is already offered. Must be called with the lock held.
+func (c *controller) applyOfferedCurrent(ctx context.Context, immediate, requested bool) (float64, bool, error) {
+	current := c.limitedCurrent()
+
+	if !requested && current == c.offeredCurrent {
+		return current, false, nil
+	}
+
+	// The requested current is not offered due to the limits, which are already applied.
+	if requested && current < c.requestedCurrent && current == c.offeredCurrent {
+		return current, false, nil
+	}
+
+	now := clock.Now()
+	applyAt := c.offeredCurrentChangedAt.Add(c.cfgService.GetOfferedCurrentWaitTime())
+
+	if !immediate && (c.queuedOfferedCurrent != nil || now.Before(applyAt)) {
+		if c.queuedOfferedCurrent == nil {
+			queued := &queuedCurrent{}
+			c.queuedOfferedCurrent = queued
+
+			clock.AfterFunc(applyAt.Sub(now), func() {
+				c.applyQueuedOfferedCurrent(queued)
+			})
+		}
+
+		c.queuedOfferedCurrent.requested = c.queuedOfferedCurrent.requested || requested
+
+		return current, false, nil
+	}
+
+	// The queued change is outdated, as the latest state is applied right away.
+	c.queuedOfferedCurrent = nil
+
+	if err := c.sendOfferedCurrent(ctx, current); err != nil {
+		return current, false, err
+	}
+
+	return current, true, nil
+}
+
+// applyQueuedOfferedCurrent applies the latest state once the wait time elapses, unless the queue has been cleared in the meantime.
+// The limits are applied again, as they might have changed while the change was queued.
+func (c *controller) applyQueuedOfferedCurrent(queued *queuedCurrent) {
+	c.offeredCurrentLock.Lock()
+	defer c.offeredCurrentLock.Unlock()
+
+	if c.queuedOfferedCurrent != queued {
+		return
+	}
+
+	c.queuedOfferedCurrent = nil
+
+	current := c.limitedCurrent()
+	if !queued.requested && current == c.offeredCurrent {
+		return
+	}
+
+	ctx, cancel := c.requestContext()
+	defer cancel()
+
+	if err := c.sendOfferedCurrent(ctx, current); err != nil {
+		log.WithError(err).
+			WithField("charger_id", c.chargerID).
+			WithField("current", current).
+			Error("failed to apply the queued offered current")
+	}
+}
+
+// sendOfferedCurrent changes the dynamic current of the charger, charging is paused if the current is 0.
+// Must be called with the lock held.
+func (c *controller) sendOfferedCurrent(ctx context.Context, current float64) error {
+	var err error
+
+	// When stop charging command is sent, Easee sets dynamic current to 0.
+	if current == 0 {
+		err = c.client.StopCharging(ctx, c.chargerID)
+	} else {
+		err = c.client.UpdateDynamicCurrent(ctx, c.chargerID, current)
+	}
+
+	if err != nil {
+		c.offeredCurrent = -1
+
+		return err
+	}
+
+	c.offeredCurrent = current
+	c.offeredCurrentChangedAt = clock.Now()
+
+	return nil
+}
+
+// limitedCurrent returns the requested current limited by the adapter limits. Must be called with the lock held.
+func (c *controller) limitedCurrent() float64 {
+	current := c.requestedCurrent
+
+	for _, limit := range c.currentLimits {
+		current = min(current, limit)
+	}
+
+	return current
+}
+
+// requestContext returns a context limiting the time a request made on behalf of the adapter can take.
+func (c *controller) requestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.cfgService.GetCommandTimeout())
+}
+
+// commandContext returns a context limiting the time a command requested by the user can take,
+// including the time the charger takes to accept or reject the command. Responses of the charger are delivered over SignalR,
+// therefore they are not awaited while the charger is disconnected.
+func (c *controller) commandContext() (context.Context, context.CancelFunc) {
+	ctx := context.Background()
+
+	if connected, _ := c.manager.Connected(c.chargerID); connected {
+		ctx = api.WithAcknowledgement(ctx)
+	}
+
+	return context.WithTimeout(ctx, c.cfgService.GetCommandTimeout())
+}
+
+// waitDuration returns the time to wait for the charger to report the value changed by the command,
+// limited by the deadline of the command, so the whole command does not take longer than the command timeout.
+func (c *controller) waitDuration(ctx context.Context) time.Duration {
+	duration := c.cfgService.GetCurrentWaitDuration()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		duration = min(duration, max(time.Until(deadline), 0))
+	}
+
+	return duration
+}
+
 func (c *controller) checkConnection() error {
 	connected, reason := c.manager.Connected(c.chargerID)
 	if !connected {
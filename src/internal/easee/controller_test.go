@@ -0,0 +1,986 @@
+package easee_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/futurehomeno/cliffhanger/adapter/service/chargepoint"
+	"github.com/futurehomeno/cliffhanger/database"
+	"github.com/michalkurzeja/go-clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/cache"
+	"github.com/futurehomeno/edge-easee-adapter/internal/config"
+	"github.com/futurehomeno/edge-easee-adapter/internal/db"
+	"github.com/futurehomeno/edge-easee-adapter/internal/easee"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+	"github.com/futurehomeno/edge-easee-adapter/internal/signalr"
+	"github.com/futurehomeno/edge-easee-adapter/internal/test/fakes"
+	"github.com/futurehomeno/edge-easee-adapter/internal/test/mocks"
+)
+
+func TestController_ExecuteChargepointSchedule(t *testing.T) { //nolint:paralleltest
+	monday := time.Date(2025, time.January, 20, 0, 0, 0, 0, time.Local)
+
+	mockedClock := clock.Mock(monday)
+	t.Cleanup(clock.Restore)
+
+	dataBase, err := database.NewDatabase(t.TempDir())
+	require.NoError(t, err)
+
+	scheduleStorage := db.NewScheduleStorage(dataBase)
+	cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+	client := mocks.NewAPIClient(t)
+	chargerCache := cache.NewCache("XX12345")
+	chargerCache.SetMaxCurrent(32, monday)
+
+	c := easee.NewController(newManager(t), client, "XX12345", chargerCache, cfgService, db.NewSessionStorage(dataBase), scheduleStorage, db.NewDepartureStorage(dataBase))
+
+	err = c.SetChargepointSchedule(model.ChargingSchedule{
+		{Days: []string{"mon", "tue"}, Start: "01:00", End: "03:00"},
+		{Days: []string{"mon"}, Start: "22:00", End: "01:00"},
+	})
+	require.NoError(t, err)
+
+	steps := []struct {
+		name     string
+		at       time.Time
+		state    chargepoint.State
+		schedule model.ChargingSchedule
+		mock     func()
+	}{
+		{
+			name:  "charging is not started before the window",
+			at:    monday.Add(30 * time.Minute),
+			state: chargepoint.StateReadyToCharge,
+		},
+		{
+			name:  "charging started before the window is left intact",
+			at:    monday.Add(45 * time.Minute),
+			state: chargepoint.StateCharging,
+		},
+		{
+			name:  "charging is started within the window",
+			at:    monday.Add(time.Hour),
+			state: chargepoint.StateReadyToCharge,
+			mock: func() {
+				client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(32)).Return(nil).Once()
+			},
+		},
+		{
+			name:  "charging is started only once within the window",
+			at:    monday.Add(2 * time.Hour),
+			state: chargepoint.StateFinished,
+		},
+		{
+			name:  "charging is started again within the window of the replaced schedule",
+			at:    monday.Add(2*time.Hour + 30*time.Minute),
+			state: chargepoint.StateReadyToCharge,
+			schedule: model.ChargingSchedule{
+				{Days: []string{"mon", "tue"}, Start: "01:00", End: "03:00", Current: 16},
+				{Days: []string{"mon"}, Start: "22:00", End: "01:00"},
+			},
+			mock: func() {
+				client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(16)).Return(nil).Once()
+			},
+		},
+		{
+			name:  "charging is stopped after the window",
+			at:    monday.Add(3 * time.Hour),
+			state: chargepoint.StateCharging,
+			mock: func() {
+				client.On("StopCharging", mock.Anything, "XX12345").Return(nil).Once()
+			},
+		},
+		{
+			name:  "charging is not started if the car is not connected",
+			at:    monday.Add(22 * time.Hour),
+			state: chargepoint.StateDisconnected,
+		},
+		{
+			name:  "charging is started once the car is connected within the window spanning over midnight",
+			at:    monday.Add(23 * time.Hour),
+			state: chargepoint.StateReadyToCharge,
+			mock: func() {
+				client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(16)).Return(nil).Once()
+			},
+		},
+		{
+			name:  "charging continues within the adjacent window",
+			at:    monday.Add(25*time.Hour + 30*time.Minute),
+			state: chargepoint.StateCharging,
+		},
+		{
+			name:  "charging is stopped after the adjacent window",
+			at:    monday.Add(27 * time.Hour),
+			state: chargepoint.StateCharging,
+			mock: func() {
+				client.On("StopCharging", mock.Anything, "XX12345").Return(nil).Once()
+			},
+		},
+		{
+			name:  "charging not started by the schedule is left intact",
+			at:    monday.Add(28 * time.Hour),
+			state: chargepoint.StateCharging,
+		},
+	}
+
+	for _, s := range steps {
+		mockedClock.Set(s.at)
+		chargerCache.SetChargerState(s.state, s.at)
+
+		if s.schedule != nil {
+			require.NoError(t, c.SetChargepointSchedule(s.schedule), s.name)
+		}
+
+		if s.mock != nil {
+			s.mock()
+		}
+
+		assert.NoError(t, c.ExecuteChargepointSchedule(), s.name)
+		client.AssertExpectations(t)
+	}
+}
+
+func TestController_ExecuteChargepointSchedule_ManagedCharging(t *testing.T) { //nolint:paralleltest
+	monday := time.Date(2025, time.January, 20, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name  string
+		mode  string
+		setup func(c easee.Controller, cfgService *config.Service, client *mocks.APIClient)
+	}{
+		{
+			name: "departure charging",
+			mode: model.ChargingModeDeparture,
+			setup: func(c easee.Controller, _ *config.Service, client *mocks.APIClient) {
+				require.NoError(t, c.SetChargepointDepartureTarget(model.DepartureTarget{Energy: 60, DepartureAt: monday.Add(10 * time.Hour)}))
+				client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name: "cheapest charging",
+			mode: model.ChargingModeCheapest,
+			setup: func(c easee.Controller, cfgService *config.Service, client *mocks.APIClient) {
+				require.NoError(t, c.SetChargepointDepartureTarget(model.DepartureTarget{Energy: 20, DepartureAt: monday.Add(4 * time.Hour)}))
+				require.NoError(t, cfgService.SetPriceCurve(&model.PriceCurve{Prices: []model.HourlyPrice{
+					{Start: monday.Add(time.Hour), Price: 0.4},
+					{Start: monday.Add(2 * time.Hour), Price: 0.9},
+					{Start: monday.Add(3 * time.Hour), Price: 1.1},
+				}}))
+				client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name: "solar charging",
+			mode: model.ChargingModeSolar,
+			setup: func(_ easee.Controller, cfgService *config.Service, _ *mocks.APIClient) {
+				require.NoError(t, cfgService.SetSolarMeterTopic("pt:j1/mt:evt/rt:dev/rn:han/ad:1/sv:meter_elec/ad:1"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedClock := clock.Mock(monday.Add(90 * time.Minute))
+			t.Cleanup(clock.Restore)
+
+			dataBase, err := database.NewDatabase(t.TempDir())
+			require.NoError(t, err)
+
+			cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+			client := mocks.NewAPIClient(t)
+			chargerCache := cache.NewCache("XX12345")
+			chargerCache.SetMaxCurrent(32, monday)
+			chargerCache.SetInstallationParameters(chargepoint.GridTypeTN, 3, monday)
+			chargerCache.SetChargerState(chargepoint.StateReadyToCharge, monday)
+
+			c := easee.NewController(newManager(t), client, "XX12345", chargerCache, cfgService, db.NewSessionStorage(dataBase), db.NewScheduleStorage(dataBase), db.NewDepartureStorage(dataBase))
+
+			require.NoError(t, c.SetChargepointSchedule(model.ChargingSchedule{
+				{Days: []string{"mon"}, Start: "01:00", End: "03:00"},
+			}))
+
+			tt.setup(c, cfgService, client)
+			require.NoError(t, c.StartChargepointCharging(&chargepoint.ChargingSettings{Mode: tt.mode}))
+			client.AssertExpectations(t)
+
+			assert.NoError(t, c.ExecuteChargepointSchedule(), "charging is not started within the window")
+
+			mockedClock.Set(monday.Add(3*time.Hour + 30*time.Minute))
+			chargerCache.SetChargerState(chargepoint.StateCharging, clock.Now())
+
+			assert.NoError(t, c.ExecuteChargepointSchedule(), "charging is not stopped after the window")
+
+			if tt.mode == model.ChargingModeSolar {
+				assert.True(t, c.ChargepointSolarChargingActive())
+			}
+		})
+	}
+}
+
+func TestController_DepartureCharging(t *testing.T) { //nolint:paralleltest
+	start := time.Date(2025, time.January, 20, 20, 0, 0, 0, time.Local)
+
+	mockedClock := clock.Mock(start)
+	t.Cleanup(clock.Restore)
+
+	dataBase, err := database.NewDatabase(t.TempDir())
+	require.NoError(t, err)
+
+	cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+	client := mocks.NewAPIClient(t)
+	chargerCache := cache.NewCache("XX12345")
+	chargerCache.SetMaxCurrent(32, start)
+	chargerCache.SetInstallationParameters(chargepoint.GridTypeTN, 3, start)
+	chargerCache.SetChargerState(chargepoint.StateReadyToCharge, start)
+
+	c := easee.NewController(newManager(t), client, "XX12345", chargerCache, cfgService, db.NewSessionStorage(dataBase), db.NewScheduleStorage(dataBase), db.NewDepartureStorage(dataBase))
+	settings := &chargepoint.ChargingSettings{Mode: model.ChargingModeDeparture}
+
+	assert.Error(t, c.StartChargepointCharging(settings), "departure target is required")
+	assert.Error(t, c.SetChargepointDepartureTarget(model.DepartureTarget{Energy: 60, DepartureAt: start}), "departure time must be in the future")
+
+	err = c.SetChargepointDepartureTarget(model.DepartureTarget{Energy: 60, DepartureAt: start.Add(10 * time.Hour)})
+	require.NoError(t, err)
+
+	// The departure target is kept once the adapter is restarted.
+	c = easee.NewController(newManager(t), client, "XX12345", chargerCache, cfgService, db.NewSessionStorage(dataBase), db.NewScheduleStorage(dataBase), db.NewDepartureStorage(dataBase))
+
+	target, err := c.ChargepointDepartureTarget()
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.InDelta(t, 60, target.Energy, 0)
+
+	// 60 kWh within 10 hours on a three phase TN grid.
+	client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(10)).Return(nil).Once()
+	require.NoError(t, c.StartChargepointCharging(settings))
+
+	steps := []struct {
+		name   string
+		at     time.Time
+		energy float64
+		mock   func()
+	}{
+		{
+			name:   "current is not adjusted before the wait time elapses",
+			at:     start.Add(10 * time.Second),
+			energy: 0,
+		},
+		{
+			name:   "current is not adjusted if charging is on track",
+			at:     start.Add(2 * time.Hour),
+			energy: 10,
+		},
+		{
+			name:   "current is increased if charging falls behind",
+			at:     start.Add(6 * time.Hour),
+			energy: 20,
+			mock: func() {
+				client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(16)).Return(nil).Once()
+			},
+		},
+		{
+			name:   "charging is stopped once the target energy is charged",
+			at:     start.Add(9 * time.Hour),
+			energy: 60,
+			mock: func() {
+				client.On("StopCharging", mock.Anything, "XX12345").Return(nil).Once()
+			},
+		},
+		{
+			name:   "current is not adjusted after the departure charging is finished",
+			at:     start.Add(9*time.Hour + 30*time.Minute),
+			energy: 60,
+		},
+	}
+
+	for _, s := range steps {
+		mockedClock.Set(s.at)
+		chargerCache.SetEnergySession(s.energy, s.at)
+
+		if s.mock != nil {
+			s.mock()
+		}
+
+		assert.NoError(t, c.AdjustChargepointDepartureCharging(), s.name)
+		client.AssertExpectations(t)
+	}
+}
+
+func TestController_ResumeDepartureCharging(t *testing.T) { //nolint:paralleltest
+	start := time.Date(2025, time.January, 20, 20, 0, 0, 0, time.Local)
+
+	mockedClock := clock.Mock(start)
+	t.Cleanup(clock.Restore)
+
+	dataBase, err := database.NewDatabase(t.TempDir())
+	require.NoError(t, err)
+
+	cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+	client := mocks.NewAPIClient(t)
+	chargerCache := cache.NewCache("XX12345")
+	chargerCache.SetMaxCurrent(32, start)
+	chargerCache.SetInstallationParameters(chargepoint.GridTypeTN, 3, start)
+	chargerCache.SetChargerState(chargepoint.StateReadyToCharge, start)
+
+	departureStorage := db.NewDepartureStorage(dataBase)
+	newController := func() easee.Controller {
+		return easee.NewController(newManager(t), client, "XX12345", chargerCache, cfgService, db.NewSessionStorage(dataBase), db.NewScheduleStorage(dataBase), departureStorage)
+	}
+
+	c := newController()
+
+	// Nothing is resumed if the departure charging was not active.
+	require.NoError(t, c.ResumeDepartureCharging())
+	require.NoError(t, c.AdjustChargepointDepartureCharging())
+
+	require.NoError(t, c.SetChargepointDepartureTarget(model.DepartureTarget{Energy: 60, DepartureAt: start.Add(10 * time.Hour)}))
+
+	client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(10)).Return(nil).Once()
+	require.NoError(t, c.StartChargepointCharging(&chargepoint.ChargingSettings{Mode: model.ChargingModeDeparture}))
+
+	mode, err := departureStorage.DepartureModeByChargerID("XX12345")
+	require.NoError(t, err)
+	assert.Equal(t, model.ChargingModeDeparture, mode)
+
+	// The departure charging is resumed once the adapter is restarted and the current is offered again.
+	mockedClock.Set(start.Add(time.Hour))
+	chargerCache.SetEnergySession(6, start.Add(time.Hour))
+
+	c = newController()
+
+	require.NoError(t, c.ResumeDepartureCharging())
+
+	client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(10)).Return(nil).Once()
+	require.NoError(t, c.AdjustChargepointDepartureCharging())
+	client.AssertExpectations(t)
+
+	// The departure charging which expired while the adapter was stopped is not resumed.
+	mockedClock.Set(start.Add(11 * time.Hour))
+
+	c = newController()
+
+	require.NoError(t, c.ResumeDepartureCharging())
+	require.NoError(t, c.AdjustChargepointDepartureCharging())
+
+	mode, err = departureStorage.DepartureModeByChargerID("XX12345")
+	require.NoError(t, err)
+	assert.Empty(t, mode)
+}
+
+func TestController_CheapestCharging(t *testing.T) { //nolint:paralleltest
+	start := time.Date(2025, time.January, 20, 22, 0, 0, 0, time.Local)
+	hour := func(h int) time.Time { return start.Add(time.Duration(h) * time.Hour) }
+
+	mockedClock := clock.Mock(start)
+	t.Cleanup(clock.Restore)
+
+	dataBase, err := database.NewDatabase(t.TempDir())
+	require.NoError(t, err)
+
+	cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+	client := mocks.NewAPIClient(t)
+	chargerCache := cache.NewCache("XX12345")
+	chargerCache.SetMaxCurrent(16, start)
+	chargerCache.SetInstallationParameters(chargepoint.GridTypeTN, 3, start)
+	chargerCache.SetChargerState(chargepoint.StateReadyToCharge, start)
+
+	c := easee.NewController(newManager(t), client, "XX12345", chargerCache, cfgService, db.NewSessionStorage(dataBase), db.NewScheduleStorage(dataBase), db.NewDepartureStorage(dataBase))
+	settings := &chargepoint.ChargingSettings{Mode: model.ChargingModeCheapest}
+
+	// 15 kWh by the departure requires two of the hours at 11 kW.
+	err = c.SetChargepointDepartureTarget(model.DepartureTarget{Energy: 15, DepartureAt: hour(4)})
+	require.NoError(t, err)
+
+	assert.Error(t, c.StartChargepointCharging(settings), "price curve is required")
+
+	// The tariff used for the session cost is not used to plan the charging.
+	err = cfgService.SetTariff(&model.Tariff{Type: model.TariffTypeFixed, Currency: "NOK", Price: 1})
+	require.NoError(t, err)
+
+	assert.Error(t, c.StartChargepointCharging(settings), "price curve is required")
+
+	err = cfgService.SetPriceCurve(&model.PriceCurve{
+		Prices: []model.HourlyPrice{
+			{Start: hour(0), Price: 1.1},
+			{Start: hour(1), Price: 0.4},
+			{Start: hour(2), Price: 0.9},
+			{Start: hour(3), Price: 0.6},
+		},
+	})
+	require.NoError(t, err)
+
+	client.On("StopCharging", mock.Anything, "XX12345").Return(nil).Once()
+	require.NoError(t, c.StartChargepointCharging(settings))
+
+	steps := []struct {
+		name   string
+		at     time.Time
+		state  chargepoint.State
+		energy float64
+		mock   func()
+	}{
+		{
+			name:  "charging remains paused in the expensive hour",
+			at:    hour(0).Add(30 * time.Minute),
+			state: chargepoint.StateReadyToCharge,
+		},
+		{
+			name:  "charging is paused again if started by the charger in the expensive hour",
+			at:    hour(0).Add(40 * time.Minute),
+			state: chargepoint.StateCharging,
+			mock: func() {
+				client.On("StopCharging", mock.Anything, "XX12345").Return(nil).Once()
+			},
+		},
+		{
+			name:  "charging is resumed in the cheapest hour",
+			at:    hour(1),
+			state: chargepoint.StateReadyToCharge,
+			mock: func() {
+				client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(16)).Return(nil).Once()
+			},
+		},
+		{
+			name:   "charging continues within the cheapest hour",
+			at:     hour(1).Add(30 * time.Minute),
+			state:  chargepoint.StateCharging,
+			energy: 5,
+		},
+		{
+			name:   "charging is paused in the expensive hour",
+			at:     hour(2),
+			state:  chargepoint.StateCharging,
+			energy: 11,
+			mock: func() {
+				client.On("StopCharging", mock.Anything, "XX12345").Return(nil).Once()
+			},
+		},
+		{
+			name:   "charging is resumed in the second cheapest hour",
+			at:     hour(3),
+			state:  chargepoint.StateReadyToCharge,
+			energy: 11,
+			mock: func() {
+				client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(16)).Return(nil).Once()
+			},
+		},
+		{
+			name:   "charging is stopped once the target energy is charged",
+			at:     hour(3).Add(30 * time.Minute),
+			state:  chargepoint.StateCharging,
+			energy: 15,
+			mock: func() {
+				client.On("StopCharging", mock.Anything, "XX12345").Return(nil).Once()
+			},
+		},
+		{
+			name:   "charging is not adjusted after the cheapest charging is finished",
+			at:     hour(3).Add(45 * time.Minute),
+			state:  chargepoint.StateCharging,
+			energy: 15,
+		},
+	}
+
+	for _, s := range steps {
+		mockedClock.Set(s.at)
+		chargerCache.SetChargerState(s.state, s.at)
+		chargerCache.SetEnergySession(s.energy, s.at)
+
+		if s.mock != nil {
+			s.mock()
+		}
+
+		assert.NoError(t, c.AdjustChargepointDepartureCharging(), s.name)
+		client.AssertExpectations(t)
+	}
+}
+
+func TestController_SolarCharging(t *testing.T) { //nolint:paralleltest
+	start := time.Date(2025, time.June, 20, 12, 0, 0, 0, time.Local)
+
+	mockedClock := clock.Mock(start)
+	t.Cleanup(clock.Restore)
+
+	dataBase, err := database.NewDatabase(t.TempDir())
+	require.NoError(t, err)
+
+	cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+	client := mocks.NewAPIClient(t)
+	chargerCache := cache.NewCache("XX12345")
+	chargerCache.SetMaxCurrent(16, start)
+	chargerCache.SetInstallationParameters(chargepoint.GridTypeTN, 3, start)
+	chargerCache.SetChargerState(chargepoint.StateReadyToCharge, start)
+
+	c := easee.NewController(newManager(t), client, "XX12345", chargerCache, cfgService, db.NewSessionStorage(dataBase), db.NewScheduleStorage(dataBase), db.NewDepartureStorage(dataBase))
+	settings := &chargepoint.ChargingSettings{Mode: model.ChargingModeSolar}
+
+	assert.Error(t, c.StartChargepointCharging(settings), "solar meter topic is required")
+
+	require.NoError(t, cfgService.SetSolarMeterTopic("pt:j1/mt:evt/rt:dev/rn:han/ad:1/sv:meter_elec/ad:1"))
+	require.NoError(t, c.StartChargepointCharging(settings))
+
+	steps := []struct {
+		name      string
+		at        time.Time
+		state     chargepoint.State
+		measured  float64
+		gridPower float64
+		mock      func()
+	}{
+		{
+			name:      "charging is not started without sufficient surplus",
+			at:        start,
+			state:     chargepoint.StateReadyToCharge,
+			gridPower: -3000,
+		},
+		{
+			name:      "charging is started once the surplus is sufficient",
+			at:        start.Add(time.Minute),
+			state:     chargepoint.StateReadyToCharge,
+			gridPower: -5600,
+			mock: func() {
+				client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(8)).Return(nil).Once()
+			},
+		},
+		{
+			name:      "current is not adjusted before the wait time elapses",
+			at:        start.Add(time.Minute + 10*time.Second),
+			state:     chargepoint.StateCharging,
+			measured:  8,
+			gridPower: -2800,
+		},
+		{
+			name:      "current is increased with the surplus",
+			at:        start.Add(2 * time.Minute),
+			state:     chargepoint.StateCharging,
+			measured:  8,
+			gridPower: -2800,
+			mock: func() {
+				client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(12)).Return(nil).Once()
+			},
+		},
+		{
+			name:      "current is not adjusted within the hysteresis",
+			at:        start.Add(3 * time.Minute),
+			state:     chargepoint.StateCharging,
+			measured:  12,
+			gridPower: 300,
+		},
+		{
+			name:      "charging is paused once the surplus is insufficient",
+			at:        start.Add(4 * time.Minute),
+			state:     chargepoint.StateCharging,
+			measured:  12,
+			gridPower: 5000,
+			mock: func() {
+				client.On("StopCharging", mock.Anything, "XX12345").Return(nil).Once()
+			},
+		},
+		{
+			name:      "solar charging is finished once the car is disconnected",
+			at:        start.Add(5 * time.Minute),
+			state:     chargepoint.StateDisconnected,
+			gridPower: -10000,
+		},
+		{
+			name:      "current is not adjusted after the solar charging is finished",
+			at:        start.Add(6 * time.Minute),
+			state:     chargepoint.StateReadyToCharge,
+			gridPower: -10000,
+		},
+	}
+
+	for _, s := range steps {
+		mockedClock.Set(s.at)
+		chargerCache.SetChargerState(s.state, s.at)
+		chargerCache.SetPhase1Current(s.measured, s.at)
+		chargerCache.SetPhase2Current(s.measured, s.at)
+		chargerCache.SetPhase3Current(s.measured, s.at)
+
+		if s.mock != nil {
+			s.mock()
+		}
+
+		assert.NoError(t, c.AdjustChargepointSolarCharging(s.gridPower), s.name)
+		client.AssertExpectations(t)
+	}
+}
+
+func TestController_AdjustChargepointLoadGuard(t *testing.T) { //nolint:paralleltest
+	start := time.Date(2025, time.January, 20, 18, 0, 0, 0, time.Local)
+
+	mockedClock := clock.Mock(start)
+	t.Cleanup(clock.Restore)
+
+	dataBase, err := database.NewDatabase(t.TempDir())
+	require.NoError(t, err)
+
+	cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+	client := mocks.NewAPIClient(t)
+	chargerCache := cache.NewCache("XX12345")
+	chargerCache.SetMaxCurrent(32, start)
+	chargerCache.SetInstallationParameters(chargepoint.GridTypeTN, 3, start)
+	chargerCache.SetChargerState(chargepoint.StateCharging, start)
+	chargerCache.SetOfferedCurrent(16, start)
+
+	c := easee.NewController(newManager(t), client, "XX12345", chargerCache, cfgService, db.NewSessionStorage(dataBase), db.NewScheduleStorage(dataBase), db.NewDepartureStorage(dataBase))
+
+	// Load guard is disabled until the main fuse rating is configured.
+	assert.NoError(t, c.AdjustChargepointLoadGuard([3]float64{40, 40, 40}, 1))
+	require.NoError(t, cfgService.SetMainFuseRating(25))
+
+	steps := []struct {
+		name           string
+		at             time.Time
+		houseCurrent   float64
+		chargerCurrent float64
+		mock           func()
+	}{
+		{
+			name:           "current is lowered before the fuse limit is exceeded",
+			at:             start,
+			houseCurrent:   30,
+			chargerCurrent: 16,
+			mock: func() {
+				client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(9)).Return(nil).Once()
+			},
+		},
+		{
+			name:           "current is not changed while the load is stable",
+			at:             start.Add(10 * time.Second),
+			houseCurrent:   23,
+			chargerCurrent: 9,
+		},
+		{
+			name:           "charging is paused immediately if the limit drops below the minimum current",
+			at:             start.Add(20 * time.Second),
+			houseCurrent:   29,
+			chargerCurrent: 9,
+			mock: func() {
+				client.On("StopCharging", mock.Anything, "XX12345").Return(nil).Once()
+			},
+		},
+		{
+			name:           "charging is resumed with the limited current once there is headroom",
+			at:             start.Add(time.Minute),
+			houseCurrent:   14,
+			chargerCurrent: 0,
+			mock: func() {
+				client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(9)).Return(nil).Once()
+			},
+		},
+		{
+			name:           "current is not raised before the wait time elapses",
+			at:             start.Add(time.Minute + 10*time.Second),
+			houseCurrent:   13,
+			chargerCurrent: 9,
+		},
+		{
+			name:           "current is restored once the headroom returns",
+			at:             start.Add(2 * time.Minute),
+			houseCurrent:   13,
+			chargerCurrent: 9,
+			mock: func() {
+				client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(16)).Return(nil).Once()
+			},
+		},
+		{
+			name:           "current is not changed while below the limit",
+			at:             start.Add(3 * time.Minute),
+			houseCurrent:   20,
+			chargerCurrent: 16,
+		},
+	}
+
+	for _, s := range steps {
+		mockedClock.Set(s.at)
+		chargerCache.SetPhase1Current(s.chargerCurrent, s.at)
+		chargerCache.SetPhase2Current(s.chargerCurrent, s.at)
+		chargerCache.SetPhase3Current(s.chargerCurrent, s.at)
+
+		if s.mock != nil {
+			s.mock()
+		}
+
+		assert.NoError(t, c.AdjustChargepointLoadGuard([3]float64{s.houseCurrent, s.houseCurrent, s.houseCurrent}, 1), s.name)
+		client.AssertExpectations(t)
+	}
+}
+
+func TestController_SetChargepointAuthorization(t *testing.T) { //nolint:paralleltest
+	now := time.Now()
+
+	dataBase, err := database.NewDatabase(t.TempDir())
+	require.NoError(t, err)
+
+	cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+	client := mocks.NewAPIClient(t)
+	manager := mocks.NewManager(t)
+	chargerCache := cache.NewCache("XX12345")
+
+	manager.On("Connected", "XX12345").Return(true, signalr.DisconnectionReason(""))
+
+	c := easee.NewController(manager, client, "XX12345", chargerCache, cfgService, db.NewSessionStorage(dataBase), db.NewScheduleStorage(dataBase), db.NewDepartureStorage(dataBase))
+
+	chargerCache.SetChargerState(chargepoint.StateReadyToCharge, now)
+	assert.Error(t, c.SetChargepointAuthorization(true), "charging is not authorized if the charger is not awaiting authorization")
+
+	chargerCache.SetChargerState(chargepoint.StateRequesting, now)
+	client.On("AuthorizeCharging", mock.Anything, "XX12345").Return(nil).Once()
+	assert.NoError(t, c.SetChargepointAuthorization(true))
+
+	client.On("DeauthorizeCharging", mock.Anything, "XX12345").Return(nil).Once()
+	assert.NoError(t, c.SetChargepointAuthorization(false))
+}
+
+func TestController_SetChargepointPhaseMode(t *testing.T) { //nolint:paralleltest
+	now := time.Now()
+
+	dataBase, err := database.NewDatabase(t.TempDir())
+	require.NoError(t, err)
+
+	cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+	require.NoError(t, cfgService.SetCurrentWaitDuration(time.Millisecond))
+
+	client := mocks.NewAPIClient(t)
+	chargerCache := cache.NewCache("XX12345")
+	chargerCache.SetInstallationParameters(chargepoint.GridTypeTN, 3, now)
+	chargerCache.SetPhaseMode(model.PhaseModeLockedToSinglePhase, now)
+
+	c := easee.NewController(newManager(t), client, "XX12345", chargerCache, cfgService, db.NewSessionStorage(dataBase), db.NewScheduleStorage(dataBase), db.NewDepartureStorage(dataBase))
+
+	assert.Error(t, c.SetChargepointPhaseMode(chargepoint.PhaseModeL1L2), "phase mode of another grid type is not supported")
+
+	client.On("UpdatePhaseMode", mock.Anything, "XX12345", model.PhaseModeAuto).Return(nil).Once()
+	assert.NoError(t, c.SetChargepointPhaseMode(chargepoint.PhaseModeNL1L2L3), "change not confirmed by the charger is not an error")
+
+	chargerCache.SetPhaseMode(model.PhaseModeAuto, now)
+
+	client.On("UpdatePhaseMode", mock.Anything, "XX12345", model.PhaseModeLockedToSinglePhase).Return(nil).Once()
+	assert.NoError(t, c.SetChargepointPhaseMode(chargepoint.PhaseModeNL2), "any single phase mode locks the charger to a single phase")
+}
+
+func TestController_SetChargepointOfferedCurrent(t *testing.T) { //nolint:paralleltest
+	start := time.Date(2025, time.January, 20, 20, 0, 0, 0, time.Local)
+
+	mockedClock := clock.Mock(start)
+	t.Cleanup(clock.Restore)
+
+	dataBase, err := database.NewDatabase(t.TempDir())
+	require.NoError(t, err)
+
+	cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+	require.NoError(t, cfgService.SetCurrentWaitDuration(time.Millisecond))
+
+	client := mocks.NewAPIClient(t)
+	chargerCache := cache.NewCache("XX12345")
+
+	c := easee.NewController(newManager(t), client, "XX12345", chargerCache, cfgService, db.NewSessionStorage(dataBase), db.NewScheduleStorage(dataBase), db.NewDepartureStorage(dataBase))
+
+	client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(16)).Return(nil).Once()
+	assert.NoError(t, c.SetChargepointOfferedCurrent(16), "the first change is applied right away")
+
+	mockedClock.Add(10 * time.Second)
+
+	assert.NoError(t, c.SetChargepointOfferedCurrent(10), "changes within the wait time are queued")
+	assert.NoError(t, c.SetChargepointOfferedCurrent(12), "changes within the wait time are queued")
+
+	requested, _ := chargerCache.RequestedOfferedCurrent()
+	assert.Equal(t, int64(12), requested, "the latest queued current is reported as requested")
+	client.AssertExpectations(t)
+
+	applied := make(chan struct{})
+
+	client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(12)).
+		Run(func(mock.Arguments) { close(applied) }).
+		Return(nil).
+		Once()
+	mockedClock.Add(20 * time.Second)
+
+	select {
+	case <-applied:
+	case <-time.After(time.Second):
+		t.Fatal("the latest queued current is not applied once the wait time elapses")
+	}
+
+	mockedClock.Add(5 * time.Second)
+
+	assert.NoError(t, c.SetChargepointOfferedCurrent(8), "changes within the wait time are queued")
+
+	client.On("StopCharging", mock.Anything, "XX12345").Return(nil).Once()
+	assert.NoError(t, c.StopChargepointCharging())
+
+	mockedClock.Add(time.Minute)
+	client.AssertExpectations(t)
+}
+
+// newManager returns a SignalR manager mock reporting the charger as connected.
+func newManager(t *testing.T) *mocks.Manager {
+	t.Helper()
+
+	manager := mocks.NewManager(t)
+	manager.On("Connected", "XX12345").Return(true, signalr.DisconnectionReason("")).Maybe()
+
+	return manager
+}
+
+func TestController_OfferedCurrentLimits(t *testing.T) { //nolint:paralleltest
+	start := time.Date(2025, time.January, 20, 18, 0, 0, 0, time.Local)
+
+	mockedClock := clock.Mock(start)
+	t.Cleanup(clock.Restore)
+
+	dataBase, err := database.NewDatabase(t.TempDir())
+	require.NoError(t, err)
+
+	cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+	require.NoError(t, cfgService.SetCurrentWaitDuration(time.Millisecond))
+	require.NoError(t, cfgService.SetMainFuseRating(25))
+
+	client := mocks.NewAPIClient(t)
+	chargerCache := cache.NewCache("XX12345")
+	chargerCache.SetMaxCurrent(32, start)
+	chargerCache.SetInstallationParameters(chargepoint.GridTypeTN, 3, start)
+	chargerCache.SetChargerState(chargepoint.StateCharging, start)
+	chargerCache.SetOfferedCurrent(16, start)
+
+	c := easee.NewController(newManager(t), client, "XX12345", chargerCache, cfgService, db.NewSessionStorage(dataBase), db.NewScheduleStorage(dataBase), db.NewDepartureStorage(dataBase))
+
+	setPhaseCurrents := func(current float64, at time.Time) {
+		chargerCache.SetPhase1Current(current, at)
+		chargerCache.SetPhase2Current(current, at)
+		chargerCache.SetPhase3Current(current, at)
+	}
+
+	client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(16)).Return(nil).Once()
+	assert.NoError(t, c.SetChargepointOfferedCurrent(16))
+
+	mockedClock.Add(10 * time.Second)
+	assert.NoError(t, c.SetChargepointOfferedCurrent(32), "the change within the wait time is queued")
+
+	mockedClock.Add(5 * time.Second)
+	setPhaseCurrents(16, mockedClock.Now())
+
+	client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(9)).Return(nil).Once()
+	assert.NoError(t, c.AdjustChargepointLoadGuard([3]float64{30, 30, 30}, 1), "the load guard lowers the current immediately")
+	client.AssertExpectations(t)
+
+	// The queued current is applied limited by the load guard, which has already been done.
+	mockedClock.Add(30 * time.Second)
+	client.AssertExpectations(t)
+
+	mockedClock.Add(5 * time.Second)
+	setPhaseCurrents(9, mockedClock.Now())
+
+	client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(12)).Return(nil).Once()
+	assert.NoError(t, c.AdjustChargepointLoadGuard([3]float64{20, 20, 20}, 1), "the load guard raises the limit")
+	client.AssertExpectations(t)
+
+	mockedClock.Add(5 * time.Second)
+	assert.NoError(t, c.SetChargepointOfferedCurrent(20), "the change above the limit is not applied")
+
+	requested, _ := chargerCache.RequestedOfferedCurrent()
+	assert.Equal(t, int64(20), requested, "the latest requested current is reported")
+	client.AssertExpectations(t)
+
+	mockedClock.Add(time.Minute)
+	setPhaseCurrents(12, mockedClock.Now())
+
+	client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(20)).Return(nil).Once()
+	assert.NoError(t, c.AdjustChargepointLoadGuard([3]float64{14, 14, 14}, 1), "the latest requested current is restored")
+	client.AssertExpectations(t)
+}
+
+func TestController_ManagedOfferedCurrentQueue(t *testing.T) { //nolint:paralleltest
+	start := time.Date(2025, time.June, 20, 12, 0, 0, 0, time.Local)
+
+	mockedClock := clock.Mock(start)
+	t.Cleanup(clock.Restore)
+
+	dataBase, err := database.NewDatabase(t.TempDir())
+	require.NoError(t, err)
+
+	cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+	require.NoError(t, cfgService.SetCurrentWaitDuration(time.Millisecond))
+	require.NoError(t, cfgService.SetSolarMeterTopic("pt:j1/mt:evt/rt:dev/rn:han/ad:1/sv:meter_elec/ad:1"))
+
+	client := mocks.NewAPIClient(t)
+	chargerCache := cache.NewCache("XX12345")
+	chargerCache.SetMaxCurrent(16, start)
+	chargerCache.SetInstallationParameters(chargepoint.GridTypeTN, 3, start)
+	chargerCache.SetChargerState(chargepoint.StateReadyToCharge, start)
+
+	c := easee.NewController(newManager(t), client, "XX12345", chargerCache, cfgService, db.NewSessionStorage(dataBase), db.NewScheduleStorage(dataBase), db.NewDepartureStorage(dataBase))
+
+	client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(16)).Return(nil).Once()
+	assert.NoError(t, c.SetChargepointOfferedCurrent(16))
+
+	mockedClock.Add(5 * time.Second)
+	require.NoError(t, c.StartChargepointCharging(&chargepoint.ChargingSettings{Mode: model.ChargingModeSolar}))
+
+	mockedClock.Add(5 * time.Second)
+	assert.NoError(t, c.AdjustChargepointSolarCharging(-5600), "the solar current within the wait time of the last change is queued")
+	client.AssertExpectations(t)
+
+	applied := make(chan struct{})
+
+	client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(8)).
+		Run(func(mock.Arguments) { close(applied) }).
+		Return(nil).
+		Once()
+	mockedClock.Add(20 * time.Second)
+
+	select {
+	case <-applied:
+	case <-time.After(time.Second):
+		t.Fatal("the queued solar current is not applied once the wait time elapses")
+	}
+}
+
+func TestController_LimitChargepointSharedCurrent(t *testing.T) { //nolint:paralleltest
+	start := time.Date(2025, time.January, 20, 18, 0, 0, 0, time.Local)
+
+	mockedClock := clock.Mock(start)
+	t.Cleanup(clock.Restore)
+
+	dataBase, err := database.NewDatabase(t.TempDir())
+	require.NoError(t, err)
+
+	cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+	require.NoError(t, cfgService.SetCurrentWaitDuration(time.Millisecond))
+
+	client := mocks.NewAPIClient(t)
+	chargerCache := cache.NewCache("XX12345")
+
+	c := easee.NewController(newManager(t), client, "XX12345", chargerCache, cfgService, db.NewSessionStorage(dataBase), db.NewScheduleStorage(dataBase), db.NewDepartureStorage(dataBase))
+
+	client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(16)).Return(nil).Once()
+	assert.NoError(t, c.SetChargepointOfferedCurrent(16))
+
+	mockedClock.Add(10 * time.Second)
+
+	client.On("UpdateDynamicCurrent", mock.Anything, "XX12345", float64(10)).Return(nil).Once()
+	assert.NoError(t, c.LimitChargepointSharedCurrent(10), "the share is applied immediately")
+	client.AssertExpectations(t)
+
+	mockedClock.Add(10 * time.Second)
+	assert.NoError(t, c.LimitChargepointSharedCurrent(12), "the raised share waits for the wait time")
+	client.AssertExpectations(t)
+
+	client.On("StopCharging", mock.Anything, "XX12345").Return(nil).Once()
+	assert.NoError(t, c.LimitChargepointSharedCurrent(0), "charging is paused without the share")
+	client.AssertExpectations(t)
+
+	mockedClock.Add(time.Minute)
+
+	client.On("StopCharging", mock.Anything, "XX12345").Return(nil).Once()
+	assert.NoError(t, c.StopChargepointCharging())
+
+	mockedClock.Add(time.Minute)
+	assert.NoError(t, c.LimitChargepointSharedCurrent(-1), "charging stopped on request is not resumed once the limit is lifted")
+	client.AssertExpectations(t)
+}
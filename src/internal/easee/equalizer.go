@@ -0,0 +1,170 @@
+package easee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/futurehomeno/cliffhanger/adapter"
+	"github.com/futurehomeno/cliffhanger/adapter/service/numericmeter"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/api"
+	"github.com/futurehomeno/edge-easee-adapter/internal/cache"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+	"github.com/futurehomeno/edge-easee-adapter/internal/signalr"
+)
+
+// EqualizerController represents an equalizer controller reporting measurements of the main meter.
+type EqualizerController interface {
+	numericmeter.Reporter
+	numericmeter.ExportReporter
+	numericmeter.ExtendedReporter
+}
+
+// equalizerExtendedReportMapping maps extended meter values to equalizer observations.
+var equalizerExtendedReportMapping = map[numericmeter.Value]model.ObservationID{
+	numericmeter.ValueCurrentPhase1: model.EqualizerCurrentL1,
+	numericmeter.ValueCurrentPhase2: model.EqualizerCurrentL2,
+	numericmeter.ValueCurrentPhase3: model.EqualizerCurrentL3,
+	numericmeter.ValuePowerImport:   model.EqualizerActivePowerImport,
+	numericmeter.ValuePowerExport:   model.EqualizerActivePowerExport,
+	numericmeter.ValueEnergyImport:  model.EqualizerCumulativeActivePowerImport,
+	numericmeter.ValueEnergyExport:  model.EqualizerCumulativeActivePowerExport,
+}
+
+type equalizerController struct {
+	manager     signalr.Manager
+	equalizerID string
+	cache       cache.EqualizerCache
+}
+
+// NewEqualizerController returns a new instance of EqualizerController.
+func NewEqualizerController(manager signalr.Manager, equalizerID string, cache cache.EqualizerCache) EqualizerController {
+	return &equalizerController{
+		manager:     manager,
+		equalizerID: equalizerID,
+		cache:       cache,
+	}
+}
+
+func (c *equalizerController) MeterReport(unit numericmeter.Unit) (float64, error) {
+	return c.measurement(unit, model.EqualizerActivePowerImport, model.EqualizerCumulativeActivePowerImport)
+}
+
+func (c *equalizerController) MeterExportReport(unit numericmeter.Unit) (float64, error) {
+	return c.measurement(unit, model.EqualizerActivePowerExport, model.EqualizerCumulativeActivePowerExport)
+}
+
+func (c *equalizerController) MeterExtendedReport(values numericmeter.Values) (numericmeter.ValuesReport, error) {
+	if err := c.checkConnection(); err != nil {
+		return nil, err
+	}
+
+	ret := make(numericmeter.ValuesReport, len(values))
+
+	for _, value := range values {
+		id, ok := equalizerExtendedReportMapping[value]
+		if !ok {
+			continue
+		}
+
+		if measurement, timestamp := c.cache.Measurement(id); !timestamp.IsZero() {
+			ret[value] = measurement
+		}
+	}
+
+	return ret, nil
+}
+
+// measurement returns the cached power or energy measurement depending on the unit.
+func (c *equalizerController) measurement(unit numericmeter.Unit, power, energy model.ObservationID) (float64, error) {
+	if err := c.checkConnection(); err != nil {
+		return 0, err
+	}
+
+	var id model.ObservationID
+
+	switch unit { //nolint:exhaustive
+	case numericmeter.UnitW:
+		id = power
+	case numericmeter.UnitKWh:
+		id = energy
+	default:
+		return 0, fmt.Errorf("unsupported unit: %s", unit)
+	}
+
+	measurement, timestamp := c.cache.Measurement(id)
+	if timestamp.IsZero() {
+		return 0, fmt.Errorf("%s value not updated", unit)
+	}
+
+	return measurement, nil
+}
+
+func (c *equalizerController) checkConnection() error {
+	connected, reason := c.manager.Connected(c.equalizerID)
+	if !connected {
+		return fmt.Errorf("equalizer %s is not connected: %s", c.equalizerID, reason)
+	}
+
+	return nil
+}
+
+type equalizerConnector struct {
+	manager    signalr.Manager
+	httpClient api.Client
+
+	equalizerID string
+	cache       cache.EqualizerCache
+}
+
+// NewEqualizerConnector returns a new instance of adapter.Connector for equalizers.
+func NewEqualizerConnector(manager signalr.Manager, httpClient api.Client, equalizerID string, cache cache.EqualizerCache) adapter.Connector {
+	return &equalizerConnector{
+		manager:     manager,
+		httpClient:  httpClient,
+		equalizerID: equalizerID,
+		cache:       cache,
+	}
+}
+
+func (c *equalizerConnector) Connect(thing adapter.Thing) {
+	c.manager.Register(c.equalizerID, signalr.NewEqualizerHandler(thing, c.cache))
+}
+
+func (c *equalizerConnector) Disconnect(_ adapter.Thing) {
+	if err := c.manager.Unregister(c.equalizerID); err != nil {
+		log.WithError(err).Error("failed to unregister equalizer within signalR manager")
+	}
+}
+
+func (c *equalizerConnector) Connectivity() *adapter.ConnectivityDetails {
+	ret := adapter.ConnectivityDetails{
+		ConnectionStatus: adapter.ConnectionStatusDown,
+		ConnectionType:   adapter.ConnectionTypeIndirect,
+	}
+
+	if connected, _ := c.manager.Connected(c.equalizerID); connected {
+		ret.ConnectionStatus = adapter.ConnectionStatusUp
+	}
+
+	return &ret
+}
+
+func (c *equalizerConnector) Ping() *adapter.PingDetails {
+	if err := c.httpClient.Ping(context.Background()); err != nil {
+		return &adapter.PingDetails{
+			Status: adapter.PingResultFailed,
+		}
+	}
+
+	if connected, _ := c.manager.Connected(c.equalizerID); !connected {
+		return &adapter.PingDetails{
+			Status: adapter.PingResultFailed,
+		}
+	}
+
+	return &adapter.PingDetails{
+		Status: adapter.PingResultSuccess,
+	}
+}
@@ -0,0 +1,73 @@
+package easee_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/futurehomeno/cliffhanger/adapter/service/numericmeter"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/cache"
+	"github.com/futurehomeno/edge-easee-adapter/internal/easee"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+	"github.com/futurehomeno/edge-easee-adapter/internal/signalr"
+	"github.com/futurehomeno/edge-easee-adapter/internal/test/mocks"
+)
+
+func TestEqualizerController(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	manager := mocks.NewManager(t)
+	manager.On("Connected", "QH12345").Return(true, signalr.DisconnectionReason(""))
+
+	equalizerCache := cache.NewEqualizerCache("QH12345")
+	equalizerCache.SetMeasurement(model.EqualizerActivePowerImport, 2500, now)
+	equalizerCache.SetMeasurement(model.EqualizerActivePowerExport, 0, now)
+	equalizerCache.SetMeasurement(model.EqualizerCurrentL1, 10.5, now)
+	equalizerCache.SetMeasurement(model.EqualizerCumulativeActivePowerImport, 1234.5, now)
+
+	c := easee.NewEqualizerController(manager, "QH12345", equalizerCache)
+
+	power, err := c.MeterReport(numericmeter.UnitW)
+	assert.NoError(t, err)
+	assert.Equal(t, 2500.0, power)
+
+	energy, err := c.MeterReport(numericmeter.UnitKWh)
+	assert.NoError(t, err)
+	assert.Equal(t, 1234.5, energy)
+
+	exported, err := c.MeterExportReport(numericmeter.UnitW)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, exported)
+
+	_, err = c.MeterExportReport(numericmeter.UnitKWh)
+	assert.Error(t, err, "exported energy has not been reported yet")
+
+	report, err := c.MeterExtendedReport(numericmeter.Values{
+		numericmeter.ValueCurrentPhase1,
+		numericmeter.ValueCurrentPhase2,
+		numericmeter.ValuePowerImport,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, numericmeter.ValuesReport{
+		numericmeter.ValueCurrentPhase1: 10.5,
+		numericmeter.ValuePowerImport:   2500,
+	}, report)
+}
+
+func TestEqualizerController_NotConnected(t *testing.T) {
+	t.Parallel()
+
+	manager := mocks.NewManager(t)
+	manager.On("Connected", "QH12345").Return(false, signalr.ChargerNotSubscribed)
+
+	c := easee.NewEqualizerController(manager, "QH12345", cache.NewEqualizerCache("QH12345"))
+
+	_, err := c.MeterReport(numericmeter.UnitW)
+	assert.Error(t, err)
+
+	_, err = c.MeterExtendedReport(numericmeter.Values{numericmeter.ValueCurrentPhase1})
+	assert.Error(t, err)
+}
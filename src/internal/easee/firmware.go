@@ -0,0 +1,184 @@
+package easee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/futurehomeno/cliffhanger/adapter"
+	"github.com/futurehomeno/cliffhanger/notification"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/api"
+	"github.com/futurehomeno/edge-easee-adapter/internal/config"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+// FirmwareMonitor tracks firmware versions of chargers, persists them and notifies the user about their changes.
+type FirmwareMonitor interface {
+	// Register starts monitoring the firmware of the charger and checks its versions right away.
+	Register(chargerID, product string, thing adapter.Thing, thingState adapter.ThingState)
+	// Unregister stops monitoring the firmware of the charger.
+	Unregister(chargerID string)
+	// FirmwareVersionObserved records the firmware version reported by the charger.
+	FirmwareVersionObserved(chargerID string, version int) error
+	// Check fetches firmware versions of all registered chargers, so the user learns about a newly available firmware.
+	Check() error
+}
+
+type firmwareMonitor struct {
+	client     api.Client
+	cfgService *config.Service
+	notifier   api.Notifier
+
+	chargers map[string]*firmwareCharger
+	lock     sync.Mutex
+}
+
+// firmwareCharger represents a charger which firmware is monitored.
+type firmwareCharger struct {
+	product    string
+	thing      adapter.Thing
+	thingState adapter.ThingState
+}
+
+// NewFirmwareMonitor returns a new instance of FirmwareMonitor.
+func NewFirmwareMonitor(client api.Client, cfgService *config.Service, notifier api.Notifier) FirmwareMonitor {
+	return &firmwareMonitor{
+		client:     client,
+		cfgService: cfgService,
+		notifier:   notifier,
+		chargers:   make(map[string]*firmwareCharger),
+	}
+}
+
+func (m *firmwareMonitor) Register(chargerID, product string, thing adapter.Thing, thingState adapter.ThingState) {
+	m.lock.Lock()
+	m.chargers[chargerID] = &firmwareCharger{product: product, thing: thing, thingState: thingState}
+	m.lock.Unlock()
+
+	if err := m.check(chargerID); err != nil {
+		log.WithError(err).
+			WithField("charger_id", chargerID).
+			Warn("firmware monitor: failed to check charger firmware")
+	}
+}
+
+func (m *firmwareMonitor) Unregister(chargerID string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.chargers, chargerID)
+}
+
+func (m *firmwareMonitor) FirmwareVersionObserved(chargerID string, version int) error {
+	return m.update(chargerID, func(firmware *model.Firmware) {
+		firmware.Version = version
+	})
+}
+
+func (m *firmwareMonitor) Check() error {
+	m.lock.Lock()
+	chargerIDs := make([]string, 0, len(m.chargers))
+
+	for chargerID := range m.chargers {
+		chargerIDs = append(chargerIDs, chargerID)
+	}
+	m.lock.Unlock()
+
+	var errs []error
+
+	for _, chargerID := range chargerIDs {
+		if err := m.check(chargerID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// check fetches firmware versions of the charger from the API.
+func (m *firmwareMonitor) check(chargerID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.cfgService.GetCommandTimeout())
+	defer cancel()
+
+	fetched, err := m.client.ChargerFirmware(ctx, chargerID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch firmware of charger %s: %w", chargerID, err)
+	}
+
+	return m.update(chargerID, func(firmware *model.Firmware) {
+		*firmware = *fetched
+	})
+}
+
+// update applies the change to the persisted firmware versions of the charger.
+// Once the installed version changes, the software version of the inclusion report is updated as well.
+func (m *firmwareMonitor) update(chargerID string, change func(firmware *model.Firmware)) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	ch, ok := m.chargers[chargerID]
+	if !ok {
+		return nil
+	}
+
+	state := &State{}
+	if err := ch.thingState.State(state); err != nil {
+		return fmt.Errorf("failed to retrieve state of charger %s: %w", chargerID, err)
+	}
+
+	previous := state.Firmware()
+	current := previous
+	change(&current)
+
+	if current == previous {
+		return nil
+	}
+
+	state.FirmwareVersion = current.Version
+	state.LatestFirmwareVersion = current.LatestVersion
+
+	if err := ch.thingState.SetState(state); err != nil {
+		return fmt.Errorf("failed to set state of charger %s: %w", chargerID, err)
+	}
+
+	if current.Version != previous.Version {
+		ch.thing.InclusionReport().SwVersion = model.FirmwareVersionString(current.Version)
+
+		if _, err := ch.thing.SendInclusionReport(false); err != nil {
+			return err
+		}
+	}
+
+	m.notify(chargerID, ch.product, previous, current)
+
+	return nil
+}
+
+// notify notifies the user about the installed firmware update and about a newly available firmware.
+func (m *firmwareMonitor) notify(chargerID, product string, previous, current model.Firmware) {
+	var events []*notification.Event
+
+	// The version is unknown until the charger is included, so there is no update to notify about.
+	if previous.Version > 0 && current.Version > previous.Version {
+		events = append(events, &notification.Event{
+			EventName:      model.NotificationFirmwareUpdated,
+			MessageContent: fmt.Sprintf("%s %s: firmware updated to version %d", product, chargerID, current.Version),
+		})
+	}
+
+	if current.UpdateAvailable() && current.LatestVersion != previous.LatestVersion {
+		events = append(events, &notification.Event{
+			EventName:      model.NotificationFirmwareUpdateAvailable,
+			MessageContent: fmt.Sprintf("%s %s: firmware version %d is available", product, chargerID, current.LatestVersion),
+		})
+	}
+
+	for _, event := range events {
+		if err := m.notifier.Event(event); err != nil {
+			log.WithError(err).Warnf("firmware monitor: failed to send %s notification", event.EventName)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package easee_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/futurehomeno/cliffhanger/notification"
+	mockedadapter "github.com/futurehomeno/cliffhanger/test/mocks/adapter"
+	"github.com/futurehomeno/fimpgo/fimptype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/config"
+	"github.com/futurehomeno/edge-easee-adapter/internal/easee"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+	"github.com/futurehomeno/edge-easee-adapter/internal/test/fakes"
+	"github.com/futurehomeno/edge-easee-adapter/internal/test/mocks"
+)
+
+func TestFirmwareMonitor(t *testing.T) {
+	t.Parallel()
+
+	cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+	client := mocks.NewAPIClient(t)
+	notifier := mocks.NewNotifier(t)
+	thing := mockedadapter.NewThing(t)
+	thingState := mockedadapter.NewThingState(t)
+
+	state := &easee.State{FirmwareVersion: 300, LatestFirmwareVersion: 300}
+
+	thingState.On("State", mock.Anything).Run(func(args mock.Arguments) {
+		data, _ := json.Marshal(state)
+		_ = json.Unmarshal(data, args.Get(0))
+	}).Return(nil)
+	thingState.On("SetState", mock.Anything).Run(func(args mock.Arguments) {
+		state = args.Get(0).(*easee.State)
+	}).Return(nil)
+
+	inclusionReport := &fimptype.ThingInclusionReport{SwVersion: "300"}
+	thing.On("InclusionReport").Return(inclusionReport)
+	thing.On("SendInclusionReport", false).Return(true, nil)
+
+	m := easee.NewFirmwareMonitor(client, cfgService, notifier)
+
+	// The firmware has been updated while the adapter was stopped.
+	client.On("ChargerFirmware", mock.Anything, "XX12345").Return(&model.Firmware{Version: 310, LatestVersion: 310}, nil).Once()
+	notifier.On("Event", &notification.Event{
+		EventName:      model.NotificationFirmwareUpdated,
+		MessageContent: "Easee Home XX12345: firmware updated to version 310",
+	}).Return(nil).Once()
+
+	m.Register("XX12345", "Easee Home", thing, thingState)
+
+	assert.Equal(t, 310, state.FirmwareVersion)
+	assert.Equal(t, "310", inclusionReport.SwVersion)
+
+	// A newer firmware is announced once.
+	client.On("ChargerFirmware", mock.Anything, "XX12345").Return(&model.Firmware{Version: 310, LatestVersion: 320}, nil).Twice()
+	notifier.On("Event", &notification.Event{
+		EventName:      model.NotificationFirmwareUpdateAvailable,
+		MessageContent: "Easee Home XX12345: firmware version 320 is available",
+	}).Return(nil).Once()
+
+	assert.NoError(t, m.Check())
+	assert.NoError(t, m.Check())
+	assert.Equal(t, 320, state.LatestFirmwareVersion)
+
+	// The update reported by the charger is persisted, so it is notified only once.
+	notifier.On("Event", &notification.Event{
+		EventName:      model.NotificationFirmwareUpdated,
+		MessageContent: "Easee Home XX12345: firmware updated to version 320",
+	}).Return(nil).Once()
+
+	assert.NoError(t, m.FirmwareVersionObserved("XX12345", 320))
+	assert.NoError(t, m.FirmwareVersionObserved("XX12345", 320))
+	assert.Equal(t, 320, state.FirmwareVersion)
+	assert.Equal(t, "320", inclusionReport.SwVersion)
+
+	// Unregistered chargers are not checked anymore.
+	m.Unregister("XX12345")
+
+	assert.NoError(t, m.Check())
+	assert.NoError(t, m.FirmwareVersionObserved("XX12345", 330))
+}
@@ -0,0 +1,64 @@
+package easee
+
+import (
+	"github.com/futurehomeno/cliffhanger/adapter"
+	"github.com/futurehomeno/fimpgo"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+// Constants defining adapter specific thing commands and events.
+const (
+	CmdThingReboot            = "cmd.thing.reboot"
+	CmdThingUpdateFirmware    = "cmd.thing.update_firmware"
+	EvtThingMaintenanceReport = "evt.thing.maintenance_report"
+)
+
+// Statuses of remote maintenance commands.
+const (
+	// MaintenanceStatusAccepted means that the command has been accepted by the Easee cloud.
+	MaintenanceStatusAccepted = "accepted"
+	// MaintenanceStatusCompleted means that the charger came back online after executing the command.
+	MaintenanceStatusCompleted = "completed"
+)
+
+// MaintenanceReport represents the status of the remote maintenance command of the thing.
+type MaintenanceReport struct {
+	Address string                   `json:"address"`
+	Command model.MaintenanceCommand `json:"command"`
+	Status  string                   `json:"status"`
+}
+
+// NewMaintenanceReportMessage returns a message reporting the status of the remote maintenance command.
+func NewMaintenanceReportMessage(service string, report MaintenanceReport) *fimpgo.FimpMessage {
+	return fimpgo.NewObjectMessage(
+		EvtThingMaintenanceReport,
+		service,
+		report,
+		nil,
+		nil,
+		nil,
+	)
+}
+
+// maintenanceReporter reports completion of remote maintenance commands of the thing.
+type maintenanceReporter struct {
+	publisher adapter.ThingPublisher
+	thing     adapter.Thing
+}
+
+func (r *maintenanceReporter) MaintenanceCompleted(command model.MaintenanceCommand) {
+	message := NewMaintenanceReportMessage("", MaintenanceReport{
+		Address: r.thing.Address(),
+		Command: command,
+		Status:  MaintenanceStatusCompleted,
+	})
+
+	// The publisher sets the adapter name as the service of the message.
+	if err := r.publisher.PublishThingMessage(r.thing, message); err != nil {
+		log.WithError(err).
+			WithField("address", r.thing.Address()).
+			Errorf("failed to report completion of %s maintenance command", command)
+	}
+}
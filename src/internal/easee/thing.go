@@ -18,21 +18,70 @@ import (
 	"github.com/futurehomeno/edge-easee-adapter/internal/config"
 	"github.com/futurehomeno/edge-easee-adapter/internal/db"
 	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+	"github.com/futurehomeno/edge-easee-adapter/internal/sharing"
 	"github.com/futurehomeno/edge-easee-adapter/internal/signalr"
 )
 
-// Info is an object representing charger persisted information.
+// Types of things created by the adapter.
+const (
+	// ThingTypeCharger represents a charger, it is the default type of things without the type set.
+	ThingTypeCharger = "charger"
+	// ThingTypeCircuit represents an electrical circuit of the site, limiting the current of all its chargers.
+	ThingTypeCircuit = "circuit"
+	// ThingTypeEqualizer represents an Equalizer measuring the main meter of the site.
+	ThingTypeEqualizer = "equalizer"
+)
+
+// Info is an object representing thing persisted information.
 type Info struct {
+	Type      string `json:"type,omitempty"`
 	ChargerID string `json:"chargerID"`
 	Product   string `json:"product"`
+
+	EqualizerID string  `json:"equalizerID,omitempty"`
+	SiteID      int64   `json:"siteID,omitempty"`
+	CircuitID   int64   `json:"circuitID,omitempty"`
+	PanelName   string  `json:"panelName,omitempty"`
+	FuseRating  float64 `json:"fuseRating,omitempty"`
+}
+
+// NewCircuitInfo returns information of a thing representing the circuit.
+func NewCircuitInfo(circuit model.Circuit) Info {
+	return Info{
+		Type:       ThingTypeCircuit,
+		SiteID:     circuit.SiteID,
+		CircuitID:  circuit.ID,
+		PanelName:  circuit.PanelName,
+		FuseRating: circuit.RatedCurrent,
+	}
+}
+
+// NewEqualizerInfo returns information of a thing representing the equalizer.
+func NewEqualizerInfo(equalizer model.Equalizer) Info {
+	return Info{
+		Type:        ThingTypeEqualizer,
+		EqualizerID: equalizer.ID,
+		Product:     "Equalizer",
+		SiteID:      equalizer.SiteID,
+		CircuitID:   equalizer.CircuitID,
+	}
+}
+
+// CircuitThingID returns the ID of a thing representing the circuit.
+func CircuitThingID(circuitID int64) string {
+	return fmt.Sprintf("circuit-%d", circuitID)
 }
 
 // State is an object representing charger persisted mutable information.
 type State struct {
-	GridType            chargepoint.GridType `json:"gridType"`
-	Phases              int                  `json:"phases"`
-	PhaseMode           int                  `json:"phaseMode"`
-	SupportedMaxCurrent int64                `json:"supportedMaxCurrent"`
+	GridType            chargepoint.GridType  `json:"gridType"`
+	Phases              int                   `json:"phases"`
+	PhaseMode           int                   `json:"phaseMode"`
+	SupportedMaxCurrent int64                 `json:"supportedMaxCurrent"`
+	OfflineMaxCurrent   *model.OfflineCurrent `json:"offlineMaxCurrent,omitempty"`
+
+	FirmwareVersion       int `json:"firmwareVersion,omitempty"`
+	LatestFirmwareVersion int `json:"latestFirmwareVersion,omitempty"`
 }
 
 func (s *State) IsConfigUpdateNeeded() bool {
@@ -43,11 +92,24 @@ func (s *State) IsSiteUpdateNeeded() bool {
 	return s.SupportedMaxCurrent == 0
 }
 
+// Firmware returns firmware versions of the charger.
+func (s *State) Firmware() model.Firmware {
+	return model.Firmware{
+		Version:       s.FirmwareVersion,
+		LatestVersion: s.LatestFirmwareVersion,
+	}
+}
+
 type thingFactory struct {
-	client         api.Client
-	cfgService     *config.Service
-	signalRManager signalr.Manager
-	sessionStorage db.ChargingSessionStorage
+	client           api.Client
+	cfgService       *config.Service
+	signalRManager   signalr.Manager
+	sessionStorage   db.ChargingSessionStorage
+	scheduleStorage  db.ChargingScheduleStorage
+	departureStorage db.DepartureTargetStorage
+	allocator        sharing.Allocator
+	firmwareMonitor  FirmwareMonitor
+	notifier         api.Notifier
 }
 
 // NewThingFactory returns a new instance of adapter.ThingFactory.
@@ -56,12 +118,22 @@ func NewThingFactory(
 	cfgService *config.Service,
 	signalRManager signalr.Manager,
 	sessionStorage db.ChargingSessionStorage,
+	scheduleStorage db.ChargingScheduleStorage,
+	departureStorage db.DepartureTargetStorage,
+	allocator sharing.Allocator,
+	firmwareMonitor FirmwareMonitor,
+	notifier api.Notifier,
 ) adapter.ThingFactory {
 	return &thingFactory{
-		client:         client,
-		cfgService:     cfgService,
-		signalRManager: signalRManager,
-		sessionStorage: sessionStorage,
+		client:           client,
+		cfgService:       cfgService,
+		signalRManager:   signalRManager,
+		sessionStorage:   sessionStorage,
+		scheduleStorage:  scheduleStorage,
+		departureStorage: departureStorage,
+		allocator:        allocator,
+		firmwareMonitor:  firmwareMonitor,
+		notifier:         notifier,
 	}
 }
 
@@ -72,8 +144,15 @@ func (t *thingFactory) Create(ad adapter.Adapter, publisher adapter.Publisher, t
 		return nil, fmt.Errorf("factory: failed to retrieve information: %w", err)
 	}
 
+	switch info.Type {
+	case ThingTypeCircuit:
+		return t.createCircuit(ad, publisher, thingState, info), nil
+	case ThingTypeEqualizer:
+		return t.createEqualizer(ad, publisher, thingState, info), nil
+	}
+
 	thingCache := cache.NewCache(info.ChargerID)
-	controller := NewController(t.signalRManager, t.client, info.ChargerID, thingCache, t.cfgService, t.sessionStorage)
+	controller := NewController(t.signalRManager, t.client, info.ChargerID, thingCache, t.cfgService, t.sessionStorage, t.scheduleStorage, t.departureStorage)
 
 	state := &State{}
 	if err := thingState.State(state); err != nil {
@@ -88,9 +167,18 @@ func (t *thingFactory) Create(ad adapter.Adapter, publisher adapter.Publisher, t
 		log.WithError(err).Warnf("factory: failed to set state: %v", err)
 	}
 
+	if err := controller.ResumeDepartureCharging(); err != nil {
+		log.WithError(err).Warnf("factory: failed to resume departure charging: %v", err)
+	}
+
 	// using zero time, because we have no idea about the exact time those parameters were set
 	thingCache.SetInstallationParameters(state.GridType, state.Phases, time.Time{})
 	thingCache.SetPhaseMode(state.PhaseMode, time.Time{})
+	thingCache.SetFirmwareVersion(state.FirmwareVersion, time.Time{})
+
+	if state.OfflineMaxCurrent != nil {
+		thingCache.SetOfflineMaxCurrent(*state.OfflineMaxCurrent, time.Time{})
+	}
 
 	groups := []string{"ch_0"}
 	services := []adapter.Service{
@@ -100,17 +188,93 @@ func (t *thingFactory) Create(ad adapter.Adapter, publisher adapter.Publisher, t
 	}
 
 	return adapter.NewThing(publisher, thingState, &adapter.ThingConfig{
-		Connector:       NewConnector(t.signalRManager, t.client, info.ChargerID, thingCache, t.cfgService, t.sessionStorage),
-		InclusionReport: t.inclusionReport(info, thingState, groups),
+		Connector:       NewConnector(t.signalRManager, t.client, info.ChargerID, info.Product, thingState, thingCache, t.cfgService, t.sessionStorage, controller, t.allocator, t.firmwareMonitor, t.notifier, publisher),
+		InclusionReport: t.inclusionReport(info, thingState, groups, state),
 	}, services...), nil
 }
 
-func (t *thingFactory) inclusionReport(info *Info, thingState adapter.ThingState, groups []string) *fimptype.ThingInclusionReport {
+// createCircuit creates a thing representing the circuit of the site.
+func (t *thingFactory) createCircuit(ad adapter.Adapter, publisher adapter.Publisher, thingState adapter.ThingState, info *Info) adapter.Thing {
+	groups := []string{"ch_0"}
+	controller := NewCircuitController(t.client, t.cfgService, info.SiteID, info.CircuitID, info.FuseRating)
+	specification := CircuitSpecification(ad.Name(), ad.Address(), thingState.Address(), groups, info.FuseRating)
+
+	return adapter.NewThing(publisher, thingState, &adapter.ThingConfig{
+		Connector:       NewCircuitConnector(t.client),
+		InclusionReport: t.circuitInclusionReport(info, thingState, groups),
+	}, NewCircuitService(publisher, specification, controller))
+}
+
+// createEqualizer creates a thing representing the equalizer.
+func (t *thingFactory) createEqualizer(ad adapter.Adapter, publisher adapter.Publisher, thingState adapter.ThingState, info *Info) adapter.Thing {
+	groups := []string{"ch_0"}
+	equalizerCache := cache.NewEqualizerCache(info.EqualizerID)
+	controller := NewEqualizerController(t.signalRManager, info.EqualizerID, equalizerCache)
+
+	meterElecSrv := numericmeter.NewService(publisher, &numericmeter.Config{
+		Specification:     t.equalizerMeterElecSpecification(ad, thingState, groups),
+		Reporter:          controller,
+		ReportingStrategy: cliffCache.ReportAtLeastEvery(time.Minute),
+	})
+
+	return adapter.NewThing(publisher, thingState, &adapter.ThingConfig{
+		Connector: NewEqualizerConnector(t.signalRManager, t.client, info.EqualizerID, equalizerCache),
+		InclusionReport: &fimptype.ThingInclusionReport{
+			Address:        thingState.Address(),
+			ProductHash:    "Easee - Easee - " + info.Product,
+			ProductName:    info.Product,
+			DeviceId:       info.EqualizerID,
+			CommTechnology: "cloud",
+			ManufacturerId: "Easee",
+			PowerSource:    "ac",
+			WakeUpInterval: "-1",
+			Groups:         groups,
+		},
+	}, meterElecSrv)
+}
+
+func (t *thingFactory) equalizerMeterElecSpecification(adapter adapter.Adapter, thingState adapter.ThingState, groups []string) *fimptype.Service {
+	return numericmeter.Specification(
+		numericmeter.MeterElec,
+		adapter.Name(),
+		adapter.Address(),
+		thingState.Address(),
+		groups,
+		[]numericmeter.Unit{numericmeter.UnitW, numericmeter.UnitKWh},
+		numericmeter.WithExportUnits(numericmeter.UnitW, numericmeter.UnitKWh),
+		numericmeter.WithExtendedValues(
+			numericmeter.ValueCurrentPhase1,
+			numericmeter.ValueCurrentPhase2,
+			numericmeter.ValueCurrentPhase3,
+			numericmeter.ValuePowerImport,
+			numericmeter.ValuePowerExport,
+			numericmeter.ValueEnergyImport,
+			numericmeter.ValueEnergyExport,
+		),
+	)
+}
+
+func (t *thingFactory) circuitInclusionReport(info *Info, thingState adapter.ThingState, groups []string) *fimptype.ThingInclusionReport {
+	return &fimptype.ThingInclusionReport{
+		Address:        thingState.Address(),
+		ProductHash:    "Easee - Easee - Circuit",
+		ProductName:    "Circuit " + info.PanelName,
+		DeviceId:       CircuitThingID(info.CircuitID),
+		CommTechnology: "cloud",
+		ManufacturerId: "Easee",
+		PowerSource:    "ac",
+		WakeUpInterval: "-1",
+		Groups:         groups,
+	}
+}
+
+func (t *thingFactory) inclusionReport(info *Info, thingState adapter.ThingState, groups []string, state *State) *fimptype.ThingInclusionReport {
 	return &fimptype.ThingInclusionReport{
 		Address:        thingState.Address(),
 		ProductHash:    "Easee - Easee - " + info.Product,
 		ProductName:    info.Product,
 		DeviceId:       info.ChargerID,
+		SwVersion:      model.FirmwareVersionString(state.FirmwareVersion),
 		CommTechnology: "cloud",
 		ManufacturerId: "Easee",
 		PowerSource:    "ac",
@@ -137,11 +301,11 @@ func (t *thingFactory) chargepointSpecification(ad adapter.Adapter, thingState a
 		options = append(options, chargepoint.WithSupportedMaxCurrent(maxCurrent))
 	}
 
-	if phaseModes := model.SupportedPhaseModes(state.GridType, state.PhaseMode, state.Phases); len(phaseModes) > 0 {
+	if phaseModes := model.SwitchablePhaseModes(state.GridType, state.Phases); len(phaseModes) > 0 {
 		options = append(options, chargepoint.WithSupportedPhaseModes(phaseModes...))
 	}
 
-	return chargepoint.Specification(
+	specification := chargepoint.Specification(
 		ad.Name(),
 		ad.Address(),
 		thingState.Address(),
@@ -149,6 +313,12 @@ func (t *thingFactory) chargepointSpecification(ad adapter.Adapter, thingState a
 		t.supportedStates(),
 		options...,
 	)
+
+	if state.OfflineMaxCurrent != nil {
+		specification.Props[model.PropertyOfflineMaxCurrent] = state.OfflineMaxCurrent.Currents()
+	}
+
+	return specification
 }
 
 func (t *thingFactory) supportedStates() []chargepoint.State {
@@ -189,7 +359,7 @@ func (t *thingFactory) newChargepointService(
 	controller Controller,
 	state *State,
 ) adapter.Service {
-	return chargepoint.NewService(publisher, &chargepoint.Config{
+	return NewChargepointService(publisher, &chargepoint.Config{
 		Specification: t.chargepointSpecification(ad, thingState, groups, state),
 		Controller:    controller,
 	})
@@ -223,26 +393,3 @@ func (t *thingFactory) newParametersService(publisher adapter.ServicePublisher,
 func (t *thingFactory) parametersSpecification(adapter adapter.Adapter, thingState adapter.ThingState, groups []string) *fimptype.Service {
 	return parameters.Specification(adapter.Name(), adapter.Address(), thingState.Address(), groups)
 }
-
-// parameterSpecificationCableAlwaysLocked returns parameter specification for the associated configuration option.
-func parameterSpecificationCableAlwaysLocked() *parameters.ParameterSpecification {
-	return &parameters.ParameterSpecification{
-		ID:          model.CableAlwaysLockedParameter,
-		Name:        "Cable always locked",
-		Description: "Maintains locked cable at all times.",
-		ValueType:   parameters.ValueTypeBool,
-		WidgetType:  parameters.WidgetTypeSelect,
-		Options: parameters.SelectOptions{
-			{
-				Label: "Yes",
-				Value: true,
-			},
-			{
-				Label: "No",
-				Value: false,
-			},
-		},
-		DefaultValue: false,
-		ReadOnly:     false,
-	}
-}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// CommandTicket identifies a command accepted by the Easee API, which is then delivered to the charger.
+type CommandTicket struct {
+	Device    string `json:"device"`
+	CommandID int    `json:"commandId"`
+	Ticks     int64  `json:"ticks"`
+}
+
+// Key returns a key correlating the ticket with the response of the charger to the command.
+func (t CommandTicket) Key() string {
+	return fmt.Sprintf("%s/%d", t.Device, t.Ticks)
+}
+
+// CommandResponse represents a response of the charger to a command, delivered over SignalR.
+type CommandResponse struct {
+	SerialNumber string    `json:"serialNumber"`
+	ID           int       `json:"id"`
+	Timestamp    time.Time `json:"timestamp"`
+	DeliveredAt  time.Time `json:"deliveredAt"`
+	WasAccepted  bool      `json:"wasAccepted"`
+	ResultCode   int       `json:"resultCode"`
+	Comment      string    `json:"comment"`
+	Ticks        int64     `json:"ticks"`
+}
+
+// Key returns a key correlating the response with the ticket of the command.
+func (r CommandResponse) Key() string {
+	return fmt.Sprintf("%s/%d", r.SerialNumber, r.Ticks)
+}
+
+// Err returns an error if the command has been rejected by the charger.
+func (r CommandResponse) Err() error {
+	if r.WasAccepted {
+		return nil
+	}
+
+	if r.Comment != "" {
+		return fmt.Errorf("command %d rejected by the charger %s with result code %d: %s", r.ID, r.SerialNumber, r.ResultCode, r.Comment)
+	}
+
+	return fmt.Errorf("command %d rejected by the charger %s with result code %d", r.ID, r.SerialNumber, r.ResultCode)
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/futurehomeno/cliffhanger/adapter/service/chargepoint"
+)
+
+const (
+	// NominalVoltage is a nominal phase voltage of the grid in V.
+	NominalVoltage = 230.0
+	// MinChargingCurrent is the lowest current per phase in A at which cars can be charged.
+	MinChargingCurrent = 6
+
+	// departureChargingMargin compensates charging losses and the time needed to adjust the current.
+	departureChargingMargin = 1.1
+)
+
+// PowerPerAmpere returns the charging power in W per ampere of current per phase for the grid type and number of phases.
+func PowerPerAmpere(gridType chargepoint.GridType, phases int) float64 {
+	switch {
+	case phases <= 1:
+		return NominalVoltage
+	case gridType == chargepoint.GridTypeIT:
+		// Phases of IT grid are 230 V apart, so three phase power equals √3 times the phase-to-phase voltage.
+		return math.Sqrt(3) * NominalVoltage
+	default:
+		return float64(phases) * NominalVoltage
+	}
+}
+
+// DepartureTarget represents the energy in kWh required to be charged within the session by the departure time.
+type DepartureTarget struct {
+	Energy      float64   `json:"energy"`
+	DepartureAt time.Time `json:"departure_at"`
+}
+
+// Validate checks if the departure target is valid at the provided time.
+func (t DepartureTarget) Validate(now time.Time) error {
+	if t.Energy <= 0 {
+		return errors.New("departure target: energy must be positive")
+	}
+
+	if !t.DepartureAt.After(now) {
+		return errors.New("departure target: departure time must be in the future")
+	}
+
+	return nil
+}
+
+// RequiredCurrent returns the current per phase required to charge the remaining energy by the departure time,
+// limited to the [MinChargingCurrent, maxCurrent] range. Returns 0 if the target energy has been already charged.
+func (t DepartureTarget) RequiredCurrent(now time.Time, sessionEnergy, powerPerAmpere float64, maxCurrent int64) int64 {
+	remaining := t.Energy - sessionEnergy
+	if remaining <= 0 {
+		return 0
+	}
+
+	hours := t.DepartureAt.Sub(now).Hours()
+	if hours <= 0 || powerPerAmpere <= 0 {
+		return maxCurrent
+	}
+
+	current := int64(math.Ceil(remaining * 1000 * departureChargingMargin / hours / powerPerAmpere))
+
+	return max(min(current, maxCurrent), MinChargingCurrent)
+}
+
+// CheapestPlan returns the cheapest charging plan for the remaining energy by the departure time at the charging power in kW.
+func (t DepartureTarget) CheapestPlan(now time.Time, sessionEnergy, power float64, priceAt func(time.Time) (float64, bool)) ChargingPlan {
+	return PlanCheapestCharging(now, t.DepartureAt, t.Energy-sessionEnergy, power, priceAt)
+}
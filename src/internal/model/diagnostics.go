@@ -0,0 +1,39 @@
+package model
+
+import (
+	"fmt"
+)
+
+// Diagnostics represents errors and warnings reported by the charger.
+type Diagnostics struct {
+	// ErrorCode is the code of the current charger error, zero if there is none.
+	ErrorCode int
+	// ReasonForNoCurrent is the code explaining why the charger is not offering current.
+	ReasonForNoCurrent NoCurrentReason
+	// GridType is the power grid type detected by the charger.
+	GridType GridType
+}
+
+// GridWarning returns a description of the detected grid type if it is faulty, otherwise an empty string.
+func (d Diagnostics) GridWarning() string {
+	if !d.GridType.Faulty() {
+		return ""
+	}
+
+	return d.GridType.String()
+}
+
+// NewFaults returns faults which were not reported by the previous diagnostics.
+func (d Diagnostics) NewFaults(previous Diagnostics) []string {
+	var faults []string
+
+	if d.ErrorCode != 0 && d.ErrorCode != previous.ErrorCode {
+		faults = append(faults, fmt.Sprintf("error code %d", d.ErrorCode))
+	}
+
+	if warning := d.GridWarning(); warning != "" && d.GridType != previous.GridType {
+		faults = append(faults, "faulty grid type "+warning)
+	}
+
+	return faults
+}
@@ -0,0 +1,59 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+func TestDiagnostics_NewFaults(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		previous model.Diagnostics
+		current  model.Diagnostics
+		want     []string
+	}{
+		{
+			name:     "no faults",
+			previous: model.Diagnostics{},
+			current:  model.Diagnostics{ReasonForNoCurrent: 50, GridType: model.GridTypeTN3Phase},
+			want:     nil,
+		},
+		{
+			name:     "new error and grid warning",
+			previous: model.Diagnostics{GridType: model.GridTypeTN3Phase},
+			current:  model.Diagnostics{ErrorCode: 5, GridType: model.GridTypeWarningTN3PhaseGNDFault},
+			want:     []string{"error code 5", "faulty grid type TN 3-phase (ground fault)"},
+		},
+		{
+			name:     "already reported faults",
+			previous: model.Diagnostics{ErrorCode: 5, GridType: model.GridTypeWarningTN3PhaseGNDFault},
+			current:  model.Diagnostics{ErrorCode: 5, ReasonForNoCurrent: 3, GridType: model.GridTypeWarningTN3PhaseGNDFault},
+			want:     nil,
+		},
+		{
+			name:     "error changed",
+			previous: model.Diagnostics{ErrorCode: 5},
+			current:  model.Diagnostics{ErrorCode: 7},
+			want:     []string{"error code 7"},
+		},
+		{
+			name:     "error cleared",
+			previous: model.Diagnostics{ErrorCode: 5},
+			current:  model.Diagnostics{},
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.current.NewFaults(tt.previous))
+		})
+	}
+}
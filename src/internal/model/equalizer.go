@@ -0,0 +1,33 @@
+package model
+
+// Equalizer represents an Easee Equalizer installed on the main meter of the site.
+type Equalizer struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	SiteID    int64  `json:"siteId"`
+	CircuitID int64  `json:"circuitId"`
+}
+
+// Observation IDs of the Equalizer. IDs are defined per product, hence they might overlap with IDs of charger observations.
+const (
+	EqualizerActivePowerImport           ObservationID = 40
+	EqualizerActivePowerExport           ObservationID = 41
+	EqualizerCurrentL1                   ObservationID = 51
+	EqualizerCurrentL2                   ObservationID = 52
+	EqualizerCurrentL3                   ObservationID = 53
+	EqualizerCumulativeActivePowerImport ObservationID = 60
+	EqualizerCumulativeActivePowerExport ObservationID = 61
+)
+
+// EqualizerObservationIDs returns all Equalizer observation IDs supported by our system.
+func EqualizerObservationIDs() []ObservationID {
+	return []ObservationID{
+		EqualizerActivePowerImport,
+		EqualizerActivePowerExport,
+		EqualizerCurrentL1,
+		EqualizerCurrentL2,
+		EqualizerCurrentL3,
+		EqualizerCumulativeActivePowerImport,
+		EqualizerCumulativeActivePowerExport,
+	}
+}
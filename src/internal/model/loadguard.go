@@ -0,0 +1,34 @@
+package model
+
+import (
+	"math"
+)
+
+// LoadGuardLimit returns the highest current per phase in A the charger can draw without exceeding the main fuse rating
+// reduced by the margin. House currents are measured per phase by the main meter and include the currents drawn by the charger.
+// Only phases used by the charger are considered while it is charging, all phases are considered otherwise.
+// The headroom left by the house is divided into the provided number of shares, as all chargers adjust to the same reading.
+func LoadGuardLimit(houseCurrents, chargerCurrents [3]float64, fuseRating, margin float64, shares int) float64 {
+	charging := false
+
+	for _, c := range chargerCurrents {
+		if c > 0 {
+			charging = true
+		}
+	}
+
+	limit := math.Inf(1)
+
+	for i := range houseCurrents {
+		if charging && chargerCurrents[i] <= 0 {
+			continue
+		}
+
+		// Readings of the meter and the charger are not synchronised, so the load of other devices is never negative.
+		otherLoad := max(houseCurrents[i]-chargerCurrents[i], 0)
+		headroom := fuseRating - margin - otherLoad - chargerCurrents[i]
+		limit = min(limit, chargerCurrents[i]+headroom/float64(max(shares, 1)))
+	}
+
+	return limit
+}
@@ -0,0 +1,74 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+func TestLoadGuardLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		houseCurrents   [3]float64
+		chargerCurrents [3]float64
+		shares          int
+		want            float64
+	}{
+		{
+			name:            "limit is set by the most loaded phase",
+			houseCurrents:   [3]float64{20, 30, 25},
+			chargerCurrents: [3]float64{16, 16, 16},
+			shares:          1,
+			want:            20,
+		},
+		{
+			name:            "phases not used by the charger are ignored while charging",
+			houseCurrents:   [3]float64{20, 30, 25},
+			chargerCurrents: [3]float64{16, 0, 0},
+			shares:          1,
+			want:            30,
+		},
+		{
+			name:            "all phases are considered if the charger is not charging",
+			houseCurrents:   [3]float64{4, 30, 25},
+			chargerCurrents: [3]float64{0, 0, 0},
+			shares:          1,
+			want:            4,
+		},
+		{
+			name:            "load of other devices is never negative",
+			houseCurrents:   [3]float64{10, 10, 10},
+			chargerCurrents: [3]float64{16, 16, 16},
+			shares:          1,
+			want:            34,
+		},
+		{
+			name:            "headroom is divided between chargers",
+			houseCurrents:   [3]float64{24, 24, 24},
+			chargerCurrents: [3]float64{10, 10, 10},
+			shares:          2,
+			want:            15,
+		},
+		{
+			name:            "overload is divided between chargers",
+			houseCurrents:   [3]float64{40, 40, 40},
+			chargerCurrents: [3]float64{16, 16, 16},
+			shares:          2,
+			want:            13,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := model.LoadGuardLimit(tt.houseCurrents, tt.chargerCurrents, 35, 1, tt.shares)
+
+			assert.InDelta(t, tt.want, got, 0.001)
+		})
+	}
+}
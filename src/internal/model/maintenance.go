@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+)
+
+// MaintenanceCommand represents a remote maintenance command executed by the charger.
+type MaintenanceCommand string
+
+const (
+	// MaintenanceCommandReboot reboots the charger.
+	MaintenanceCommandReboot MaintenanceCommand = "reboot"
+	// MaintenanceCommandUpdateFirmware updates the charger to the latest firmware, the charger reboots once it's updated.
+	MaintenanceCommandUpdateFirmware MaintenanceCommand = "update_firmware"
+)
+
+// PendingMaintenance represents a maintenance command awaiting the charger to come back online.
+type PendingMaintenance struct {
+	Command  MaintenanceCommand
+	IssuedAt time.Time
+	// WentOffline is true once the charger has been observed offline after the command was issued.
+	WentOffline bool
+}
+
+// Observe registers the charger connectivity observed at the provided time and returns true if the charger came back online,
+// which completes the command. Observations preceding the command are ignored.
+func (p *PendingMaintenance) Observe(online bool, timestamp time.Time) bool {
+	if timestamp.Before(p.IssuedAt) {
+		return false
+	}
+
+	if !online {
+		p.WentOffline = true
+
+		return false
+	}
+
+	return p.WentOffline
+}
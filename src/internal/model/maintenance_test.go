@@ -0,0 +1,54 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+func TestPendingMaintenance_Observe(t *testing.T) {
+	t.Parallel()
+
+	issuedAt := time.Date(2024, time.March, 4, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		observations []bool
+		offsets      []time.Duration
+		want         []bool
+	}{
+		{
+			name:         "charger comes back online after the reboot",
+			observations: []bool{true, false, true},
+			offsets:      []time.Duration{time.Second, 10 * time.Second, time.Minute},
+			want:         []bool{false, false, true},
+		},
+		{
+			name:         "observations preceding the command are ignored",
+			observations: []bool{false, true},
+			offsets:      []time.Duration{-time.Second, time.Minute},
+			want:         []bool{false, false},
+		},
+		{
+			name:         "charger has not come back online yet",
+			observations: []bool{false, false},
+			offsets:      []time.Duration{time.Second, time.Minute},
+			want:         []bool{false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pending := &model.PendingMaintenance{Command: model.MaintenanceCommandReboot, IssuedAt: issuedAt}
+
+			for i, online := range tt.observations {
+				assert.Equal(t, tt.want[i], pending.Observe(online, issuedAt.Add(tt.offsets[i])))
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"fmt"
+
 	"github.com/futurehomeno/cliffhanger/adapter/service/chargepoint"
 	log "github.com/sirupsen/logrus"
 )
@@ -34,6 +36,29 @@ func SupportedPhaseModes(gridType chargepoint.GridType, phaseMode, phases int) [
 	return phaseModeMap
 }
 
+// SwitchablePhaseModes returns all phase modes the charger can be switched to within the detected installation.
+func SwitchablePhaseModes(gridType chargepoint.GridType, phases int) []chargepoint.PhaseMode {
+	return SupportedPhaseModes(gridType, PhaseModeAuto, phases)
+}
+
+// ToEaseePhaseMode maps a FIMP phase mode to Easee's internal phase mode setting.
+// Easee does not allow to choose a particular phase, so all single phase modes result in the same setting.
+// FIMP has no phase mode for automatic switching, so three phase modes result in the automatic setting,
+// which charges on all three phases unless the charger switches to a single phase, e.g. at low currents.
+func ToEaseePhaseMode(mode chargepoint.PhaseMode) (int, error) {
+	switch mode {
+	case chargepoint.PhaseModeNL1, chargepoint.PhaseModeNL2, chargepoint.PhaseModeNL3,
+		chargepoint.PhaseModeL1L2, chargepoint.PhaseModeL2L3, chargepoint.PhaseModeL3L1:
+		return PhaseModeLockedToSinglePhase, nil
+	case chargepoint.PhaseModeNL1L2L3, chargepoint.PhaseModeL1L2L3:
+		return PhaseModeAuto, nil
+	case chargepoint.PhaseModeNL1L2, chargepoint.PhaseModeNL2L3:
+		return 0, fmt.Errorf("phase mode %s is not supported by Easee", mode)
+	default:
+		return 0, fmt.Errorf("unknown phase mode: %s", mode)
+	}
+}
+
 var phaseModeMatrix = map[chargepoint.GridType]map[int]map[int][]chargepoint.PhaseMode{
 	chargepoint.GridTypeTN: {
 		1: {
@@ -11,7 +11,26 @@ import (
 )
 
 const (
-	CableAlwaysLockedParameter = "cable_always_locked"
+	CableAlwaysLockedParameter     = "cable_always_locked"
+	AuthorizationRequiredParameter = "authorization_required"
+	AccessLevelParameter           = "access_level"
+	LEDStripBrightnessParameter    = "led_strip_brightness"
+	SmartButtonEnabledParameter    = "smart_button_enabled"
+	EnableIdleCurrentParameter     = "enable_idle_current"
+	OfflineMaxCurrentParameter     = "offline_max_current"
+
+	// PropertyOfflineMaxCurrent is a chargepoint service property holding the max current per phase offered while offline.
+	PropertyOfflineMaxCurrent = "offline_max_current"
+)
+
+// Names of notification events sent to the user.
+const (
+	// NotificationFirmwareUpdated is sent once the charger firmware has been updated.
+	NotificationFirmwareUpdated = "easee_firmware_updated"
+	// NotificationFirmwareUpdateAvailable is sent once a newer firmware is available for the charger.
+	NotificationFirmwareUpdateAvailable = "easee_firmware_update_available"
+	// NotificationChargerFault is sent once the charger reports a new error or a faulty grid type.
+	NotificationChargerFault = "easee_charger_fault"
 )
 
 // Credentials stands for Easee API credentials.
@@ -40,6 +59,26 @@ type ChargerDetails struct {
 	Product string `json:"product"`
 }
 
+// Firmware represents the firmware version installed on the charger and the latest version available, as reported by the charger state.
+type Firmware struct {
+	Version       int `json:"chargerFirmware"`
+	LatestVersion int `json:"latestFirmware"`
+}
+
+// UpdateAvailable returns true if a firmware newer than the installed one is available.
+func (f Firmware) UpdateAvailable() bool {
+	return f.Version > 0 && f.LatestVersion > f.Version
+}
+
+// FirmwareVersionString returns the firmware version in the format of the inclusion report, empty if the version is unknown.
+func FirmwareVersionString(version int) string {
+	if version <= 0 {
+		return ""
+	}
+
+	return strconv.Itoa(version)
+}
+
 // BackPlate represents charger's back plate.
 type BackPlate struct {
 	ID                string `json:"id"`
@@ -50,11 +89,54 @@ type BackPlate struct {
 type ChargerConfig struct {
 	DetectedPowerGridType GridType `json:"detectedPowerGridType"`
 	PhaseMode             int      `json:"phaseMode"`
+	AuthorizationRequired bool     `json:"authorizationRequired"`
+
+	OfflineCurrent
+}
+
+// ChargerSettings represents charger settings updated with the settings endpoint, only provided settings are updated.
+type ChargerSettings struct {
+	LEDStripBrightness *int  `json:"ledStripBrightness,omitempty"`
+	SmartButtonEnabled *bool `json:"smartButtonEnabled,omitempty"`
+	EnableIdleCurrent  *bool `json:"enableIdleCurrent,omitempty"`
 }
 
-// ChargerSiteInfo represents charger rate current.
+// AccessLevel represents who is allowed to charge with the charger.
+type AccessLevel int
+
+// Easee's internal charger access level values.
+const (
+	// AccessLevelOpenForAll allows anyone to charge without authorization.
+	AccessLevelOpenForAll AccessLevel = 1
+	// AccessLevelEaseeAccount allows charging to users with an Easee account.
+	AccessLevelEaseeAccount AccessLevel = 2
+	// AccessLevelWhitelist allows charging only to whitelisted users and RFID tags.
+	AccessLevelWhitelist AccessLevel = 3
+)
+
+// Easee's internal phase mode setting values.
+const (
+	// PhaseModeLockedToSinglePhase locks the charger to a single phase charging.
+	PhaseModeLockedToSinglePhase = 1
+	// PhaseModeAuto lets the charger switch between single and three phase charging automatically.
+	PhaseModeAuto = 2
+	// PhaseModeLockedToThreePhase locks the charger to a three phase charging.
+	PhaseModeLockedToThreePhase = 3
+)
+
+// ChargerSession represents a charging session retrieved from the Easee API.
+type ChargerSession struct {
+	ID              int64     `json:"sessionId"`
+	CarConnected    time.Time `json:"carConnected"`
+	CarDisconnected time.Time `json:"carDisconnected"`
+	KiloWattHours   float64   `json:"kiloWattHours"`
+}
+
+// ChargerSiteInfo represents the site of the charger, its rated current and electrical circuits.
 type ChargerSiteInfo struct {
-	RatedCurrent float64 `json:"ratedCurrent"`
+	ID           int64     `json:"id"`
+	RatedCurrent float64   `json:"ratedCurrent"`
+	Circuits     []Circuit `json:"circuits"`
 }
 
 const (
@@ -62,6 +144,12 @@ const (
 	ChargingModeNormal = "normal"
 	// ChargingModeSlow represents a "slow" charging mode.
 	ChargingModeSlow = "slow"
+	// ChargingModeDeparture represents a charging mode adjusting current to charge the required energy by the departure time.
+	ChargingModeDeparture = "departure"
+	// ChargingModeCheapest represents a charging mode charging the required energy by the departure time in the cheapest hours.
+	ChargingModeCheapest = "cheapest"
+	// ChargingModeSolar represents a charging mode using only the surplus of solar production exported to the grid.
+	ChargingModeSolar = "solar"
 )
 
 // SupportedChargingModes returns all charging modes supported by Easee.
@@ -69,6 +157,9 @@ func SupportedChargingModes() []string {
 	return []string{
 		ChargingModeNormal,
 		ChargingModeSlow,
+		ChargingModeDeparture,
+		ChargingModeCheapest,
+		ChargingModeSolar,
 	}
 }
 
@@ -123,9 +214,19 @@ type ObservationID int
 const (
 	DetectedPowerGridType ObservationID = 21
 	LockCablePermanently  ObservationID = 30
+	EnableIdleCurrent     ObservationID = 37
 	PhaseMode             ObservationID = 38
+	LEDStripBrightness    ObservationID = 40
+	AuthorizationRequired ObservationID = 42
+	SmartButtonEnabled    ObservationID = 44
 	MaxChargerCurrent     ObservationID = 47
 	DynamicChargerCurrent ObservationID = 48
+	MaxCurrentOfflineP1   ObservationID = 50
+	MaxCurrentOfflineP2   ObservationID = 51
+	MaxCurrentOfflineP3   ObservationID = 52
+	ErrorCode             ObservationID = 60
+	ChargerFirmware       ObservationID = 80
+	ReasonForNoCurrent    ObservationID = 96
 	CableLocked           ObservationID = 103
 	CableRating           ObservationID = 104
 	ChargerOPState        ObservationID = 109
@@ -149,6 +250,12 @@ func (o ObservationID) Supported() bool {
 		}
 	}
 
+	for _, id := range EqualizerObservationIDs() {
+		if o == id {
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -172,6 +279,16 @@ func SupportedObservationIDs() []ObservationID {
 		CableLocked,
 		CableRating,
 		LockCablePermanently,
+		AuthorizationRequired,
+		LEDStripBrightness,
+		SmartButtonEnabled,
+		EnableIdleCurrent,
+		MaxCurrentOfflineP1,
+		MaxCurrentOfflineP2,
+		MaxCurrentOfflineP3,
+		ChargerFirmware,
+		ErrorCode,
+		ReasonForNoCurrent,
 		ChargingSessionStart,
 		ChargingSessionStop,
 	}
@@ -350,7 +467,7 @@ const (
 
 // ToFimpGridType returns grid type and phases.
 func (g GridType) ToFimpGridType() (chargepoint.GridType, int) {
-	if g >= GridTypeWarningTN2PhasePin235 {
+	if g.Faulty() {
 		log.Warnf("faulty grid type detected: %s", g)
 	}
 
@@ -363,6 +480,11 @@ func (g GridType) ToFimpGridType() (chargepoint.GridType, int) {
 	return "", 0
 }
 
+// Faulty returns true if the grid type is detected with a warning or an error.
+func (g GridType) Faulty() bool {
+	return g >= GridTypeWarningTN2PhasePin235
+}
+
 // String returns a human-readable name of the grid type.
 func (g GridType) String() string { //nolint:cyclop
 	switch g { //nolint:exhaustive
@@ -441,6 +563,7 @@ type TimestampedValue[T any] struct {
 
 type StartChargingSession struct {
 	ID         int64     `json:"Id"`
+	Auth       string    `json:"Auth"`
 	MeterValue float64   `json:"MeterValue"`
 	Start      time.Time `json:"Start"`
 }
@@ -451,6 +574,7 @@ func (s *StartChargingSession) IDString() string {
 
 type StopChargingSession struct {
 	ID              int64     `json:"Id"`
+	Auth            string    `json:"Auth"`
 	Energy          float64   `json:"EnergyKwh"`
 	MeterValueStart float64   `json:"MeterValueStart"`
 	MeterValueStop  float64   `json:"MeterValueStop"`
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"fmt"
+)
+
+// OfflineCurrent represents the max current per phase in A offered by the charger when it's disconnected from the cloud.
+// Zero current means that charging is not allowed while offline.
+type OfflineCurrent struct {
+	Phase1 int `json:"maxCurrentOfflineFallback_P1"`
+	Phase2 int `json:"maxCurrentOfflineFallback_P2"`
+	Phase3 int `json:"maxCurrentOfflineFallback_P3"`
+}
+
+// NewOfflineCurrent returns the offline current from currents of subsequent phases.
+func NewOfflineCurrent(currents []int) (OfflineCurrent, error) {
+	if len(currents) != 3 {
+		return OfflineCurrent{}, fmt.Errorf("offline current: expected currents of 3 phases, got %d", len(currents))
+	}
+
+	return OfflineCurrent{
+		Phase1: currents[0],
+		Phase2: currents[1],
+		Phase3: currents[2],
+	}, nil
+}
+
+// Currents returns currents of subsequent phases.
+func (c OfflineCurrent) Currents() []int {
+	return []int{c.Phase1, c.Phase2, c.Phase3}
+}
+
+// Validate checks if the current of each phase is within the provided max current.
+func (c OfflineCurrent) Validate(maxCurrent int) error {
+	for i, current := range c.Currents() {
+		if current < 0 || current > maxCurrent {
+			return fmt.Errorf("offline current: current of phase %d must be between 0 and %d A", i+1, maxCurrent)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+func TestNewOfflineCurrent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		currents []int
+		want     model.OfflineCurrent
+		wantErr  bool
+	}{
+		{
+			name:     "currents of all phases",
+			currents: []int{16, 10, 0},
+			want:     model.OfflineCurrent{Phase1: 16, Phase2: 10, Phase3: 0},
+		},
+		{
+			name:     "missing current of a phase",
+			currents: []int{16, 16},
+			wantErr:  true,
+		},
+		{
+			name:     "negative current",
+			currents: []int{16, -1, 16},
+			wantErr:  true,
+		},
+		{
+			name:     "current exceeding the max current",
+			currents: []int{16, 16, 40},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := model.NewOfflineCurrent(tt.currents)
+			if err == nil {
+				err = got.Validate(32)
+			}
+
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.currents, got.Currents())
+		})
+	}
+}
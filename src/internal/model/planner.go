@@ -0,0 +1,86 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// ChargingSlot represents a period selected for charging by the planner.
+type ChargingSlot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Price float64   `json:"price"`
+}
+
+// ChargingPlan represents periods selected for charging ordered by time.
+type ChargingPlan []ChargingSlot
+
+// Includes checks if the provided time falls within one of the planned slots.
+func (p ChargingPlan) Includes(at time.Time) bool {
+	for _, s := range p {
+		if !at.Before(s.Start) && at.Before(s.End) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PlanCheapestCharging selects the cheapest hourly slots between now and the deadline needed to charge the energy in kWh
+// with the charging power in kW. Slots with unknown prices are selected only if the cheaper ones are not sufficient,
+// and ties are resolved in favour of earlier slots. If the energy can't be charged in time, all slots are selected.
+func PlanCheapestCharging(now, deadline time.Time, energy, power float64, priceAt func(time.Time) (float64, bool)) ChargingPlan {
+	if energy <= 0 || power <= 0 || !deadline.After(now) {
+		return nil
+	}
+
+	type candidate struct {
+		slot  ChargingSlot
+		known bool
+	}
+
+	var candidates []candidate
+
+	for start := now; start.Before(deadline); {
+		end := start.Truncate(time.Hour).Add(time.Hour)
+		if end.After(deadline) {
+			end = deadline
+		}
+
+		price, known := priceAt(start)
+
+		candidates = append(candidates, candidate{
+			slot:  ChargingSlot{Start: start, End: end, Price: price},
+			known: known,
+		})
+
+		start = end
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].known != candidates[j].known {
+			return candidates[i].known
+		}
+
+		return candidates[i].slot.Price < candidates[j].slot.Price
+	})
+
+	required := energy * departureChargingMargin
+
+	var plan ChargingPlan
+
+	for _, c := range candidates {
+		if required <= 0 {
+			break
+		}
+
+		plan = append(plan, c.slot)
+		required -= c.slot.End.Sub(c.slot.Start).Hours() * power
+	}
+
+	sort.Slice(plan, func(i, j int) bool {
+		return plan[i].Start.Before(plan[j].Start)
+	})
+
+	return plan
+}
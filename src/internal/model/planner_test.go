@@ -0,0 +1,132 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+func TestPlanCheapestCharging(t *testing.T) {
+	t.Parallel()
+
+	midnight := time.Date(2025, time.January, 23, 0, 0, 0, 0, time.UTC)
+	hour := func(h int) time.Time { return midnight.Add(time.Duration(h) * time.Hour) }
+	slot := func(from, to time.Time, price float64) model.ChargingSlot {
+		return model.ChargingSlot{Start: from, End: to, Price: price}
+	}
+
+	tariff := &model.Tariff{
+		Type: model.TariffTypeHourly,
+		Prices: []model.HourlyPrice{
+			{Start: hour(0), Price: 0.9},
+			{Start: hour(1), Price: 0.5},
+			{Start: hour(2), Price: 0.7},
+			{Start: hour(3), Price: 0.5},
+			{Start: hour(4), Price: 1.2},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		deadline time.Time
+		energy   float64
+		power    float64
+		want     model.ChargingPlan
+	}{
+		{
+			name:     "cheapest hours are selected in time order",
+			now:      hour(0),
+			deadline: hour(5),
+			energy:   10,
+			power:    7.4,
+			want: model.ChargingPlan{
+				slot(hour(1), hour(2), 0.5),
+				slot(hour(3), hour(4), 0.5),
+			},
+		},
+		{
+			name:     "ties are resolved in favour of earlier hours",
+			now:      hour(0),
+			deadline: hour(5),
+			energy:   5,
+			power:    7.4,
+			want: model.ChargingPlan{
+				slot(hour(1), hour(2), 0.5),
+			},
+		},
+		{
+			name:     "partial hours are planned at their remaining capacity",
+			now:      hour(1).Add(30 * time.Minute),
+			deadline: hour(3).Add(30 * time.Minute),
+			energy:   5,
+			power:    7.4,
+			want: model.ChargingPlan{
+				slot(hour(1).Add(30*time.Minute), hour(2), 0.5),
+				slot(hour(3), hour(3).Add(30*time.Minute), 0.5),
+			},
+		},
+		{
+			name:     "hours with unknown prices are used only if needed",
+			now:      hour(3),
+			deadline: hour(7),
+			energy:   20,
+			power:    7.4,
+			want: model.ChargingPlan{
+				slot(hour(3), hour(4), 0.5),
+				slot(hour(4), hour(5), 1.2),
+				slot(hour(5), hour(6), 0),
+			},
+		},
+		{
+			name:     "all hours are selected if the energy can't be charged in time",
+			now:      hour(0),
+			deadline: hour(2),
+			energy:   50,
+			power:    7.4,
+			want: model.ChargingPlan{
+				slot(hour(0), hour(1), 0.9),
+				slot(hour(1), hour(2), 0.5),
+			},
+		},
+		{
+			name:     "nothing is planned if no energy is required",
+			now:      hour(0),
+			deadline: hour(5),
+			energy:   0,
+			power:    7.4,
+		},
+		{
+			name:     "nothing is planned after the deadline",
+			now:      hour(5),
+			deadline: hour(4),
+			energy:   10,
+			power:    7.4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := model.PlanCheapestCharging(tt.now, tt.deadline, tt.energy, tt.power, tariff.PriceAt)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChargingPlan_Includes(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.January, 23, 1, 0, 0, 0, time.UTC)
+	plan := model.ChargingPlan{{Start: start, End: start.Add(time.Hour)}}
+
+	assert.False(t, plan.Includes(start.Add(-time.Second)))
+	assert.True(t, plan.Includes(start))
+	assert.True(t, plan.Includes(start.Add(59*time.Minute)))
+	assert.False(t, plan.Includes(start.Add(time.Hour)))
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PriceCurve represents hourly energy prices used to plan charging in the cheapest hours, e.g. spot prices.
+// Unlike the tariff it is not used to calculate the cost of charging sessions. Prices are expressed per kWh.
+type PriceCurve struct {
+	Prices []HourlyPrice `json:"prices"`
+}
+
+// Validate checks if the price curve is valid.
+func (c *PriceCurve) Validate() error {
+	if len(c.Prices) == 0 {
+		return errors.New("price curve: at least one price is required")
+	}
+
+	starts := make(map[int64]struct{}, len(c.Prices))
+
+	for _, p := range c.Prices {
+		if _, ok := starts[p.Start.Unix()]; ok {
+			return fmt.Errorf("price curve: duplicated price for %s", p.Start.Format(time.RFC3339))
+		}
+
+		starts[p.Start.Unix()] = struct{}{}
+	}
+
+	return nil
+}
+
+// PriceAt returns the price valid at the provided time. Returns false if the price is unknown.
+func (c *PriceCurve) PriceAt(at time.Time) (float64, bool) {
+	return hourlyPriceAt(c.Prices, at)
+}
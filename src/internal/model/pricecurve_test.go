@@ -0,0 +1,75 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+func TestPriceCurve_Validate(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.January, 23, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		curve   model.PriceCurve
+		wantErr bool
+	}{
+		{
+			name: "valid price curve",
+			curve: model.PriceCurve{Prices: []model.HourlyPrice{
+				{Start: start, Price: 0.85},
+				{Start: start.Add(time.Hour), Price: -0.05},
+			}},
+		},
+		{
+			name:    "empty price curve",
+			curve:   model.PriceCurve{},
+			wantErr: true,
+		},
+		{
+			name: "duplicated hour",
+			curve: model.PriceCurve{Prices: []model.HourlyPrice{
+				{Start: start, Price: 0.85},
+				{Start: start.In(time.FixedZone("CET", 3600)), Price: 0.62},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.curve.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPriceCurve_PriceAt(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.January, 23, 0, 0, 0, 0, time.UTC)
+	curve := model.PriceCurve{Prices: []model.HourlyPrice{
+		{Start: start, Price: 0.85},
+		{Start: start.Add(time.Hour), Price: 0.62},
+	}}
+
+	price, ok := curve.PriceAt(start.Add(90 * time.Minute))
+	assert.True(t, ok)
+	assert.InDelta(t, 0.62, price, 0)
+
+	_, ok = curve.PriceAt(start.Add(2 * time.Hour))
+	assert.False(t, ok)
+}
@@ -0,0 +1,69 @@
+package model
+
+// NoCurrentReason represents the reason why the charger is not offering current to the car.
+type NoCurrentReason int
+
+const (
+	NoCurrentReasonOK                                NoCurrentReason = 0
+	NoCurrentReasonMaxCircuitCurrentTooLow           NoCurrentReason = 1
+	NoCurrentReasonMaxDynamicCircuitCurrentTooLow    NoCurrentReason = 2
+	NoCurrentReasonMaxOfflineCircuitCurrentTooLow    NoCurrentReason = 3
+	NoCurrentReasonCircuitFuseTooLow                 NoCurrentReason = 4
+	NoCurrentReasonWaitingInQueue                    NoCurrentReason = 5
+	NoCurrentReasonWaitingInFullyChargedQueue        NoCurrentReason = 6
+	NoCurrentReasonIllegalGridType                   NoCurrentReason = 7
+	NoCurrentReasonNoCurrentRequestFromSecondaryUnit NoCurrentReason = 8
+	NoCurrentReasonCarNotRequestingCurrent           NoCurrentReason = 50
+	NoCurrentReasonMaxChargerCurrentTooLow           NoCurrentReason = 51
+	NoCurrentReasonMaxDynamicChargerCurrentTooLow    NoCurrentReason = 52
+	NoCurrentReasonChargerDisabled                   NoCurrentReason = 53
+	NoCurrentReasonPendingScheduledCharging          NoCurrentReason = 54
+	NoCurrentReasonPendingAuthorization              NoCurrentReason = 55
+	NoCurrentReasonChargerInErrorState               NoCurrentReason = 56
+	NoCurrentReasonCarLimit                          NoCurrentReason = 79
+	NoCurrentReasonUndefined                         NoCurrentReason = 100
+)
+
+// String returns a human-readable description of the reason.
+func (r NoCurrentReason) String() string { //nolint:cyclop
+	switch r { //nolint:exhaustive
+	case NoCurrentReasonOK:
+		return "ok"
+	case NoCurrentReasonMaxCircuitCurrentTooLow:
+		return "max circuit current too low"
+	case NoCurrentReasonMaxDynamicCircuitCurrentTooLow:
+		return "dynamic circuit current too low"
+	case NoCurrentReasonMaxOfflineCircuitCurrentTooLow:
+		return "offline circuit current too low"
+	case NoCurrentReasonCircuitFuseTooLow:
+		return "circuit fuse too low"
+	case NoCurrentReasonWaitingInQueue:
+		return "waiting in queue"
+	case NoCurrentReasonWaitingInFullyChargedQueue:
+		return "waiting in fully charged queue"
+	case NoCurrentReasonIllegalGridType:
+		return "illegal grid type"
+	case NoCurrentReasonNoCurrentRequestFromSecondaryUnit:
+		return "no current request from secondary unit"
+	case NoCurrentReasonCarNotRequestingCurrent:
+		return "car not requesting current"
+	case NoCurrentReasonMaxChargerCurrentTooLow:
+		return "max charger current too low"
+	case NoCurrentReasonMaxDynamicChargerCurrentTooLow:
+		return "dynamic charger current too low"
+	case NoCurrentReasonChargerDisabled:
+		return "charger disabled"
+	case NoCurrentReasonPendingScheduledCharging:
+		return "pending scheduled charging"
+	case NoCurrentReasonPendingAuthorization:
+		return "pending authorization"
+	case NoCurrentReasonChargerInErrorState:
+		return "charger in error state"
+	case NoCurrentReasonCarLimit:
+		return "limited by car"
+	case NoCurrentReasonUndefined:
+		return "undefined"
+	default:
+		return "unknown"
+	}
+}
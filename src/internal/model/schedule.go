@@ -0,0 +1,145 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// weekdays maps weekday names used by charging schedules.
+var weekdays = map[string]time.Weekday{
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+	"sun": time.Sunday,
+}
+
+// ChargingSchedule represents a weekly charging schedule of a charger.
+type ChargingSchedule []ScheduleEntry
+
+// ScheduleEntry represents a weekly recurring charging window in local time, e.g. from "01:00" to "06:00" on weekdays.
+// Days refer to the day the window starts on, a window ending before its start spans over midnight.
+type ScheduleEntry struct {
+	Days    []string `json:"days"`
+	Start   string   `json:"start"`
+	End     string   `json:"end"`
+	Current int64    `json:"current,omitempty"`
+}
+
+// ScheduleWindow represents a single occurrence of a schedule entry.
+type ScheduleWindow struct {
+	Entry ScheduleEntry
+	Start time.Time
+	End   time.Time
+}
+
+// Validate checks if the charging schedule is valid.
+func (s ChargingSchedule) Validate() error {
+	for i, e := range s {
+		if err := e.Validate(); err != nil {
+			return fmt.Errorf("schedule: entry %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// ActiveAt returns the schedule window active at the provided time. Returns false if there is no active window.
+func (s ChargingSchedule) ActiveAt(at time.Time) (*ScheduleWindow, bool) {
+	for _, e := range s {
+		if w, ok := e.activeAt(at); ok {
+			return w, true
+		}
+	}
+
+	return nil, false
+}
+
+// Validate checks if the schedule entry is valid.
+func (e ScheduleEntry) Validate() error {
+	if len(e.Days) == 0 {
+		return errors.New("at least one day is required")
+	}
+
+	for _, d := range e.Days {
+		if _, ok := weekdays[d]; !ok {
+			return fmt.Errorf("invalid day %s", d)
+		}
+	}
+
+	start, err := minuteOfDay(e.Start)
+	if err != nil {
+		return fmt.Errorf("invalid start %s: %w", e.Start, err)
+	}
+
+	end, err := minuteOfDay(e.End)
+	if err != nil {
+		return fmt.Errorf("invalid end %s: %w", e.End, err)
+	}
+
+	if start == end {
+		return errors.New("start and end must differ")
+	}
+
+	if e.Current < 0 {
+		return errors.New("current must not be negative")
+	}
+
+	return nil
+}
+
+func (e ScheduleEntry) activeAt(at time.Time) (*ScheduleWindow, bool) {
+	start, err := minuteOfDay(e.Start)
+	if err != nil {
+		return nil, false
+	}
+
+	end, err := minuteOfDay(e.End)
+	if err != nil {
+		return nil, false
+	}
+
+	local := at.Local()
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+
+	// A window started on the previous day might still be active if it spans over midnight.
+	for _, day := range []time.Time{midnight, midnight.AddDate(0, 0, -1)} {
+		if !e.includes(day.Weekday()) {
+			continue
+		}
+
+		w := &ScheduleWindow{
+			Entry: e,
+			Start: atMinuteOfDay(day, start),
+			End:   atMinuteOfDay(day, end),
+		}
+
+		if end < start {
+			w.End = w.End.AddDate(0, 0, 1)
+		}
+
+		if !at.Before(w.Start) && at.Before(w.End) {
+			return w, true
+		}
+	}
+
+	return nil, false
+}
+
+func (e ScheduleEntry) includes(weekday time.Weekday) bool {
+	for _, d := range e.Days {
+		if weekdays[d] == weekday {
+			return true
+		}
+	}
+
+	return false
+}
+
+// atMinuteOfDay returns the time at the provided minute of the day.
+func atMinuteOfDay(day time.Time, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minute/60, minute%60, 0, 0, day.Location())
+}
@@ -0,0 +1,135 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Strategies of sharing the current budget between chargers.
+const (
+	// SharingStrategyEqual shares the budget equally between all charging chargers.
+	SharingStrategyEqual = "equal"
+	// SharingStrategyPriority allocates the budget to chargers in the configured order of priority.
+	SharingStrategyPriority = "priority"
+	// SharingStrategyFirstCome allocates the budget to chargers in the order cars started charging.
+	SharingStrategyFirstCome = "first_come"
+)
+
+// PowerSharing represents settings of sharing the current budget of the site between chargers.
+// Budget is the total current per phase in A, priority is a list of charger IDs starting with the highest priority.
+type PowerSharing struct {
+	Strategy string   `json:"strategy"`
+	Budget   int64    `json:"budget"`
+	Priority []string `json:"priority,omitempty"`
+}
+
+// SharingDemand represents a charging charger demanding a share of the budget.
+type SharingDemand struct {
+	ChargerID     string
+	MaxCurrent    int64
+	ChargingSince time.Time
+}
+
+// Validate checks if the power sharing settings are valid.
+func (s *PowerSharing) Validate() error {
+	switch s.Strategy {
+	case SharingStrategyEqual, SharingStrategyPriority, SharingStrategyFirstCome:
+	default:
+		return fmt.Errorf("power sharing: unsupported strategy %s", s.Strategy)
+	}
+
+	if s.Budget < MinChargingCurrent {
+		return fmt.Errorf("power sharing: budget must be at least %d A", MinChargingCurrent)
+	}
+
+	if s.Strategy == SharingStrategyPriority && len(s.Priority) == 0 {
+		return errors.New("power sharing: priority is required")
+	}
+
+	return nil
+}
+
+// Allocate distributes the budget between the chargers and returns the current per phase allocated to each of them.
+// Chargers which can't be allocated MinChargingCurrent are allocated 0, meaning their charging has to be paused.
+func (s *PowerSharing) Allocate(demands []SharingDemand) map[string]int64 {
+	ordered := s.order(demands)
+	allocation := make(map[string]int64, len(ordered))
+
+	if s.Strategy == SharingStrategyEqual {
+		s.allocateEqually(ordered, allocation)
+
+		return allocation
+	}
+
+	remaining := s.Budget
+
+	for _, d := range ordered {
+		if remaining < MinChargingCurrent {
+			allocation[d.ChargerID] = 0
+
+			continue
+		}
+
+		allocation[d.ChargerID] = min(d.MaxCurrent, remaining)
+		remaining -= allocation[d.ChargerID]
+	}
+
+	return allocation
+}
+
+// allocateEqually shares the budget equally between as many chargers as the budget allows, in the order of demands.
+// The share not used by chargers with a lower maximum current is distributed between the others.
+func (s *PowerSharing) allocateEqually(ordered []SharingDemand, allocation map[string]int64) {
+	active := ordered[:min(len(ordered), int(s.Budget/MinChargingCurrent))]
+
+	for _, d := range ordered[len(active):] {
+		allocation[d.ChargerID] = 0
+	}
+
+	byMaxCurrent := slices.Clone(active)
+	sort.SliceStable(byMaxCurrent, func(i, j int) bool {
+		return byMaxCurrent[i].MaxCurrent < byMaxCurrent[j].MaxCurrent
+	})
+
+	remaining := s.Budget
+
+	for i, d := range byMaxCurrent {
+		share := remaining / int64(len(byMaxCurrent)-i)
+		allocation[d.ChargerID] = min(d.MaxCurrent, share)
+		remaining -= allocation[d.ChargerID]
+	}
+}
+
+// order returns demands in the order the budget is allocated in, cars charging first take precedence by default.
+func (s *PowerSharing) order(demands []SharingDemand) []SharingDemand {
+	ordered := slices.Clone(demands)
+
+	rank := func(d SharingDemand) int {
+		if s.Strategy != SharingStrategyPriority {
+			return 0
+		}
+
+		if i := slices.Index(s.Priority, d.ChargerID); i >= 0 {
+			return i
+		}
+
+		return len(s.Priority)
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ri, rj := rank(ordered[i]), rank(ordered[j]); ri != rj {
+			return ri < rj
+		}
+
+		if !ordered[i].ChargingSince.Equal(ordered[j].ChargingSince) {
+			return ordered[i].ChargingSince.Before(ordered[j].ChargingSince)
+		}
+
+		return ordered[i].ChargerID < ordered[j].ChargerID
+	})
+
+	return ordered
+}
@@ -0,0 +1,70 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+func TestPowerSharing_Allocate(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2025, time.January, 20, 18, 0, 0, 0, time.UTC)
+	demands := []model.SharingDemand{
+		{ChargerID: "XX00003", MaxCurrent: 32, ChargingSince: at.Add(2 * time.Minute)},
+		{ChargerID: "XX00001", MaxCurrent: 32, ChargingSince: at},
+		{ChargerID: "XX00002", MaxCurrent: 10, ChargingSince: at.Add(time.Minute)},
+	}
+
+	tests := []struct {
+		name    string
+		sharing model.PowerSharing
+		want    map[string]int64
+	}{
+		{
+			name:    "budget is shared equally",
+			sharing: model.PowerSharing{Strategy: model.SharingStrategyEqual, Budget: 60},
+			want:    map[string]int64{"XX00001": 25, "XX00002": 10, "XX00003": 25},
+		},
+		{
+			name:    "charging of cars started last is paused if the budget is not sufficient for all",
+			sharing: model.PowerSharing{Strategy: model.SharingStrategyEqual, Budget: 16},
+			want:    map[string]int64{"XX00001": 8, "XX00002": 8, "XX00003": 0},
+		},
+		{
+			name:    "budget is allocated in the order of priority",
+			sharing: model.PowerSharing{Strategy: model.SharingStrategyPriority, Budget: 40, Priority: []string{"XX00003", "XX00002"}},
+			want:    map[string]int64{"XX00003": 32, "XX00002": 8, "XX00001": 0},
+		},
+		{
+			name:    "budget is allocated in the order cars started charging",
+			sharing: model.PowerSharing{Strategy: model.SharingStrategyFirstCome, Budget: 50},
+			want:    map[string]int64{"XX00001": 32, "XX00002": 10, "XX00003": 8},
+		},
+		{
+			name:    "charging is paused if the remaining budget is below the minimum current",
+			sharing: model.PowerSharing{Strategy: model.SharingStrategyFirstCome, Budget: 46},
+			want:    map[string]int64{"XX00001": 32, "XX00002": 10, "XX00003": 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.sharing.Allocate(demands))
+		})
+	}
+}
+
+func TestPowerSharing_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, (&model.PowerSharing{Strategy: model.SharingStrategyEqual, Budget: 32}).Validate())
+	assert.Error(t, (&model.PowerSharing{Strategy: "random", Budget: 32}).Validate())
+	assert.Error(t, (&model.PowerSharing{Strategy: model.SharingStrategyEqual, Budget: 5}).Validate())
+	assert.Error(t, (&model.PowerSharing{Strategy: model.SharingStrategyPriority, Budget: 32}).Validate())
+}
@@ -0,0 +1,35 @@
+package model
+
+import "fmt"
+
+// Circuit represents an electrical circuit of the site, rated current of the circuit is the rating of its fuse.
+type Circuit struct {
+	ID           int64     `json:"id"`
+	SiteID       int64     `json:"siteId"`
+	PanelName    string    `json:"panelName"`
+	RatedCurrent float64   `json:"ratedCurrent"`
+	Chargers     []Charger `json:"chargers"`
+}
+
+// CircuitCurrent represents a current per phase of the circuit in A.
+type CircuitCurrent struct {
+	Phase1 float64 `json:"phase1"`
+	Phase2 float64 `json:"phase2"`
+	Phase3 float64 `json:"phase3"`
+}
+
+// Validate checks if the current of each phase is within the provided fuse rating.
+// Zero fuse rating means the rating is unknown and only negative currents are rejected.
+func (c CircuitCurrent) Validate(fuseRating float64) error {
+	for i, current := range []float64{c.Phase1, c.Phase2, c.Phase3} {
+		if current < 0 {
+			return fmt.Errorf("circuit current: current of phase %d can't be negative", i+1)
+		}
+
+		if fuseRating > 0 && current > fuseRating {
+			return fmt.Errorf("circuit current: current of phase %d exceeds the fuse rating of %.0f A", i+1, fuseRating)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+func TestCircuitCurrent_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		current    model.CircuitCurrent
+		fuseRating float64
+		wantErr    bool
+	}{
+		{
+			name:       "current within the fuse rating",
+			current:    model.CircuitCurrent{Phase1: 25, Phase2: 16, Phase3: 0},
+			fuseRating: 25,
+		},
+		{
+			name:       "current exceeding the fuse rating",
+			current:    model.CircuitCurrent{Phase1: 16, Phase2: 16, Phase3: 32},
+			fuseRating: 25,
+			wantErr:    true,
+		},
+		{
+			name:    "any current is accepted if the fuse rating is unknown",
+			current: model.CircuitCurrent{Phase1: 63, Phase2: 63, Phase3: 63},
+		},
+		{
+			name:       "negative current",
+			current:    model.CircuitCurrent{Phase1: 16, Phase2: -1, Phase3: 16},
+			fuseRating: 25,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.current.Validate(tt.fuseRating)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"math"
+)
+
+// SolarCurrent returns the current per phase to be offered to the charger to consume the surplus of solar production.
+// The grid power is the power in W imported from the grid, negative if exported, while the charger draws the measured current
+// and is offered the current. The surplus is added to the measured current, as the car might draw less than it is offered.
+// Charging is paused (0 is returned) once the surplus is not sufficient for MinChargingCurrent and resumed once it exceeds
+// MinChargingCurrent by the hysteresis in A. Changes of the offered current smaller than the hysteresis are ignored.
+func SolarCurrent(gridPower, powerPerAmpere, measured float64, offered, maxCurrent int64, hysteresis float64) int64 {
+	if powerPerAmpere <= 0 {
+		return offered
+	}
+
+	available := measured - gridPower/powerPerAmpere
+
+	switch {
+	case offered < MinChargingCurrent && available < MinChargingCurrent+hysteresis:
+		return 0
+	case offered >= MinChargingCurrent && available < MinChargingCurrent:
+		return 0
+	case math.Abs(available-float64(offered)) < hysteresis:
+		return min(offered, maxCurrent)
+	default:
+		return min(int64(math.Floor(available)), maxCurrent)
+	}
+}
@@ -0,0 +1,112 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+func TestSolarCurrent(t *testing.T) {
+	t.Parallel()
+
+	// Three phase TN grid.
+	powerPerAmpere := 690.0
+
+	tests := []struct {
+		name      string
+		gridPower float64
+		measured  float64
+		offered   int64
+		want      int64
+	}{
+		{
+			name:      "charging remains paused if the surplus is below the minimum current",
+			gridPower: -4500,
+			measured:  0,
+			offered:   0,
+			want:      0,
+		},
+		{
+			name:      "charging remains paused if the surplus is within the hysteresis over the minimum current",
+			gridPower: -4800,
+			measured:  0,
+			offered:   0,
+			want:      0,
+		},
+		{
+			name:      "charging is resumed once the surplus exceeds the minimum current by the hysteresis",
+			gridPower: -5000,
+			measured:  0,
+			offered:   0,
+			want:      7,
+		},
+		{
+			name:      "current is not changed within the hysteresis",
+			gridPower: -600,
+			measured:  10,
+			offered:   10,
+			want:      10,
+		},
+		{
+			name:      "current is increased with the exported surplus",
+			gridPower: -1400,
+			measured:  10,
+			offered:   10,
+			want:      12,
+		},
+		{
+			name:      "current is decreased with the imported power",
+			gridPower: 1400,
+			measured:  10,
+			offered:   10,
+			want:      7,
+		},
+		{
+			name:      "current is decreased to the surplus added to the measured current",
+			gridPower: -700,
+			measured:  8,
+			offered:   16,
+			want:      9,
+		},
+		{
+			name:      "current is not changed while the car draws less than offered without surplus",
+			gridPower: 0,
+			measured:  9.5,
+			offered:   9,
+			want:      9,
+		},
+		{
+			name:      "current is limited by the maximum current",
+			gridPower: -10000,
+			measured:  10,
+			offered:   10,
+			want:      16,
+		},
+		{
+			name:      "current is kept at the minimum while the surplus covers it",
+			gridPower: -300,
+			measured:  6,
+			offered:   6,
+			want:      6,
+		},
+		{
+			name:      "charging is paused once the surplus is below the minimum current",
+			gridPower: 800,
+			measured:  6,
+			offered:   6,
+			want:      0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := model.SolarCurrent(tt.gridPower, powerPerAmpere, tt.measured, tt.offered, 16, 1)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TariffType represents a type of the energy tariff.
+type TariffType string
+
+const (
+	// TariffTypeFixed represents a tariff with a single price at all times.
+	TariffTypeFixed TariffType = "fixed"
+	// TariffTypeTimeOfUse represents a tariff with prices depending on the time of the day.
+	TariffTypeTimeOfUse TariffType = "time_of_use"
+	// TariffTypeHourly represents a tariff with a price list for each hour, e.g. spot prices.
+	TariffTypeHourly TariffType = "hourly"
+)
+
+// Tariff represents an energy tariff used to calculate the cost of charging sessions.
+// Prices are expressed per kWh.
+type Tariff struct {
+	Type     TariffType     `json:"type"`
+	Currency string         `json:"currency,omitempty"`
+	Price    float64        `json:"price,omitempty"`
+	Periods  []TariffPeriod `json:"periods,omitempty"`
+	Prices   []HourlyPrice  `json:"prices,omitempty"`
+}
+
+// TariffPeriod represents a price within a period of the day in local time, e.g. from "22:00" to "06:00".
+// A period ending before its start spans over midnight.
+type TariffPeriod struct {
+	From  string  `json:"from"`
+	To    string  `json:"to"`
+	Price float64 `json:"price"`
+}
+
+// HourlyPrice represents a price within an hour starting at the provided time.
+type HourlyPrice struct {
+	Start time.Time `json:"start"`
+	Price float64   `json:"price"`
+}
+
+// Validate checks if the tariff is valid.
+func (t *Tariff) Validate() error {
+	switch t.Type {
+	case TariffTypeFixed:
+		if t.Price < 0 {
+			return errors.New("tariff: price must not be negative")
+		}
+	case TariffTypeTimeOfUse:
+		if len(t.Periods) == 0 {
+			return errors.New("tariff: time of use tariff requires at least one period")
+		}
+
+		for _, p := range t.Periods {
+			if _, err := minuteOfDay(p.From); err != nil {
+				return fmt.Errorf("tariff: invalid period start %s: %w", p.From, err)
+			}
+
+			if _, err := minuteOfDay(p.To); err != nil {
+				return fmt.Errorf("tariff: invalid period end %s: %w", p.To, err)
+			}
+		}
+	case TariffTypeHourly:
+		if len(t.Prices) == 0 {
+			return errors.New("tariff: hourly tariff requires at least one price")
+		}
+	default:
+		return fmt.Errorf("tariff: unsupported type %s", t.Type)
+	}
+
+	return nil
+}
+
+// PriceAt returns the price valid at the provided time. Returns false if the price is unknown.
+func (t *Tariff) PriceAt(at time.Time) (float64, bool) {
+	switch t.Type {
+	case TariffTypeFixed:
+		return t.Price, true
+	case TariffTypeTimeOfUse:
+		return t.timeOfUsePriceAt(at)
+	case TariffTypeHourly:
+		return hourlyPriceAt(t.Prices, at)
+	default:
+		return 0, false
+	}
+}
+
+func (t *Tariff) timeOfUsePriceAt(at time.Time) (float64, bool) {
+	local := at.Local()
+	minute := local.Hour()*60 + local.Minute()
+
+	for _, p := range t.Periods {
+		fromMinute, err := minuteOfDay(p.From)
+		if err != nil {
+			continue
+		}
+
+		toMinute, err := minuteOfDay(p.To)
+		if err != nil {
+			continue
+		}
+
+		if fromMinute < toMinute && minute >= fromMinute && minute < toMinute {
+			return p.Price, true
+		}
+
+		// Period spans over midnight, or covers the whole day if both ends are equal.
+		if fromMinute >= toMinute && (minute >= fromMinute || minute < toMinute) {
+			return p.Price, true
+		}
+	}
+
+	return 0, false
+}
+
+// hourlyPriceAt returns the price of the hour including the provided time. Returns false if the price is unknown.
+func hourlyPriceAt(prices []HourlyPrice, at time.Time) (float64, bool) {
+	for _, p := range prices {
+		if !at.Before(p.Start) && at.Before(p.Start.Add(time.Hour)) {
+			return p.Price, true
+		}
+	}
+
+	return 0, false
+}
+
+// timeOfDayLayout is a layout of the time of the day, e.g. used by time of use tariff periods.
+const timeOfDayLayout = "15:04"
+
+// minuteOfDay parses the time of the day and returns the number of minutes since midnight.
+func minuteOfDay(value string) (int, error) {
+	t, err := time.Parse(timeOfDayLayout, value)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,254 @@
+package params
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/futurehomeno/cliffhanger/adapter/service/parameters"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/api"
+	"github.com/futurehomeno/edge-easee-adapter/internal/cache"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+// maxOfflineCurrent is the max current per phase in A which can be offered by the charger while offline.
+const maxOfflineCurrent = 32
+
+// Parameter represents a charger parameter exposed through the parameters service.
+type Parameter struct {
+	// ID is the ID of the parameter.
+	ID string
+	// ObservationIDs are IDs of observations reporting the parameter value, empty if the value is not observed.
+	ObservationIDs []model.ObservationID
+	// Specification returns the specification of the parameter.
+	Specification func() *parameters.ParameterSpecification
+	// Get returns the current value of the parameter, retrieving it through the API if it is not observed.
+	Get func(ctx context.Context, client api.Client, chargerID string, cache cache.Cache) (*parameters.Parameter, error)
+	// Set sets the value of the parameter through the API, waiting for the charger to apply it if requested by the context.
+	Set func(ctx context.Context, client api.Client, chargerID string, p *parameters.Parameter) error
+	// Observe stores the observed value of the parameter in the cache. Returns true if the value has been stored.
+	Observe func(cache cache.Cache, observation model.Observation) (bool, error)
+}
+
+// registry contains all supported charger parameters in the order they are presented to the user.
+var registry = []*Parameter{
+	boolParameter(
+		specificationCableAlwaysLocked,
+		model.LockCablePermanently,
+		cache.Cache.CableAlwaysLocked,
+		cache.Cache.SetCableAlwaysLocked,
+		func(ctx context.Context, client api.Client, chargerID string, locked bool) error {
+			return client.SetCableAlwaysLocked(ctx, chargerID, locked)
+		},
+	),
+	boolParameter(
+		specificationAuthorizationRequired,
+		model.AuthorizationRequired,
+		cache.Cache.AuthorizationRequired,
+		cache.Cache.SetAuthorizationRequired,
+		func(ctx context.Context, client api.Client, chargerID string, required bool) error {
+			return client.SetAuthorizationRequired(ctx, chargerID, required)
+		},
+	),
+	{
+		ID:            model.AccessLevelParameter,
+		Specification: specificationAccessLevel,
+		// Access level is not reported over SignalR, therefore it is retrieved from the API.
+		Get: func(ctx context.Context, client api.Client, chargerID string, _ cache.Cache) (*parameters.Parameter, error) {
+			level, err := client.ChargerAccess(ctx, chargerID)
+			if err != nil {
+				return nil, err
+			}
+
+			return parameters.NewIntParameter(model.AccessLevelParameter, int(level)), nil
+		},
+		Set: func(ctx context.Context, client api.Client, chargerID string, p *parameters.Parameter) error {
+			val, err := p.IntValue()
+			if err != nil {
+				return err
+			}
+
+			return client.SetChargerAccess(ctx, chargerID, model.AccessLevel(val))
+		},
+	},
+	intParameter(
+		specificationLEDStripBrightness,
+		model.LEDStripBrightness,
+		cache.Cache.LEDStripBrightness,
+		cache.Cache.SetLEDStripBrightness,
+		func(ctx context.Context, client api.Client, chargerID string, brightness int) error {
+			return client.UpdateChargerSettings(ctx, chargerID, model.ChargerSettings{LEDStripBrightness: &brightness})
+		},
+	),
+	boolParameter(
+		specificationSmartButtonEnabled,
+		model.SmartButtonEnabled,
+		cache.Cache.SmartButtonEnabled,
+		cache.Cache.SetSmartButtonEnabled,
+		func(ctx context.Context, client api.Client, chargerID string, enabled bool) error {
+			return client.UpdateChargerSettings(ctx, chargerID, model.ChargerSettings{SmartButtonEnabled: &enabled})
+		},
+	),
+	boolParameter(
+		specificationEnableIdleCurrent,
+		model.EnableIdleCurrent,
+		cache.Cache.EnableIdleCurrent,
+		cache.Cache.SetEnableIdleCurrent,
+		func(ctx context.Context, client api.Client, chargerID string, enabled bool) error {
+			return client.UpdateChargerSettings(ctx, chargerID, model.ChargerSettings{EnableIdleCurrent: &enabled})
+		},
+	),
+	{
+		ID:             model.OfflineMaxCurrentParameter,
+		ObservationIDs: []model.ObservationID{model.MaxCurrentOfflineP1, model.MaxCurrentOfflineP2, model.MaxCurrentOfflineP3},
+		Specification:  specificationOfflineMaxCurrent,
+		Get: func(ctx context.Context, client api.Client, chargerID string, c cache.Cache) (*parameters.Parameter, error) {
+			current, ok := c.OfflineMaxCurrent()
+
+			// The value is neither persisted nor observed yet.
+			if !ok {
+				fetched, err := client.OfflineMaxCurrent(ctx, chargerID)
+				if err != nil {
+					return nil, err
+				}
+
+				current = *fetched
+			}
+
+			return parameters.NewIntArrayParameter(model.OfflineMaxCurrentParameter, current.Currents()), nil
+		},
+		Set: func(ctx context.Context, client api.Client, chargerID string, p *parameters.Parameter) error {
+			val, err := p.IntArrayValue()
+			if err != nil {
+				return err
+			}
+
+			current, err := model.NewOfflineCurrent(val)
+			if err != nil {
+				return err
+			}
+
+			if err := current.Validate(maxOfflineCurrent); err != nil {
+				return err
+			}
+
+			return client.UpdateOfflineMaxCurrent(ctx, chargerID, current)
+		},
+		Observe: func(c cache.Cache, observation model.Observation) (bool, error) {
+			val, err := observation.IntValue()
+			if err != nil {
+				return false, err
+			}
+
+			phase := int(observation.ID-model.MaxCurrentOfflineP1) + 1
+
+			return c.SetOfflineMaxCurrentPhase(phase, val, observation.Timestamp), nil
+		},
+	},
+}
+
+// All returns all supported charger parameters.
+func All() []*Parameter {
+	return registry
+}
+
+// ByID returns the charger parameter with the provided ID.
+func ByID(id string) (*Parameter, error) {
+	for _, p := range registry {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("parameter: %v not supported", id)
+}
+
+// Observed returns charger parameters keyed by IDs of observations reporting their values.
+func Observed() map[model.ObservationID]*Parameter {
+	observed := make(map[model.ObservationID]*Parameter)
+
+	for _, p := range registry {
+		for _, id := range p.ObservationIDs {
+			observed[id] = p
+		}
+	}
+
+	return observed
+}
+
+// boolParameter returns a boolean parameter, which value is observed and kept in the cache.
+func boolParameter(
+	specification func() *parameters.ParameterSpecification,
+	observationID model.ObservationID,
+	get func(cache.Cache) (bool, time.Time),
+	store func(cache.Cache, bool, time.Time) bool,
+	set func(context.Context, api.Client, string, bool) error,
+) *Parameter {
+	id := specification().ID
+
+	return &Parameter{
+		ID:             id,
+		ObservationIDs: []model.ObservationID{observationID},
+		Specification:  specification,
+		Get: func(_ context.Context, _ api.Client, _ string, c cache.Cache) (*parameters.Parameter, error) {
+			val, _ := get(c)
+
+			return parameters.NewBoolParameter(id, val), nil
+		},
+		Set: func(ctx context.Context, client api.Client, chargerID string, p *parameters.Parameter) error {
+			val, err := p.BoolValue()
+			if err != nil {
+				return err
+			}
+
+			return set(ctx, client, chargerID, val)
+		},
+		Observe: func(c cache.Cache, observation model.Observation) (bool, error) {
+			val, err := observation.BoolValue()
+			if err != nil {
+				return false, err
+			}
+
+			return store(c, val, observation.Timestamp), nil
+		},
+	}
+}
+
+// intParameter returns an integer parameter, which value is observed and kept in the cache.
+func intParameter(
+	specification func() *parameters.ParameterSpecification,
+	observationID model.ObservationID,
+	get func(cache.Cache) (int, time.Time),
+	store func(cache.Cache, int, time.Time) bool,
+	set func(context.Context, api.Client, string, int) error,
+) *Parameter {
+	id := specification().ID
+
+	return &Parameter{
+		ID:             id,
+		ObservationIDs: []model.ObservationID{observationID},
+		Specification:  specification,
+		Get: func(_ context.Context, _ api.Client, _ string, c cache.Cache) (*parameters.Parameter, error) {
+			val, _ := get(c)
+
+			return parameters.NewIntParameter(id, val), nil
+		},
+		Set: func(ctx context.Context, client api.Client, chargerID string, p *parameters.Parameter) error {
+			val, err := p.IntValue()
+			if err != nil {
+				return err
+			}
+
+			return set(ctx, client, chargerID, val)
+		},
+		Observe: func(c cache.Cache, observation model.Observation) (bool, error) {
+			val, err := observation.IntValue()
+			if err != nil {
+				return false, err
+			}
+
+			return store(c, val, observation.Timestamp), nil
+		},
+	}
+}
@@ -0,0 +1,178 @@
+package params_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/futurehomeno/cliffhanger/adapter/service/parameters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/cache"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+	"github.com/futurehomeno/edge-easee-adapter/internal/params"
+	"github.com/futurehomeno/edge-easee-adapter/internal/test/mocks"
+)
+
+func TestParameters(t *testing.T) { //nolint:paralleltest
+	brightness := 50
+	enabled := true
+
+	tests := []struct {
+		name        string
+		observation model.Observation
+		want        *parameters.Parameter
+		mock        func(client *mocks.APIClient)
+	}{
+		{
+			name: "cable always locked",
+			observation: model.Observation{
+				ID:       model.LockCablePermanently,
+				DataType: model.ObservationDataTypeBoolean,
+				Value:    "true",
+			},
+			want: parameters.NewBoolParameter(model.CableAlwaysLockedParameter, true),
+			mock: func(client *mocks.APIClient) {
+				client.On("SetCableAlwaysLocked", mock.Anything, "XX12345", true).Return(nil)
+			},
+		},
+		{
+			name: "authorization required",
+			observation: model.Observation{
+				ID:       model.AuthorizationRequired,
+				DataType: model.ObservationDataTypeBoolean,
+				Value:    "true",
+			},
+			want: parameters.NewBoolParameter(model.AuthorizationRequiredParameter, true),
+			mock: func(client *mocks.APIClient) {
+				client.On("SetAuthorizationRequired", mock.Anything, "XX12345", true).Return(nil)
+			},
+		},
+		{
+			name: "LED strip brightness",
+			observation: model.Observation{
+				ID:       model.LEDStripBrightness,
+				DataType: model.ObservationDataTypeInteger,
+				Value:    "50",
+			},
+			want: parameters.NewIntParameter(model.LEDStripBrightnessParameter, 50),
+			mock: func(client *mocks.APIClient) {
+				client.On("UpdateChargerSettings", mock.Anything, "XX12345", model.ChargerSettings{LEDStripBrightness: &brightness}).Return(nil)
+			},
+		},
+		{
+			name: "smart button enabled",
+			observation: model.Observation{
+				ID:       model.SmartButtonEnabled,
+				DataType: model.ObservationDataTypeBoolean,
+				Value:    "true",
+			},
+			want: parameters.NewBoolParameter(model.SmartButtonEnabledParameter, true),
+			mock: func(client *mocks.APIClient) {
+				client.On("UpdateChargerSettings", mock.Anything, "XX12345", model.ChargerSettings{SmartButtonEnabled: &enabled}).Return(nil)
+			},
+		},
+		{
+			name: "enable idle current",
+			observation: model.Observation{
+				ID:       model.EnableIdleCurrent,
+				DataType: model.ObservationDataTypeBoolean,
+				Value:    "true",
+			},
+			want: parameters.NewBoolParameter(model.EnableIdleCurrentParameter, true),
+			mock: func(client *mocks.APIClient) {
+				client.On("UpdateChargerSettings", mock.Anything, "XX12345", model.ChargerSettings{EnableIdleCurrent: &enabled}).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests { //nolint:paralleltest
+		t.Run(tt.name, func(t *testing.T) {
+			client := mocks.NewAPIClient(t)
+			chargerCache := cache.NewCache("XX12345")
+
+			param, ok := params.Observed()[tt.observation.ID]
+			require.True(t, ok)
+			assert.Equal(t, tt.want.ID, param.Specification().ID)
+
+			tt.observation.Timestamp = time.Now()
+
+			stored, err := param.Observe(chargerCache, tt.observation)
+			require.NoError(t, err)
+			assert.True(t, stored)
+
+			got, err := param.Get(context.Background(), client, "XX12345", chargerCache)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			tt.mock(client)
+
+			assert.NoError(t, param.Set(context.Background(), client, "XX12345", tt.want))
+		})
+	}
+}
+
+func TestParameters_AccessLevel(t *testing.T) {
+	t.Parallel()
+
+	client := mocks.NewAPIClient(t)
+	client.On("ChargerAccess", mock.Anything, "XX12345").Return(model.AccessLevelWhitelist, nil)
+	client.On("SetChargerAccess", mock.Anything, "XX12345", model.AccessLevelEaseeAccount).Return(nil)
+
+	param, err := params.ByID(model.AccessLevelParameter)
+	require.NoError(t, err)
+
+	got, err := param.Get(context.Background(), client, "XX12345", cache.NewCache("XX12345"))
+	require.NoError(t, err)
+	assert.Equal(t, parameters.NewIntParameter(model.AccessLevelParameter, 3), got)
+
+	assert.NoError(t, param.Set(context.Background(), client, "XX12345", parameters.NewIntParameter(model.AccessLevelParameter, 2)))
+
+	_, err = params.ByID("fake_param")
+	assert.Error(t, err)
+}
+
+func TestParameters_OfflineMaxCurrent(t *testing.T) {
+	t.Parallel()
+
+	client := mocks.NewAPIClient(t)
+	chargerCache := cache.NewCache("XX12345")
+
+	param, err := params.ByID(model.OfflineMaxCurrentParameter)
+	require.NoError(t, err)
+
+	// The value is retrieved from the API until it's known.
+	client.On("OfflineMaxCurrent", mock.Anything, "XX12345").Return(&model.OfflineCurrent{Phase1: 16, Phase2: 16, Phase3: 16}, nil).Once()
+
+	got, err := param.Get(context.Background(), client, "XX12345", chargerCache)
+	require.NoError(t, err)
+	assert.Equal(t, parameters.NewIntArrayParameter(model.OfflineMaxCurrentParameter, []int{16, 16, 16}), got)
+
+	// The persisted value is known, even though it has not been observed yet.
+	chargerCache.SetOfflineMaxCurrent(model.OfflineCurrent{Phase1: 16, Phase2: 16, Phase3: 16}, time.Time{})
+
+	got, err = param.Get(context.Background(), client, "XX12345", chargerCache)
+	require.NoError(t, err)
+	assert.Equal(t, parameters.NewIntArrayParameter(model.OfflineMaxCurrentParameter, []int{16, 16, 16}), got)
+
+	stored, err := params.Observed()[model.MaxCurrentOfflineP2].Observe(chargerCache, model.Observation{
+		ID:        model.MaxCurrentOfflineP2,
+		DataType:  model.ObservationDataTypeInteger,
+		Timestamp: time.Now(),
+		Value:     "10",
+	})
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	got, err = param.Get(context.Background(), client, "XX12345", chargerCache)
+	require.NoError(t, err)
+	assert.Equal(t, parameters.NewIntArrayParameter(model.OfflineMaxCurrentParameter, []int{16, 10, 16}), got)
+
+	client.On("UpdateOfflineMaxCurrent", mock.Anything, "XX12345", model.OfflineCurrent{Phase1: 10, Phase2: 10, Phase3: 0}).Return(nil).Once()
+
+	assert.NoError(t, param.Set(context.Background(), client, "XX12345", parameters.NewIntArrayParameter(model.OfflineMaxCurrentParameter, []int{10, 10, 0})))
+	assert.Error(t, param.Set(context.Background(), client, "XX12345", parameters.NewIntArrayParameter(model.OfflineMaxCurrentParameter, []int{40, 40, 40})))
+	assert.Error(t, param.Set(context.Background(), client, "XX12345", parameters.NewIntArrayParameter(model.OfflineMaxCurrentParameter, []int{10})))
+}
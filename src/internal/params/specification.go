@@ -0,0 +1,131 @@
+package params
+
+import (
+	"github.com/futurehomeno/cliffhanger/adapter/service/parameters"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+// specificationCableAlwaysLocked returns parameter specification for the associated configuration option.
+func specificationCableAlwaysLocked() *parameters.ParameterSpecification {
+	return &parameters.ParameterSpecification{
+		ID:           model.CableAlwaysLockedParameter,
+		Name:         "Cable always locked",
+		Description:  "Maintains locked cable at all times.",
+		ValueType:    parameters.ValueTypeBool,
+		WidgetType:   parameters.WidgetTypeSelect,
+		Options:      yesNoOptions(),
+		DefaultValue: false,
+		ReadOnly:     false,
+	}
+}
+
+// specificationAuthorizationRequired returns parameter specification for the associated configuration option.
+func specificationAuthorizationRequired() *parameters.ParameterSpecification {
+	return &parameters.ParameterSpecification{
+		ID:           model.AuthorizationRequiredParameter,
+		Name:         "Authorization required",
+		Description:  "Requires charging sessions to be authorized before charging starts.",
+		ValueType:    parameters.ValueTypeBool,
+		WidgetType:   parameters.WidgetTypeSelect,
+		Options:      yesNoOptions(),
+		DefaultValue: false,
+		ReadOnly:     false,
+	}
+}
+
+// specificationAccessLevel returns parameter specification for the associated configuration option.
+func specificationAccessLevel() *parameters.ParameterSpecification {
+	return &parameters.ParameterSpecification{
+		ID:          model.AccessLevelParameter,
+		Name:        "Access level",
+		Description: "Defines who is allowed to charge when authorization is required.",
+		ValueType:   parameters.ValueTypeInt,
+		WidgetType:  parameters.WidgetTypeSelect,
+		Options: parameters.SelectOptions{
+			{
+				Label: "Open for all",
+				Value: int(model.AccessLevelOpenForAll),
+			},
+			{
+				Label: "Easee account required",
+				Value: int(model.AccessLevelEaseeAccount),
+			},
+			{
+				Label: "Whitelisted users and RFID tags",
+				Value: int(model.AccessLevelWhitelist),
+			},
+		},
+		DefaultValue: int(model.AccessLevelOpenForAll),
+		ReadOnly:     false,
+	}
+}
+
+// specificationLEDStripBrightness returns parameter specification for the associated configuration option.
+func specificationLEDStripBrightness() *parameters.ParameterSpecification {
+	spec := &parameters.ParameterSpecification{
+		ID:           model.LEDStripBrightnessParameter,
+		Name:         "LED strip brightness",
+		Description:  "Brightness of the LED strip in percent.",
+		ValueType:    parameters.ValueTypeInt,
+		WidgetType:   parameters.WidgetTypeInput,
+		DefaultValue: 100,
+		ReadOnly:     false,
+	}
+
+	return spec.WithMin(0).WithMax(100)
+}
+
+// specificationSmartButtonEnabled returns parameter specification for the associated configuration option.
+func specificationSmartButtonEnabled() *parameters.ParameterSpecification {
+	return &parameters.ParameterSpecification{
+		ID:           model.SmartButtonEnabledParameter,
+		Name:         "Smart button enabled",
+		Description:  "Allows switching between charging modes with the button on the charger.",
+		ValueType:    parameters.ValueTypeBool,
+		WidgetType:   parameters.WidgetTypeSelect,
+		Options:      yesNoOptions(),
+		DefaultValue: false,
+		ReadOnly:     false,
+	}
+}
+
+// specificationEnableIdleCurrent returns parameter specification for the associated configuration option.
+func specificationEnableIdleCurrent() *parameters.ParameterSpecification {
+	return &parameters.ParameterSpecification{
+		ID:           model.EnableIdleCurrentParameter,
+		Name:         "Enable idle current",
+		Description:  "Keeps offering current after the car has stopped charging, e.g. to precondition the car.",
+		ValueType:    parameters.ValueTypeBool,
+		WidgetType:   parameters.WidgetTypeSelect,
+		Options:      yesNoOptions(),
+		DefaultValue: false,
+		ReadOnly:     false,
+	}
+}
+
+// specificationOfflineMaxCurrent returns parameter specification for the associated configuration option.
+func specificationOfflineMaxCurrent() *parameters.ParameterSpecification {
+	return &parameters.ParameterSpecification{
+		ID:           model.OfflineMaxCurrentParameter,
+		Name:         "Offline max current",
+		Description:  "Max current per phase (L1, L2, L3) in A offered while the charger is disconnected from the cloud, 0 disables offline charging.",
+		ValueType:    parameters.ValueTypeIntArray,
+		WidgetType:   parameters.WidgetTypeInput,
+		DefaultValue: []int{0, 0, 0},
+		ReadOnly:     false,
+	}
+}
+
+func yesNoOptions() parameters.SelectOptions {
+	return parameters.SelectOptions{
+		{
+			Label: "Yes",
+			Value: true,
+		},
+		{
+			Label: "No",
+			Value: false,
+		},
+	}
+}
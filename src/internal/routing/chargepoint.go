@@ -0,0 +1,349 @@
+package routing
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	cliffAdapter "github.com/futurehomeno/cliffhanger/adapter"
+	"github.com/futurehomeno/cliffhanger/adapter/service/chargepoint"
+	"github.com/futurehomeno/cliffhanger/router"
+	"github.com/futurehomeno/fimpgo"
+	"github.com/michalkurzeja/go-clock"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/easee"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+// Session history query parameters.
+const (
+	sessionHistoryFrom   = "from"
+	sessionHistoryTo     = "to"
+	sessionHistoryLimit  = "limit"
+	sessionHistoryOffset = "offset"
+)
+
+// RouteChargepoint returns routing for adapter specific chargepoint commands.
+func RouteChargepoint(serviceRegistry cliffAdapter.ServiceRegistry) []*router.Routing {
+	return []*router.Routing{
+		routeCmdSessionHistoryGetReport(serviceRegistry),
+		routeCmdScheduleSet(serviceRegistry),
+		routeCmdScheduleGetReport(serviceRegistry),
+		routeCmdScheduleClear(serviceRegistry),
+		routeCmdDepartureTargetSet(serviceRegistry),
+		routeCmdDepartureTargetGetReport(serviceRegistry),
+		routeCmdAuthorizationSet(serviceRegistry),
+		routeCmdDiagnosticsGetReport(serviceRegistry),
+	}
+}
+
+// routeCmdSessionHistoryGetReport returns a routing responsible for handling the command.
+func routeCmdSessionHistoryGetReport(serviceRegistry cliffAdapter.ServiceRegistry) *router.Routing {
+	return router.NewRouting(
+		handleCmdSessionHistoryGetReport(serviceRegistry),
+		router.ForService(chargepoint.Chargepoint),
+		router.ForType(easee.CmdSessionHistoryGetReport),
+	)
+}
+
+// handleCmdSessionHistoryGetReport returns a handler responsible for handling the command.
+func handleCmdSessionHistoryGetReport(serviceRegistry cliffAdapter.ServiceRegistry) router.MessageHandler {
+	return router.NewMessageHandler(
+		router.MessageProcessorFn(func(message *fimpgo.Message) (*fimpgo.FimpMessage, error) {
+			service, err := getChargepointService(serviceRegistry, message)
+			if err != nil {
+				return nil, err
+			}
+
+			query, err := sessionHistoryQuery(message.Payload)
+			if err != nil {
+				return nil, fmt.Errorf("adapter: provided session history query has an incorrect format: %w", err)
+			}
+
+			if err := service.SendSessionHistoryReport(query); err != nil {
+				return nil, fmt.Errorf("adapter: failed to send session history report: %w", err)
+			}
+
+			return nil, nil
+		}),
+	)
+}
+
+// routeCmdScheduleSet returns a routing responsible for handling the command.
+func routeCmdScheduleSet(serviceRegistry cliffAdapter.ServiceRegistry) *router.Routing {
+	return router.NewRouting(
+		handleCmdScheduleSet(serviceRegistry),
+		router.ForService(chargepoint.Chargepoint),
+		router.ForType(easee.CmdScheduleSet),
+	)
+}
+
+// handleCmdScheduleSet returns a handler responsible for handling the command.
+func handleCmdScheduleSet(serviceRegistry cliffAdapter.ServiceRegistry) router.MessageHandler {
+	return router.NewMessageHandler(
+		router.MessageProcessorFn(func(message *fimpgo.Message) (*fimpgo.FimpMessage, error) {
+			service, err := getChargepointService(serviceRegistry, message)
+			if err != nil {
+				return nil, err
+			}
+
+			var schedule model.ChargingSchedule
+
+			if err := message.Payload.GetObjectValue(&schedule); err != nil {
+				return nil, fmt.Errorf("adapter: provided charging schedule has an incorrect format: %w", err)
+			}
+
+			if err := service.SetSchedule(schedule); err != nil {
+				return nil, fmt.Errorf("adapter: failed to set charging schedule: %w", err)
+			}
+
+			if err := service.SendScheduleReport(); err != nil {
+				return nil, fmt.Errorf("adapter: failed to send charging schedule report: %w", err)
+			}
+
+			return nil, nil
+		}),
+	)
+}
+
+// routeCmdScheduleGetReport returns a routing responsible for handling the command.
+func routeCmdScheduleGetReport(serviceRegistry cliffAdapter.ServiceRegistry) *router.Routing {
+	return router.NewRouting(
+		handleCmdScheduleGetReport(serviceRegistry),
+		router.ForService(chargepoint.Chargepoint),
+		router.ForType(easee.CmdScheduleGetReport),
+	)
+}
+
+// handleCmdScheduleGetReport returns a handler responsible for handling the command.
+func handleCmdScheduleGetReport(serviceRegistry cliffAdapter.ServiceRegistry) router.MessageHandler {
+	return router.NewMessageHandler(
+		router.MessageProcessorFn(func(message *fimpgo.Message) (*fimpgo.FimpMessage, error) {
+			service, err := getChargepointService(serviceRegistry, message)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := service.SendScheduleReport(); err != nil {
+				return nil, fmt.Errorf("adapter: failed to send charging schedule report: %w", err)
+			}
+
+			return nil, nil
+		}),
+	)
+}
+
+// routeCmdScheduleClear returns a routing responsible for handling the command.
+func routeCmdScheduleClear(serviceRegistry cliffAdapter.ServiceRegistry) *router.Routing {
+	return router.NewRouting(
+		handleCmdScheduleClear(serviceRegistry),
+		router.ForService(chargepoint.Chargepoint),
+		router.ForType(easee.CmdScheduleClear),
+	)
+}
+
+// handleCmdScheduleClear returns a handler responsible for handling the command.
+func handleCmdScheduleClear(serviceRegistry cliffAdapter.ServiceRegistry) router.MessageHandler {
+	return router.NewMessageHandler(
+		router.MessageProcessorFn(func(message *fimpgo.Message) (*fimpgo.FimpMessage, error) {
+			service, err := getChargepointService(serviceRegistry, message)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := service.ClearSchedule(); err != nil {
+				return nil, fmt.Errorf("adapter: failed to clear charging schedule: %w", err)
+			}
+
+			if err := service.SendScheduleReport(); err != nil {
+				return nil, fmt.Errorf("adapter: failed to send charging schedule report: %w", err)
+			}
+
+			return nil, nil
+		}),
+	)
+}
+
+// routeCmdDepartureTargetSet returns a routing responsible for handling the command.
+func routeCmdDepartureTargetSet(serviceRegistry cliffAdapter.ServiceRegistry) *router.Routing {
+	return router.NewRouting(
+		handleCmdDepartureTargetSet(serviceRegistry),
+		router.ForService(chargepoint.Chargepoint),
+		router.ForType(easee.CmdDepartureTargetSet),
+	)
+}
+
+// handleCmdDepartureTargetSet returns a handler responsible for handling the command.
+func handleCmdDepartureTargetSet(serviceRegistry cliffAdapter.ServiceRegistry) router.MessageHandler {
+	return router.NewMessageHandler(
+		router.MessageProcessorFn(func(message *fimpgo.Message) (*fimpgo.FimpMessage, error) {
+			service, err := getChargepointService(serviceRegistry, message)
+			if err != nil {
+				return nil, err
+			}
+
+			var target model.DepartureTarget
+
+			if err := message.Payload.GetObjectValue(&target); err != nil {
+				return nil, fmt.Errorf("adapter: provided departure target has an incorrect format: %w", err)
+			}
+
+			if err := service.SetDepartureTarget(target); err != nil {
+				return nil, fmt.Errorf("adapter: failed to set departure target: %w", err)
+			}
+
+			if err := service.SendDepartureTargetReport(); err != nil {
+				return nil, fmt.Errorf("adapter: failed to send departure target report: %w", err)
+			}
+
+			return nil, nil
+		}),
+	)
+}
+
+// routeCmdDepartureTargetGetReport returns a routing responsible for handling the command.
+func routeCmdDepartureTargetGetReport(serviceRegistry cliffAdapter.ServiceRegistry) *router.Routing {
+	return router.NewRouting(
+		handleCmdDepartureTargetGetReport(serviceRegistry),
+		router.ForService(chargepoint.Chargepoint),
+		router.ForType(easee.CmdDepartureTargetGetReport),
+	)
+}
+
+// handleCmdDepartureTargetGetReport returns a handler responsible for handling the command.
+func handleCmdDepartureTargetGetReport(serviceRegistry cliffAdapter.ServiceRegistry) router.MessageHandler {
+	return router.NewMessageHandler(
+		router.MessageProcessorFn(func(message *fimpgo.Message) (*fimpgo.FimpMessage, error) {
+			service, err := getChargepointService(serviceRegistry, message)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := service.SendDepartureTargetReport(); err != nil {
+				return nil, fmt.Errorf("adapter: failed to send departure target report: %w", err)
+			}
+
+			return nil, nil
+		}),
+	)
+}
+
+// routeCmdAuthorizationSet returns a routing responsible for handling the command.
+func routeCmdAuthorizationSet(serviceRegistry cliffAdapter.ServiceRegistry) *router.Routing {
+	return router.NewRouting(
+		handleCmdAuthorizationSet(serviceRegistry),
+		router.ForService(chargepoint.Chargepoint),
+		router.ForType(easee.CmdAuthorizationSet),
+	)
+}
+
+// handleCmdAuthorizationSet returns a handler responsible for handling the command.
+func handleCmdAuthorizationSet(serviceRegistry cliffAdapter.ServiceRegistry) router.MessageHandler {
+	return router.NewMessageHandler(
+		router.MessageProcessorFn(func(message *fimpgo.Message) (*fimpgo.FimpMessage, error) {
+			service, err := getChargepointService(serviceRegistry, message)
+			if err != nil {
+				return nil, err
+			}
+
+			authorized, err := message.Payload.GetBoolValue()
+			if err != nil {
+				return nil, fmt.Errorf("adapter: provided charging authorization has an incorrect format: %w", err)
+			}
+
+			if err := service.SetAuthorization(authorized); err != nil {
+				return nil, fmt.Errorf("adapter: failed to set charging authorization: %w", err)
+			}
+
+			return nil, nil
+		}),
+	)
+}
+
+// routeCmdDiagnosticsGetReport returns a routing responsible for handling the command.
+func routeCmdDiagnosticsGetReport(serviceRegistry cliffAdapter.ServiceRegistry) *router.Routing {
+	return router.NewRouting(
+		handleCmdDiagnosticsGetReport(serviceRegistry),
+		router.ForService(chargepoint.Chargepoint),
+		router.ForType(easee.CmdDiagnosticsGetReport),
+	)
+}
+
+// handleCmdDiagnosticsGetReport returns a handler responsible for handling the command.
+func handleCmdDiagnosticsGetReport(serviceRegistry cliffAdapter.ServiceRegistry) router.MessageHandler {
+	return router.NewMessageHandler(
+		router.MessageProcessorFn(func(message *fimpgo.Message) (*fimpgo.FimpMessage, error) {
+			service, err := getChargepointService(serviceRegistry, message)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := service.SendDiagnosticsReport(); err != nil {
+				return nil, fmt.Errorf("adapter: failed to send diagnostics report: %w", err)
+			}
+
+			return nil, nil
+		}),
+	)
+}
+
+// sessionHistoryQuery parses a session history query from the message. All parameters are optional.
+// By default, all sessions started up until now are returned.
+func sessionHistoryQuery(payload *fimpgo.FimpMessage) (*easee.SessionHistoryQuery, error) {
+	query := &easee.SessionHistoryQuery{
+		To: clock.Now(),
+	}
+
+	if payload.ValueType == fimpgo.VTypeNull {
+		return query, nil
+	}
+
+	value, err := payload.GetStrMapValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if from, ok := value[sessionHistoryFrom]; ok {
+		if query.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", sessionHistoryFrom, err)
+		}
+	}
+
+	if to, ok := value[sessionHistoryTo]; ok {
+		if query.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", sessionHistoryTo, err)
+		}
+	}
+
+	if limit, ok := value[sessionHistoryLimit]; ok {
+		if query.Limit, err = strconv.Atoi(limit); err != nil || query.Limit < 0 {
+			return nil, fmt.Errorf("invalid %s: %s", sessionHistoryLimit, limit)
+		}
+	}
+
+	if offset, ok := value[sessionHistoryOffset]; ok {
+		if query.Offset, err = strconv.Atoi(offset); err != nil || query.Offset < 0 {
+			return nil, fmt.Errorf("invalid %s: %s", sessionHistoryOffset, offset)
+		}
+	}
+
+	if !query.From.Before(query.To) {
+		return nil, fmt.Errorf("%s must be before %s", sessionHistoryFrom, sessionHistoryTo)
+	}
+
+	return query, nil
+}
+
+func getChargepointService(serviceRegistry cliffAdapter.ServiceRegistry, message *fimpgo.Message) (easee.ChargepointService, error) {
+	s := serviceRegistry.ServiceByTopic(message.Topic)
+	if s == nil {
+		return nil, fmt.Errorf("adapter: service not found under the provided address: %s", message.Addr.ServiceAddress)
+	}
+
+	service, ok := s.(easee.ChargepointService)
+	if !ok {
+		return nil, fmt.Errorf("adapter: incorrect service found under the provided address: %s", message.Addr.ServiceAddress)
+	}
+
+	return service, nil
+}
@@ -0,0 +1,119 @@
+package routing
+
+import (
+	"fmt"
+
+	cliffAdapter "github.com/futurehomeno/cliffhanger/adapter"
+	"github.com/futurehomeno/cliffhanger/router"
+	"github.com/futurehomeno/fimpgo"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/easee"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+// RouteCircuit returns routing for circuit commands.
+func RouteCircuit(serviceRegistry cliffAdapter.ServiceRegistry) []*router.Routing {
+	return []*router.Routing{
+		routeCmdCurrentSet(serviceRegistry),
+		routeCmdCurrentGetReport(serviceRegistry),
+	}
+}
+
+// routeCmdCurrentSet returns a routing responsible for handling the command.
+func routeCmdCurrentSet(serviceRegistry cliffAdapter.ServiceRegistry) *router.Routing {
+	return router.NewRouting(
+		handleCmdCurrentSet(serviceRegistry),
+		router.ForService(easee.Circuit),
+		router.ForType(easee.CmdCurrentSet),
+	)
+}
+
+// handleCmdCurrentSet returns a handler responsible for handling the command.
+func handleCmdCurrentSet(serviceRegistry cliffAdapter.ServiceRegistry) router.MessageHandler {
+	return router.NewMessageHandler(
+		router.MessageProcessorFn(func(message *fimpgo.Message) (*fimpgo.FimpMessage, error) {
+			service, err := getCircuitService(serviceRegistry, message)
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := message.Payload.GetFloatMapValue()
+			if err != nil {
+				return nil, fmt.Errorf("adapter: provided circuit current has an incorrect format: %w", err)
+			}
+
+			current, err := circuitCurrent(value)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := service.SetDynamicCurrent(current); err != nil {
+				return nil, fmt.Errorf("adapter: failed to set circuit current: %w", err)
+			}
+
+			if err := service.SendDynamicCurrentReport(); err != nil {
+				return nil, fmt.Errorf("adapter: failed to send circuit current report: %w", err)
+			}
+
+			return nil, nil
+		}),
+	)
+}
+
+// routeCmdCurrentGetReport returns a routing responsible for handling the command.
+func routeCmdCurrentGetReport(serviceRegistry cliffAdapter.ServiceRegistry) *router.Routing {
+	return router.NewRouting(
+		handleCmdCurrentGetReport(serviceRegistry),
+		router.ForService(easee.Circuit),
+		router.ForType(easee.CmdCurrentGetReport),
+	)
+}
+
+// handleCmdCurrentGetReport returns a handler responsible for handling the command.
+func handleCmdCurrentGetReport(serviceRegistry cliffAdapter.ServiceRegistry) router.MessageHandler {
+	return router.NewMessageHandler(
+		router.MessageProcessorFn(func(message *fimpgo.Message) (*fimpgo.FimpMessage, error) {
+			service, err := getCircuitService(serviceRegistry, message)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := service.SendDynamicCurrentReport(); err != nil {
+				return nil, fmt.Errorf("adapter: failed to send circuit current report: %w", err)
+			}
+
+			return nil, nil
+		}),
+	)
+}
+
+// circuitCurrent converts the provided value to the circuit current, requiring the current of all phases.
+func circuitCurrent(value map[string]float64) (model.CircuitCurrent, error) {
+	var phases [3]float64
+
+	for i, key := range []string{easee.CircuitCurrentPhase1, easee.CircuitCurrentPhase2, easee.CircuitCurrentPhase3} {
+		current, ok := value[key]
+		if !ok {
+			return model.CircuitCurrent{}, fmt.Errorf("adapter: provided circuit current is missing the current of phase %d", i+1)
+		}
+
+		phases[i] = current
+	}
+
+	return model.CircuitCurrent{Phase1: phases[0], Phase2: phases[1], Phase3: phases[2]}, nil
+}
+
+// getCircuitService returns the circuit service responsible for handling the message.
+func getCircuitService(serviceRegistry cliffAdapter.ServiceRegistry, message *fimpgo.Message) (easee.CircuitService, error) {
+	s := serviceRegistry.ServiceByTopic(message.Topic)
+	if s == nil {
+		return nil, fmt.Errorf("adapter: service not found under the provided address: %s", message.Addr.ServiceAddress)
+	}
+
+	service, ok := s.(easee.CircuitService)
+	if !ok {
+		return nil, fmt.Errorf("adapter: incorrect service found under the provided address: %s", message.Addr.ServiceAddress)
+	}
+
+	return service, nil
+}
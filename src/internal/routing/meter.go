@@ -0,0 +1,199 @@
+package routing
+
+import (
+	"fmt"
+
+	cliffAdapter "github.com/futurehomeno/cliffhanger/adapter"
+	"github.com/futurehomeno/cliffhanger/adapter/service/chargepoint"
+	"github.com/futurehomeno/cliffhanger/adapter/service/numericmeter"
+	"github.com/futurehomeno/cliffhanger/router"
+	"github.com/futurehomeno/fimpgo"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/config"
+	"github.com/futurehomeno/edge-easee-adapter/internal/easee"
+)
+
+// propertyUnit is a property of meter reports holding the unit of the reported value.
+const propertyUnit = "unit"
+
+// RouteSolarMeter returns routing for reports of the meter configured for the solar charging mode.
+func RouteSolarMeter(cfgSrv *config.Service, adapter cliffAdapter.Adapter) []*router.Routing {
+	return []*router.Routing{
+		routeEvtSolarMeterReport(cfgSrv, adapter),
+		routeEvtSolarMeterExtReport(cfgSrv, adapter),
+	}
+}
+
+// routeEvtSolarMeterReport returns a routing responsible for handling the event.
+func routeEvtSolarMeterReport(cfgSrv *config.Service, adapter cliffAdapter.Adapter) *router.Routing {
+	return router.NewRouting(
+		handleEvtSolarMeterReport(adapter),
+		router.ForServiceAndType(numericmeter.MeterElec, numericmeter.EvtMeterReport),
+		forConfiguredTopic(cfgSrv.GetSolarMeterTopic),
+	)
+}
+
+// handleEvtSolarMeterReport returns a handler responsible for handling the event.
+func handleEvtSolarMeterReport(adapter cliffAdapter.Adapter) router.MessageHandler {
+	return router.NewMessageHandler(
+		router.MessageProcessorFn(func(message *fimpgo.Message) (*fimpgo.FimpMessage, error) {
+			if message.Payload.Properties[propertyUnit] != string(numericmeter.UnitW) {
+				return nil, nil
+			}
+
+			power, err := message.Payload.GetFloatValue()
+			if err != nil {
+				return nil, fmt.Errorf("adapter: provided meter report has an incorrect format: %w", err)
+			}
+
+			adjustSolarCharging(adapter, power)
+
+			return nil, nil
+		}),
+		router.WithSilentErrors(),
+	)
+}
+
+// routeEvtSolarMeterExtReport returns a routing responsible for handling the event.
+func routeEvtSolarMeterExtReport(cfgSrv *config.Service, adapter cliffAdapter.Adapter) *router.Routing {
+	return router.NewRouting(
+		handleEvtSolarMeterExtReport(adapter),
+		router.ForServiceAndType(numericmeter.MeterElec, numericmeter.EvtMeterExtReport),
+		forConfiguredTopic(cfgSrv.GetSolarMeterTopic),
+	)
+}
+
+// handleEvtSolarMeterExtReport returns a handler responsible for handling the event.
+func handleEvtSolarMeterExtReport(adapter cliffAdapter.Adapter) router.MessageHandler {
+	return router.NewMessageHandler(
+		router.MessageProcessorFn(func(message *fimpgo.Message) (*fimpgo.FimpMessage, error) {
+			values, err := message.Payload.GetFloatMapValue()
+			if err != nil {
+				return nil, fmt.Errorf("adapter: provided extended meter report has an incorrect format: %w", err)
+			}
+
+			imported, importOK := values[string(numericmeter.ValuePowerImport)]
+			exported, exportOK := values[string(numericmeter.ValuePowerExport)]
+
+			if !importOK && !exportOK {
+				return nil, nil
+			}
+
+			adjustSolarCharging(adapter, imported-exported)
+
+			return nil, nil
+		}),
+		router.WithSilentErrors(),
+	)
+}
+
+// adjustSolarCharging adjusts solar charging of all chargers to the power imported from the grid.
+// The grid power is split equally between chargers charging in the solar mode, so the surplus is not offered to each of them in full.
+func adjustSolarCharging(adapter cliffAdapter.Adapter, gridPower float64) {
+	var services []easee.ChargepointService
+
+	for _, s := range adapter.Services(chargepoint.Chargepoint) {
+		if service, ok := s.(easee.ChargepointService); ok && service.SolarChargingActive() {
+			services = append(services, service)
+		}
+	}
+
+	for _, service := range services {
+		if err := service.AdjustSolarCharging(gridPower / float64(len(services))); err != nil {
+			log.WithError(err).
+				WithField("topic", service.Topic()).
+				Error("adapter: failed to adjust solar charging")
+		}
+	}
+}
+
+// RouteLoadGuardMeter returns routing for reports of the main meter configured for the load guard.
+func RouteLoadGuardMeter(cfgSrv *config.Service, adapter cliffAdapter.Adapter) []*router.Routing {
+	return []*router.Routing{
+		routeEvtLoadGuardMeterExtReport(cfgSrv, adapter),
+	}
+}
+
+// routeEvtLoadGuardMeterExtReport returns a routing responsible for handling the event.
+func routeEvtLoadGuardMeterExtReport(cfgSrv *config.Service, adapter cliffAdapter.Adapter) *router.Routing {
+	return router.NewRouting(
+		handleEvtLoadGuardMeterExtReport(adapter),
+		router.ForServiceAndType(numericmeter.MeterElec, numericmeter.EvtMeterExtReport),
+		forConfiguredTopic(cfgSrv.GetLoadGuardMeterTopic),
+	)
+}
+
+// handleEvtLoadGuardMeterExtReport returns a handler responsible for handling the event.
+func handleEvtLoadGuardMeterExtReport(adapter cliffAdapter.Adapter) router.MessageHandler {
+	return router.NewMessageHandler(
+		router.MessageProcessorFn(func(message *fimpgo.Message) (*fimpgo.FimpMessage, error) {
+			values, err := message.Payload.GetFloatMapValue()
+			if err != nil {
+				return nil, fmt.Errorf("adapter: provided extended meter report has an incorrect format: %w", err)
+			}
+
+			var (
+				currents [3]float64
+				found    bool
+			)
+
+			for i, v := range []numericmeter.Value{
+				numericmeter.ValueCurrentPhase1,
+				numericmeter.ValueCurrentPhase2,
+				numericmeter.ValueCurrentPhase3,
+			} {
+				if current, ok := values[string(v)]; ok {
+					currents[i] = current
+					found = true
+				}
+			}
+
+			if !found {
+				return nil, nil
+			}
+
+			var services []easee.ChargepointService
+
+			for _, s := range adapter.Services(chargepoint.Chargepoint) {
+				if service, ok := s.(easee.ChargepointService); ok {
+					services = append(services, service)
+				}
+			}
+
+			// All chargers adjust to the same reading, so the headroom is shared to prevent them from raising into it at once.
+			for _, service := range services {
+				if err := service.AdjustLoadGuard(currents, len(services)); err != nil {
+					log.WithError(err).
+						WithField("topic", service.Topic()).
+						Error("adapter: failed to adjust load guard")
+				}
+			}
+
+			return nil, nil
+		}),
+		router.WithSilentErrors(),
+	)
+}
+
+// forConfiguredTopic is a message voter allowing a routing to handle messages published to the configured topic only.
+func forConfiguredTopic(topic func() string) router.MessageVoter {
+	return router.MessageVoterFn(func(message *fimpgo.Message) bool {
+		configuredTopic := topic()
+		if configuredTopic == "" || message.Addr == nil {
+			return false
+		}
+
+		configured, err := fimpgo.NewAddressFromString(configuredTopic)
+		if err != nil {
+			return false
+		}
+
+		// Global prefix is ignored as it is not relevant for matching local topics.
+		received := *message.Addr
+		received.GlobalPrefix = ""
+		configured.GlobalPrefix = ""
+
+		return received.Serialize() == configured.Serialize()
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/futurehomeno/cliffhanger/lifecycle"
 	"github.com/futurehomeno/cliffhanger/router"
 
+	"github.com/futurehomeno/edge-easee-adapter/internal/api"
 	"github.com/futurehomeno/edge-easee-adapter/internal/config"
 )
 
@@ -25,6 +26,7 @@ func New(
 	appLifecycle *lifecycle.Lifecycle,
 	application app.App,
 	adapter cliffAdapter.Adapter,
+	rateLimiter api.RateLimiter,
 ) []*router.Routing {
 	return router.Combine(
 		[]*router.Routing{
@@ -60,10 +62,40 @@ func New(
 			cliffConfig.RouteCmdConfigSetInt(ServiceName, "signalr_repeated_failure_count", cfgSrv.SetSignalRRepeatedFailureCount),
 			cliffConfig.RouteCmdConfigGetDuration(ServiceName, "signalr_invoke_timeout", cfgSrv.GetSignalRInvokeTimeout),
 			cliffConfig.RouteCmdConfigSetDuration(ServiceName, "signalr_invoke_timeout", cfgSrv.SetSignalRInvokeTimeout),
+			cliffConfig.RouteCmdConfigGetDuration(ServiceName, "session_retention_max_age", cfgSrv.GetSessionRetentionMaxAge),
+			cliffConfig.RouteCmdConfigSetDuration(ServiceName, "session_retention_max_age", cfgSrv.SetSessionRetentionMaxAge),
+			cliffConfig.RouteCmdConfigGetInt(ServiceName, "session_retention_max_count", cfgSrv.GetSessionRetentionMaxCount),
+			cliffConfig.RouteCmdConfigSetInt(ServiceName, "session_retention_max_count", cfgSrv.SetSessionRetentionMaxCount),
+			cliffConfig.RouteCmdConfigGetDuration(ServiceName, "session_retention_interval", cfgSrv.GetSessionRetentionInterval),
+			cliffConfig.RouteCmdConfigSetDuration(ServiceName, "session_retention_interval", cfgSrv.SetSessionRetentionInterval),
+			cliffConfig.RouteCmdConfigGetObject(ServiceName, "tariff", cfgSrv.GetTariff),
+			cliffConfig.RouteCmdConfigSetObject(ServiceName, "tariff", cfgSrv.SetTariff),
+			cliffConfig.RouteCmdConfigGetObject(ServiceName, "price_curve", cfgSrv.GetPriceCurve),
+			cliffConfig.RouteCmdConfigSetObject(ServiceName, "price_curve", cfgSrv.SetPriceCurve),
+			cliffConfig.RouteCmdConfigGetString(ServiceName, "solar_meter_topic", cfgSrv.GetSolarMeterTopic),
+			cliffConfig.RouteCmdConfigSetString(ServiceName, "solar_meter_topic", cfgSrv.SetSolarMeterTopic),
+			cliffConfig.RouteCmdConfigGetFloat(ServiceName, "solar_hysteresis", cfgSrv.GetSolarHysteresis),
+			cliffConfig.RouteCmdConfigSetFloat(ServiceName, "solar_hysteresis", cfgSrv.SetSolarHysteresis),
+			cliffConfig.RouteCmdConfigGetString(ServiceName, "load_guard_meter_topic", cfgSrv.GetLoadGuardMeterTopic),
+			cliffConfig.RouteCmdConfigSetString(ServiceName, "load_guard_meter_topic", cfgSrv.SetLoadGuardMeterTopic),
+			cliffConfig.RouteCmdConfigGetFloat(ServiceName, "main_fuse_rating", cfgSrv.GetMainFuseRating),
+			cliffConfig.RouteCmdConfigSetFloat(ServiceName, "main_fuse_rating", cfgSrv.SetMainFuseRating),
+			cliffConfig.RouteCmdConfigGetFloat(ServiceName, "load_guard_margin", cfgSrv.GetLoadGuardMargin),
+			cliffConfig.RouteCmdConfigSetFloat(ServiceName, "load_guard_margin", cfgSrv.SetLoadGuardMargin),
+			cliffConfig.RouteCmdConfigGetObject(ServiceName, "power_sharing", cfgSrv.GetPowerSharing),
+			cliffConfig.RouteCmdConfigSetObject(ServiceName, "power_sharing", cfgSrv.SetPowerSharing),
+			cliffConfig.RouteCmdConfigGetObject(ServiceName, "rate_limits", cfgSrv.GetRateLimitsCfg),
+			cliffConfig.RouteCmdConfigSetObject(ServiceName, "rate_limits", cfgSrv.SetRateLimitsCfg),
+			cliffConfig.RouteCmdConfigGetObject(ServiceName, "rate_limit_stats", rateLimiter.Stats),
 		},
 		app.RouteApp(ServiceName, appLifecycle, cfgSrv, config.Factory, nil, application),
 		cliffAdapter.RouteAdapter(adapter),
 		thing.RouteCarCharger(adapter),
 		parameters.RouteService(adapter),
+		RouteChargepoint(adapter),
+		RouteThing(adapter),
+		RouteCircuit(adapter),
+		RouteSolarMeter(cfgSrv, adapter),
+		RouteLoadGuardMeter(cfgSrv, adapter),
 	)
 }
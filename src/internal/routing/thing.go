@@ -0,0 +1,74 @@
+package routing
+
+import (
+	"fmt"
+
+	cliffAdapter "github.com/futurehomeno/cliffhanger/adapter"
+	"github.com/futurehomeno/cliffhanger/adapter/service/chargepoint"
+	"github.com/futurehomeno/cliffhanger/router"
+	"github.com/futurehomeno/fimpgo"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/easee"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+// RouteThing returns routing for adapter specific thing commands.
+func RouteThing(adapter cliffAdapter.Adapter) []*router.Routing {
+	return []*router.Routing{
+		routeCmdThingMaintenance(adapter, easee.CmdThingReboot, model.MaintenanceCommandReboot),
+		routeCmdThingMaintenance(adapter, easee.CmdThingUpdateFirmware, model.MaintenanceCommandUpdateFirmware),
+	}
+}
+
+// routeCmdThingMaintenance returns a routing responsible for handling the maintenance command.
+func routeCmdThingMaintenance(adapter cliffAdapter.Adapter, messageType string, command model.MaintenanceCommand) *router.Routing {
+	return router.NewRouting(
+		handleCmdThingMaintenance(adapter, command),
+		router.ForService(adapter.Name()),
+		router.ForType(messageType),
+	)
+}
+
+// handleCmdThingMaintenance returns a handler responsible for handling the maintenance command.
+// Completion of the command is reported once the charger comes back online.
+func handleCmdThingMaintenance(adapter cliffAdapter.Adapter, command model.MaintenanceCommand) router.MessageHandler {
+	return router.NewMessageHandler(
+		router.MessageProcessorFn(func(message *fimpgo.Message) (*fimpgo.FimpMessage, error) {
+			address, err := message.Payload.GetStringValue()
+			if err != nil {
+				return nil, fmt.Errorf("adapter: provided address has an incorrect format: %w", err)
+			}
+
+			service, err := getThingChargepointService(adapter, address)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := service.ExecuteMaintenance(command); err != nil {
+				return nil, fmt.Errorf("adapter: failed to execute %s command: %w", command, err)
+			}
+
+			return easee.NewMaintenanceReportMessage(ServiceName, easee.MaintenanceReport{
+				Address: address,
+				Command: command,
+				Status:  easee.MaintenanceStatusAccepted,
+			}), nil
+		}),
+	)
+}
+
+// getThingChargepointService returns the chargepoint service of the thing with the provided address.
+func getThingChargepointService(adapter cliffAdapter.Adapter, address string) (easee.ChargepointService, error) {
+	thing := adapter.ThingByAddress(address)
+	if thing == nil {
+		return nil, fmt.Errorf("adapter: thing not found under the provided address: %s", address)
+	}
+
+	for _, s := range thing.Services(chargepoint.Chargepoint) {
+		if service, ok := s.(easee.ChargepointService); ok {
+			return service, nil
+		}
+	}
+
+	return nil, fmt.Errorf("adapter: thing under the provided address is not a charger: %s", address)
+}
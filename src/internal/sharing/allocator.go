@@ -0,0 +1,271 @@
+package sharing
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/futurehomeno/cliffhanger/adapter/service/chargepoint"
+	"github.com/michalkurzeja/go-clock"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/cache"
+	"github.com/futurehomeno/edge-easee-adapter/internal/config"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+// defaultMaxCurrent is the maximum current per phase assumed for chargers which did not report it yet.
+const defaultMaxCurrent = 32
+
+// Allocator distributes the current budget of the site between chargers of the account.
+type Allocator interface {
+	// Register adds the charger to the power sharing.
+	Register(chargerID string, cache cache.Cache, limiter CurrentLimiter)
+	// Unregister removes the charger from the power sharing.
+	Unregister(chargerID string)
+	// ChargerStateChanged records the state of the charger and rebalances the budget in the background.
+	ChargerStateChanged(chargerID string, state chargepoint.State)
+	// Rebalance distributes the budget between charging chargers and limits their offered currents to the allocated shares.
+	// Other chargers are limited to the share of the remaining budget until they start charging.
+	Rebalance() error
+}
+
+// CurrentLimiter represents a controller of the charger able to limit the offered current to the share of the budget.
+type CurrentLimiter interface {
+	// LimitChargepointSharedCurrent limits the offered current to the share of the budget, charging is paused if the share is 0.
+	// A negative current lifts the limit. The current requested by the user or the active charging mode is offered up to the limit.
+	LimitChargepointSharedCurrent(current int64) error
+}
+
+type allocator struct {
+	cfgService *config.Service
+
+	chargers map[string]*charger
+	lock     sync.Mutex
+}
+
+// charger represents a charger taking part in the power sharing.
+type charger struct {
+	cache   cache.Cache
+	limiter CurrentLimiter
+	// state is the last observed state of the charger.
+	state chargepoint.State
+	// chargingSince is the time the charger started demanding a share of the budget, zero if it is not demanding.
+	chargingSince time.Time
+	// allocated is the current the charger is limited to, -1 if it is not limited.
+	allocated int64
+}
+
+// NewAllocator returns a new instance of Allocator.
+func NewAllocator(cfgService *config.Service) Allocator {
+	return &allocator{
+		cfgService: cfgService,
+		chargers:   make(map[string]*charger),
+	}
+}
+
+func (a *allocator) Register(chargerID string, cache cache.Cache, limiter CurrentLimiter) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	ch := &charger{cache: cache, limiter: limiter, allocated: -1}
+
+	state, _ := cache.ChargerState()
+	ch.observe(state)
+
+	a.chargers[chargerID] = ch
+}
+
+func (a *allocator) Unregister(chargerID string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	delete(a.chargers, chargerID)
+}
+
+func (a *allocator) ChargerStateChanged(chargerID string, state chargepoint.State) {
+	if !a.update(chargerID, state) {
+		return
+	}
+
+	go func() {
+		if err := a.Rebalance(); err != nil {
+			log.WithError(err).Error("sharing: failed to rebalance current budget")
+		}
+	}()
+}
+
+// update records the state of the charger and returns true if the budget has to be rebalanced.
+func (a *allocator) update(chargerID string, state chargepoint.State) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	ch, ok := a.chargers[chargerID]
+	if !ok {
+		return false
+	}
+
+	return ch.observe(state) && a.cfgService.GetPowerSharing() != nil
+}
+
+func (a *allocator) Rebalance() error {
+	sharing := a.cfgService.GetPowerSharing()
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if sharing == nil {
+		return a.apply(a.liftedLimits())
+	}
+
+	var (
+		demands []model.SharingDemand
+		idle    []string
+	)
+
+	for id, ch := range a.chargers {
+		state, _ := ch.cache.ChargerState()
+		ch.observe(state)
+
+		if ch.chargingSince.IsZero() {
+			idle = append(idle, id)
+
+			continue
+		}
+
+		demands = append(demands, model.SharingDemand{
+			ChargerID:     id,
+			MaxCurrent:    ch.maxCurrent(),
+			ChargingSince: ch.chargingSince,
+		})
+	}
+
+	limits := sharing.Allocate(demands)
+
+	remaining := sharing.Budget
+	for _, current := range limits {
+		remaining -= current
+	}
+
+	// Chargers which are not charging are limited to the share of the remaining budget, so a car starting to charge
+	// does not exceed the budget before it is re-balanced. They are paused until admitted if the share is not sufficient.
+	if len(idle) > 0 {
+		share := remaining / int64(len(idle))
+		if share < model.MinChargingCurrent {
+			share = 0
+		}
+
+		for _, id := range idle {
+			limits[id] = min(share, a.chargers[id].maxCurrent())
+		}
+	}
+
+	return a.apply(limits)
+}
+
+// liftedLimits returns limits lifting the limit of all chargers.
+func (a *allocator) liftedLimits() map[string]int64 {
+	limits := make(map[string]int64, len(a.chargers))
+
+	for id := range a.chargers {
+		limits[id] = -1
+	}
+
+	return limits
+}
+
+// apply limits the chargers to the provided currents. Currents are lowered first to avoid exceeding the budget while others are raised.
+func (a *allocator) apply(limits map[string]int64) error {
+	ids := make([]string, 0, len(limits))
+	for id := range limits {
+		ids = append(ids, id)
+	}
+
+	change := func(id string) int64 {
+		return unlimitedAsMax(limits[id]) - unlimitedAsMax(a.chargers[id].allocated)
+	}
+
+	slices.SortFunc(ids, func(i, j string) int {
+		return cmp.Or(cmp.Compare(change(i), change(j)), cmp.Compare(i, j))
+	})
+
+	var errs []error
+
+	for _, id := range ids {
+		if err := a.limit(id, a.chargers[id], limits[id]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// unlimitedAsMax returns the current with a lifted limit represented as the highest possible current.
+func unlimitedAsMax(current int64) int64 {
+	if current < 0 {
+		return math.MaxInt64
+	}
+
+	return current
+}
+
+// limit limits the offered current of the charger to the allocated share, a negative current lifts the limit.
+func (a *allocator) limit(chargerID string, ch *charger, current int64) error {
+	if ch.allocated == current {
+		return nil
+	}
+
+	if err := ch.limiter.LimitChargepointSharedCurrent(current); err != nil {
+		return fmt.Errorf("sharing: failed to limit charger %s to %d A: %w", chargerID, current, err)
+	}
+
+	ch.allocated = current
+
+	return nil
+}
+
+// maxCurrent returns the maximum current per phase of the charger.
+func (ch *charger) maxCurrent() int64 {
+	if maxCurrent, _ := ch.cache.MaxCurrent(); maxCurrent > 0 {
+		return maxCurrent
+	}
+
+	return defaultMaxCurrent
+}
+
+// observe records the state of the charger and returns true if the charger started or stopped demanding a share of the budget.
+func (ch *charger) observe(state chargepoint.State) bool {
+	demanding := ch.isDemanding(state)
+	ch.state = state
+
+	switch {
+	case demanding && ch.chargingSince.IsZero():
+		ch.chargingSince = clock.Now()
+	case !demanding && !ch.chargingSince.IsZero():
+		ch.chargingSince = time.Time{}
+	default:
+		return false
+	}
+
+	return true
+}
+
+// isDemanding checks if the charger in the provided state takes part in the allocation. Charging chargers and cars which have just
+// been connected are allocated a share, while chargers paused by the power sharing keep waiting for the share until the car
+// is disconnected or charging is finished. Chargers paused otherwise release their share.
+func (ch *charger) isDemanding(state chargepoint.State) bool {
+	switch state { //nolint:exhaustive
+	case chargepoint.StateCharging:
+		return true
+	case chargepoint.StateReadyToCharge, chargepoint.StateSuspendedByEV:
+		connected := ch.state == "" || ch.state == chargepoint.StateDisconnected
+
+		return connected || (!ch.chargingSince.IsZero() && ch.allocated == 0)
+	default:
+		return false
+	}
+}
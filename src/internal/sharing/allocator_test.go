@@ -0,0 +1,199 @@
+package sharing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/futurehomeno/cliffhanger/adapter/service/chargepoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/cache"
+	"github.com/futurehomeno/edge-easee-adapter/internal/config"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+	"github.com/futurehomeno/edge-easee-adapter/internal/sharing"
+	"github.com/futurehomeno/edge-easee-adapter/internal/test/fakes"
+	"github.com/futurehomeno/edge-easee-adapter/internal/test/mocks"
+)
+
+func TestAllocator_Rebalance(t *testing.T) { //nolint:paralleltest
+	now := time.Now()
+
+	cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+
+	first := cache.NewCache("XX00001")
+	first.SetMaxCurrent(16, now)
+
+	second := cache.NewCache("XX00002")
+	second.SetMaxCurrent(32, now)
+
+	caches := map[string]cache.Cache{"XX00001": first, "XX00002": second}
+	limiters := map[string]*mocks.CurrentLimiter{"XX00001": mocks.NewCurrentLimiter(t), "XX00002": mocks.NewCurrentLimiter(t)}
+
+	a := sharing.NewAllocator(cfgService)
+	a.Register("XX00001", first, limiters["XX00001"])
+	a.Register("XX00002", second, limiters["XX00002"])
+
+	steps := []struct {
+		name    string
+		sharing *model.PowerSharing
+		disable bool
+		states  map[string]chargepoint.State
+		limits  map[string]int64
+	}{
+		{
+			name:   "nothing is limited if power sharing is not configured",
+			states: map[string]chargepoint.State{"XX00001": chargepoint.StateCharging},
+		},
+		{
+			name:    "the budget is allocated to a charging charger while others are paused until admitted",
+			sharing: &model.PowerSharing{Strategy: model.SharingStrategyEqual, Budget: 20},
+			limits:  map[string]int64{"XX00001": 16, "XX00002": 0},
+		},
+		{
+			name:   "the budget is shared equally once another car is connected",
+			states: map[string]chargepoint.State{"XX00002": chargepoint.StateReadyToCharge},
+			limits: map[string]int64{"XX00001": 10, "XX00002": 10},
+		},
+		{
+			name:   "unchanged allocations are not applied again",
+			states: map[string]chargepoint.State{"XX00002": chargepoint.StateCharging},
+		},
+		{
+			name:    "chargers which can't be allocated the minimum current are paused",
+			sharing: &model.PowerSharing{Strategy: model.SharingStrategyPriority, Budget: 10, Priority: []string{"XX00002", "XX00001"}},
+			limits:  map[string]int64{"XX00001": 0},
+		},
+		{
+			name:   "chargers paused by the power sharing keep waiting for the share",
+			states: map[string]chargepoint.State{"XX00001": chargepoint.StateSuspendedByEV},
+		},
+		{
+			name:   "the budget is re-allocated once a car is disconnected",
+			states: map[string]chargepoint.State{"XX00002": chargepoint.StateDisconnected},
+			limits: map[string]int64{"XX00001": 10, "XX00002": 0},
+		},
+		{
+			name:   "chargers paused outside of the power sharing are limited to the share of the remaining budget",
+			states: map[string]chargepoint.State{"XX00001": chargepoint.StateSuspendedByEV},
+			limits: map[string]int64{"XX00001": 0},
+		},
+		{
+			name:   "the share is allocated once charging is resumed",
+			states: map[string]chargepoint.State{"XX00001": chargepoint.StateCharging},
+			limits: map[string]int64{"XX00001": 10},
+		},
+		{
+			name:    "idle chargers are limited to the share of the remaining budget",
+			sharing: &model.PowerSharing{Strategy: model.SharingStrategyEqual, Budget: 24},
+			limits:  map[string]int64{"XX00001": 16, "XX00002": 8},
+		},
+		{
+			name:    "limits are lifted once power sharing is disabled",
+			disable: true,
+			limits:  map[string]int64{"XX00001": -1, "XX00002": -1},
+		},
+	}
+
+	for _, s := range steps {
+		if s.sharing != nil || s.disable {
+			require.NoError(t, cfgService.SetPowerSharing(s.sharing), s.name)
+		}
+
+		for id, state := range s.states {
+			caches[id].SetChargerState(state, now)
+		}
+
+		for id, limit := range s.limits {
+			limiters[id].On("LimitChargepointSharedCurrent", limit).Return(nil).Once()
+		}
+
+		assert.NoError(t, a.Rebalance(), s.name)
+
+		for _, limiter := range limiters {
+			limiter.AssertExpectations(t)
+		}
+	}
+}
+
+func TestAllocator_Rebalance_Order(t *testing.T) { //nolint:paralleltest
+	now := time.Now()
+
+	cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+	require.NoError(t, cfgService.SetPowerSharing(&model.PowerSharing{Strategy: model.SharingStrategyEqual, Budget: 10}))
+
+	first := cache.NewCache("XX00001")
+	first.SetMaxCurrent(16, now)
+	first.SetChargerState(chargepoint.StateCharging, now)
+
+	second := cache.NewCache("XX00002")
+	second.SetMaxCurrent(32, now)
+	second.SetChargerState(chargepoint.StateCharging, now)
+
+	var order []string
+
+	firstLimiter := mocks.NewCurrentLimiter(t)
+	firstLimiter.On("LimitChargepointSharedCurrent", mock.Anything).
+		Return(nil).
+		Run(func(mock.Arguments) { order = append(order, "XX00001") })
+
+	secondLimiter := mocks.NewCurrentLimiter(t)
+	secondLimiter.On("LimitChargepointSharedCurrent", int64(15)).
+		Return(nil).
+		Run(func(mock.Arguments) { order = append(order, "XX00002") }).
+		Once()
+
+	a := sharing.NewAllocator(cfgService)
+	a.Register("XX00001", first, firstLimiter)
+	require.NoError(t, a.Rebalance())
+
+	// The unlimited charger is lowered to its share before the limited one is raised.
+	a.Register("XX00002", second, secondLimiter)
+	require.NoError(t, cfgService.SetPowerSharing(&model.PowerSharing{Strategy: model.SharingStrategyEqual, Budget: 30}))
+	require.NoError(t, a.Rebalance())
+
+	assert.Equal(t, []string{"XX00001", "XX00002", "XX00001"}, order)
+	firstLimiter.AssertCalled(t, "LimitChargepointSharedCurrent", int64(10))
+	firstLimiter.AssertCalled(t, "LimitChargepointSharedCurrent", int64(15))
+}
+
+func TestAllocator_ChargerStateChanged(t *testing.T) { //nolint:paralleltest
+	now := time.Now()
+
+	cfgService := config.NewService(fakes.NewConfigStorage(t, &config.Config{}, config.Factory))
+	require.NoError(t, cfgService.SetPowerSharing(&model.PowerSharing{Strategy: model.SharingStrategyEqual, Budget: 16}))
+
+	limiter := mocks.NewCurrentLimiter(t)
+
+	chargerCache := cache.NewCache("XX00001")
+	chargerCache.SetMaxCurrent(32, now)
+
+	a := sharing.NewAllocator(cfgService)
+	a.Register("XX00001", chargerCache, limiter)
+
+	done := make(chan struct{})
+
+	limiter.On("LimitChargepointSharedCurrent", int64(16)).
+		Return(nil).
+		Run(func(_ mock.Arguments) { close(done) }).
+		Once()
+
+	// The disconnected charger does not demand a share.
+	chargerCache.SetChargerState(chargepoint.StateDisconnected, now)
+	a.ChargerStateChanged("XX00001", chargepoint.StateDisconnected)
+
+	// The connected car is admitted to the power sharing.
+	chargerCache.SetChargerState(chargepoint.StateReadyToCharge, now)
+	a.ChargerStateChanged("XX00001", chargepoint.StateReadyToCharge)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("current budget has not been rebalanced")
+	}
+
+	// Unregistered chargers are ignored.
+	a.Unregister("XX00001")
+	a.ChargerStateChanged("XX00001", chargepoint.StateFinished)
+}
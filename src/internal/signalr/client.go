@@ -37,28 +37,33 @@ type Client interface {
 	StateC() <-chan model.ClientState
 	// ObservationC returns a channel that will receive charger observations.
 	ObservationC() <-chan model.Observation
+	// CommandResponseC returns a channel that will receive responses of chargers to commands.
+	CommandResponseC() <-chan model.CommandResponse
 }
 
 type client struct {
 	mu      sync.Mutex
 	running bool
+	ctx     context.Context //nolint:containedctx
 	cancel  context.CancelFunc
 
 	connection    signalr.Client
 	cfg           *config.Service
-	tokenProvider func() (string, error)
+	tokenProvider func(ctx context.Context) (string, error)
 	receiver      *receiver
 	backoff       backoff.Stateful
 
-	states       chan model.ClientState
-	observations chan model.Observation
+	states           chan model.ClientState
+	observations     chan model.Observation
+	commandResponses chan model.CommandResponse
 
 	connState model.ClientState
 }
 
 // NewClient creates a new SignalR client.
-func NewClient(cfg *config.Service, tokenProvider func() (string, error)) Client {
+func NewClient(cfg *config.Service, tokenProvider func(ctx context.Context) (string, error)) Client {
 	observations := make(chan model.Observation, 100)
+	commandResponses := make(chan model.CommandResponse, 10)
 
 	backoff := backoff.NewStateful(cfg.GetSignalRInitialBackoff(),
 		cfg.GetSignalRRepeatedBackoff(),
@@ -67,21 +72,22 @@ func NewClient(cfg *config.Service, tokenProvider func() (string, error)) Client
 		cfg.GetSignalRRepeatedFailureCount())
 
 	return &client{
-		cfg:           cfg,
-		tokenProvider: tokenProvider,
-		receiver:      newReceiver(observations),
-		backoff:       backoff,
-		states:        make(chan model.ClientState, 10),
-		observations:  observations,
+		cfg:              cfg,
+		tokenProvider:    tokenProvider,
+		receiver:         newReceiver(observations, commandResponses),
+		backoff:          backoff,
+		states:           make(chan model.ClientState, 10),
+		observations:     observations,
+		commandResponses: commandResponses,
 	}
 }
 
 func (c *client) SubscribeCharger(id string) error {
-	return c.invoke("SubscribeWithCurrentState", id, true) // true stands for sending initial batch of data
+	return c.invoke(c.runContext(), "SubscribeWithCurrentState", id, true) // true stands for sending initial batch of data
 }
 
 func (c *client) UnsubscribeCharger(id string) error {
-	return c.invoke("Unsubscribe", id)
+	return c.invoke(c.runContext(), "Unsubscribe", id)
 }
 
 func (c *client) Connected() bool {
@@ -103,6 +109,10 @@ func (c *client) ObservationC() <-chan model.Observation {
 	return c.observations
 }
 
+func (c *client) CommandResponseC() <-chan model.CommandResponse {
+	return c.commandResponses
+}
+
 func (c *client) Start() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -112,6 +122,7 @@ func (c *client) Start() {
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
+	c.ctx = ctx
 	c.cancel = cancel
 
 	go c.handleConnection(ctx)
@@ -132,13 +143,27 @@ func (c *client) Close() error {
 		c.cancel = nil
 	}
 
+	c.ctx = nil
+
 	c.backoff.Reset()
 	c.running = false
 
 	return nil
 }
 
-func (c *client) invoke(method string, args ...any) error {
+// runContext returns the context of the running client, cancelled once the client is closed.
+func (c *client) runContext() context.Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
+func (c *client) invoke(ctx context.Context, method string, args ...any) error {
 	c.mu.Lock()
 	if !c.running || c.connection == nil {
 		c.mu.Unlock()
@@ -146,18 +171,24 @@ func (c *client) invoke(method string, args ...any) error {
 		return errors.New("client is not running")
 	}
 
+	connection := c.connection
+
 	c.mu.Unlock()
 
-	timer := time.NewTimer(c.cfg.GetSignalRInvokeTimeout())
-	defer timer.Stop()
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.GetSignalRInvokeTimeout())
+	defer cancel()
 
-	results := c.connection.Invoke(method, args...)
+	results := connection.Invoke(method, args...)
 
 	select {
 	case result := <-results:
 		return result.Error
-	case <-timer.C:
-		return fmt.Errorf("timeout")
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timeout")
+		}
+
+		return fmt.Errorf("invocation of %s cancelled: %w", method, ctx.Err())
 	}
 }
 
@@ -229,7 +260,7 @@ func (c *client) updateState(state model.ClientState) bool {
 }
 
 func (c *client) getClient(ctx context.Context) (signalr.Client, error) {
-	connection, err := c.getConnection()
+	connection, err := c.getConnection(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -244,8 +275,8 @@ func (c *client) getClient(ctx context.Context) (signalr.Client, error) {
 	)
 }
 
-func (c *client) getConnection() (signalr.Connection, error) {
-	token, err := c.tokenProvider()
+func (c *client) getConnection(ctx context.Context) (signalr.Connection, error) {
+	token, err := c.tokenProvider(ctx)
 	if err != nil {
 		// Currently we have a bug, when authorization gets broken the signalR library may start
 		// calling this method in a forever loop (with -1 timeout) trying to create a connection,
@@ -264,7 +295,7 @@ func (c *client) getConnection() (signalr.Connection, error) {
 		return h
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.GetSignalRConnCreationTimeout())
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.GetSignalRConnCreationTimeout())
 	defer cancel()
 
 	url := c.cfg.GetSignalRBaseURL() + signalRURI
@@ -0,0 +1,136 @@
+package signalr
+
+import (
+	"github.com/futurehomeno/cliffhanger/adapter"
+	"github.com/futurehomeno/cliffhanger/adapter/service/numericmeter"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/cache"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+// kiloToBase converts power reported by the equalizer in kW to W.
+const kiloToBase = 1000
+
+type equalizerHandler struct {
+	cache    cache.EqualizerCache
+	thing    adapter.Thing
+	handlers map[model.ObservationID]func(model.Observation) error
+}
+
+// NewEqualizerHandler creates new observation handler of the equalizer.
+func NewEqualizerHandler(thing adapter.Thing, cache cache.EqualizerCache) Handler {
+	handler := &equalizerHandler{
+		cache: cache,
+		thing: thing,
+	}
+
+	handler.handlers = map[model.ObservationID]func(model.Observation) error{
+		model.EqualizerActivePowerImport:           handler.handleActivePowerImport,
+		model.EqualizerActivePowerExport:           handler.handleActivePowerExport,
+		model.EqualizerCurrentL1:                   handler.handleCurrent(numericmeter.ValueCurrentPhase1),
+		model.EqualizerCurrentL2:                   handler.handleCurrent(numericmeter.ValueCurrentPhase2),
+		model.EqualizerCurrentL3:                   handler.handleCurrent(numericmeter.ValueCurrentPhase3),
+		model.EqualizerCumulativeActivePowerImport: handler.handleCumulativeActivePowerImport,
+		model.EqualizerCumulativeActivePowerExport: handler.handleCumulativeActivePowerExport,
+	}
+
+	return handler
+}
+
+// IsOnline returns true, as the equalizer does not report its cloud connection.
+func (h *equalizerHandler) IsOnline() bool {
+	return true
+}
+
+func (h *equalizerHandler) HandleObservation(observation model.Observation) error {
+	if handler, ok := h.handlers[observation.ID]; ok {
+		return handler(observation)
+	}
+
+	return nil
+}
+
+func (h *equalizerHandler) handleActivePowerImport(observation model.Observation) error {
+	return h.handleMeasurement(observation, kiloToBase, func(meterElecSrv numericmeter.Service) error {
+		if _, err := meterElecSrv.SendMeterReport(numericmeter.UnitW, false); err != nil {
+			return err
+		}
+
+		_, err := meterElecSrv.SendMeterExtendedReport(numericmeter.Values{numericmeter.ValuePowerImport}, false)
+
+		return err
+	})
+}
+
+func (h *equalizerHandler) handleActivePowerExport(observation model.Observation) error {
+	return h.handleMeasurement(observation, kiloToBase, func(meterElecSrv numericmeter.Service) error {
+		if _, err := meterElecSrv.SendMeterExportReport(numericmeter.UnitW, false); err != nil {
+			return err
+		}
+
+		_, err := meterElecSrv.SendMeterExtendedReport(numericmeter.Values{numericmeter.ValuePowerExport}, false)
+
+		return err
+	})
+}
+
+func (h *equalizerHandler) handleCurrent(value numericmeter.Value) func(model.Observation) error {
+	return func(observation model.Observation) error {
+		return h.handleMeasurement(observation, 1, func(meterElecSrv numericmeter.Service) error {
+			_, err := meterElecSrv.SendMeterExtendedReport(numericmeter.Values{value}, false)
+
+			return err
+		})
+	}
+}
+
+func (h *equalizerHandler) handleCumulativeActivePowerImport(observation model.Observation) error {
+	return h.handleMeasurement(observation, 1, func(meterElecSrv numericmeter.Service) error {
+		if _, err := meterElecSrv.SendMeterReport(numericmeter.UnitKWh, false); err != nil {
+			return err
+		}
+
+		_, err := meterElecSrv.SendMeterExtendedReport(numericmeter.Values{numericmeter.ValueEnergyImport}, false)
+
+		return err
+	})
+}
+
+func (h *equalizerHandler) handleCumulativeActivePowerExport(observation model.Observation) error {
+	return h.handleMeasurement(observation, 1, func(meterElecSrv numericmeter.Service) error {
+		if _, err := meterElecSrv.SendMeterExportReport(numericmeter.UnitKWh, false); err != nil {
+			return err
+		}
+
+		_, err := meterElecSrv.SendMeterExtendedReport(numericmeter.Values{numericmeter.ValueEnergyExport}, false)
+
+		return err
+	})
+}
+
+// handleMeasurement caches the scaled value of the observation and sends reports of the meter_elec service if it has changed.
+func (h *equalizerHandler) handleMeasurement(observation model.Observation, scale float64, report func(numericmeter.Service) error) error {
+	val, err := observation.Float64Value()
+	if err != nil {
+		return err
+	}
+
+	ok := h.cache.SetMeasurement(observation.ID, val*scale, observation.Timestamp)
+	if !ok {
+		return nil
+	}
+
+	meterElecSrv, err := getMeterElecService(h.thing)
+	if err != nil {
+		return err
+	}
+
+	return report(meterElecSrv)
+}
+
+// isEqualizerHandler checks if the handler handles observations of an equalizer.
+func isEqualizerHandler(handler Handler) bool {
+	_, ok := handler.(*equalizerHandler)
+
+	return ok
+}
@@ -2,7 +2,9 @@ package signalr
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -11,15 +13,45 @@ import (
 	"github.com/futurehomeno/cliffhanger/adapter/service/chargepoint"
 	"github.com/futurehomeno/cliffhanger/adapter/service/numericmeter"
 	"github.com/futurehomeno/cliffhanger/adapter/service/parameters"
+	"github.com/futurehomeno/cliffhanger/notification"
+	"github.com/michalkurzeja/go-clock"
 	log "github.com/sirupsen/logrus"
 	"github.com/thoas/go-funk"
 
+	"github.com/futurehomeno/edge-easee-adapter/internal/api"
 	"github.com/futurehomeno/edge-easee-adapter/internal/cache"
 	"github.com/futurehomeno/edge-easee-adapter/internal/config"
 	"github.com/futurehomeno/edge-easee-adapter/internal/db"
 	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+	"github.com/futurehomeno/edge-easee-adapter/internal/params"
 )
 
+// offlineMaxCurrentUpdateDelay is a time observations of all phases of the offline max current are awaited for before it is reported.
+const offlineMaxCurrentUpdateDelay = time.Second
+
+// ChargerStateListener is notified about changes of the charger state.
+type ChargerStateListener interface {
+	// ChargerStateChanged is called once the state of the charger changes.
+	ChargerStateChanged(chargerID string, state chargepoint.State)
+}
+
+// MaintenanceListener is notified about completion of remote maintenance commands of the charger.
+type MaintenanceListener interface {
+	// MaintenanceCompleted is called once the charger comes back online after executing the maintenance command.
+	MaintenanceCompleted(command model.MaintenanceCommand)
+}
+
+// FirmwareListener is notified about the firmware version reported by the charger.
+type FirmwareListener interface {
+	// FirmwareVersionObserved is called once the charger reports the installed firmware version.
+	FirmwareVersionObserved(chargerID string, version int) error
+}
+
+// diagnosticsReporter is a chargepoint service able to report diagnostics of the charger.
+type diagnosticsReporter interface {
+	SendDiagnosticsReport() error
+}
+
 // Handler interface handles signalr observations.
 type Handler interface {
 	// IsOnline return if the charger is online.
@@ -30,12 +62,20 @@ type Handler interface {
 }
 
 type observationsHandler struct {
-	cache          cache.Cache
-	handlers       map[model.ObservationID]func(model.Observation) error
-	thing          adapter.Thing
-	energyHandler  *energyHandler
-	sessionStorage db.ChargingSessionStorage
-	chargerID      string
+	cache               cache.Cache
+	handlers            map[model.ObservationID]func(model.Observation) error
+	thing               adapter.Thing
+	confSrv             *config.Service
+	energyHandler       *energyHandler
+	sessionStorage      db.ChargingSessionStorage
+	chargerID           string
+	stateListener       ChargerStateListener
+	firmwareListener    FirmwareListener
+	notifier            api.Notifier
+	maintenanceListener MaintenanceListener
+
+	offlineMaxCurrentLock      sync.Mutex
+	offlineMaxCurrentScheduled bool
 
 	isCloudOnline atomic.Bool
 	isStateOnline atomic.Bool
@@ -48,13 +88,22 @@ func NewObservationsHandler(
 	confSrv *config.Service,
 	sessionStorage db.ChargingSessionStorage,
 	chargerID string,
+	stateListener ChargerStateListener,
+	firmwareListener FirmwareListener,
+	notifier api.Notifier,
+	maintenanceListener MaintenanceListener,
 ) (Handler, error) {
 	handler := observationsHandler{
-		cache:          cache,
-		thing:          thing,
-		energyHandler:  newEnergyHandler(cache, thing, confSrv),
-		sessionStorage: sessionStorage,
-		chargerID:      chargerID,
+		cache:               cache,
+		confSrv:             confSrv,
+		thing:               thing,
+		energyHandler:       newEnergyHandler(cache, thing, confSrv, sessionStorage, chargerID),
+		sessionStorage:      sessionStorage,
+		chargerID:           chargerID,
+		stateListener:       stateListener,
+		firmwareListener:    firmwareListener,
+		notifier:            notifier,
+		maintenanceListener: maintenanceListener,
 	}
 
 	handler.isCloudOnline.Store(true)
@@ -76,9 +125,15 @@ func NewObservationsHandler(
 		model.CloudConnected:        handler.handleCloudConnected,
 		model.CableLocked:           handler.handleCableLocked,
 		model.CableRating:           handler.handleCableRating,
-		model.LockCablePermanently:  handler.handleLockCablePermanently,
 		model.ChargingSessionStop:   handler.handleChargingSessionStop,
 		model.ChargingSessionStart:  handler.handleChargingSessionStart,
+		model.ChargerFirmware:       handler.handleChargerFirmware,
+		model.ErrorCode:             handler.handleErrorCode,
+		model.ReasonForNoCurrent:    handler.handleReasonForNoCurrent,
+	}
+
+	for id, param := range params.Observed() {
+		handler.handlers[id] = handler.parameterHandler(param)
 	}
 
 	return &handler, nil
@@ -102,38 +157,10 @@ func (h *observationsHandler) handlePhaseMode(observation model.Observation) err
 		return err
 	}
 
-	phaseMode, _ := h.cache.PhaseMode()
-
-	if val == phaseMode {
-		return nil
-	}
+	// Supported phase modes do not depend on the phase mode setting, as the charger can be switched between them.
+	h.cache.SetPhaseMode(val, observation.Timestamp)
 
-	ok := h.cache.SetPhaseMode(val, observation.Timestamp)
-	if !ok {
-		return nil
-	}
-
-	service, err := getChargepointService(h.thing)
-	if err != nil {
-		return err
-	}
-
-	gridType, _ := h.cache.GridType()
-	phases, _ := h.cache.Phases()
-	phaseMode, _ = h.cache.PhaseMode()
-	supportedModes := model.SupportedPhaseModes(gridType, phaseMode, phases)
-
-	service = h.ensureChargepointProps(service, map[string]interface{}{
-		chargepoint.PropertySupportedPhaseModes: supportedModes,
-	})
-
-	if err := h.thing.Update(adapter.ThingUpdateRemoveService(service), adapter.ThingUpdateAddService(service)); err != nil {
-		return err
-	}
-
-	_, err = h.thing.SendInclusionReport(false)
-
-	return err
+	return nil
 }
 
 func (h *observationsHandler) handleMaxChargerCurrent(observation model.Observation) error {
@@ -164,10 +191,21 @@ func (h *observationsHandler) handleCloudConnected(observation model.Observation
 	}
 
 	h.isCloudOnline.Store(val)
+	h.completeMaintenance(val, observation.Timestamp)
 
 	return err
 }
 
+// completeMaintenance notifies the listener once the charger comes back online after the pending maintenance command.
+func (h *observationsHandler) completeMaintenance(online bool, timestamp time.Time) {
+	command, ok := h.cache.CompleteMaintenance(online, timestamp)
+	if !ok || h.maintenanceListener == nil {
+		return
+	}
+
+	h.maintenanceListener.MaintenanceCompleted(command)
+}
+
 func (h *observationsHandler) handleDynamicChargerCurrent(observation model.Observation) error {
 	val, err := observation.Float64Value()
 	if err != nil {
@@ -247,11 +285,16 @@ func (h *observationsHandler) handleChargerState(observation model.Observation)
 	}
 
 	h.isStateOnline.Store(state != model.ChargerStateOffline)
+	h.completeMaintenance(state != model.ChargerStateOffline, observation.Timestamp)
 
 	if state.IsSessionFinished() {
 		h.cache.SetRequestedOfferedCurrent(0, time.Now())
 	}
 
+	if h.stateListener != nil {
+		h.stateListener.ChargerStateChanged(h.chargerID, state.ToFimpState())
+	}
+
 	chargepointSrv, err := getChargepointService(h.thing)
 	if err != nil {
 		return err
@@ -406,6 +449,14 @@ func (h *observationsHandler) handleDetectedPowerGridType(observation model.Obse
 		return err
 	}
 
+	previous := h.cache.Diagnostics()
+	if ok := h.cache.SetDetectedGridType(model.GridType(val), observation.Timestamp); ok {
+		// Diagnostics are informative only, so a failure must not prevent the installation parameters update.
+		if err := h.reportDiagnostics(previous); err != nil {
+			log.WithError(err).Warn("failed to report charger diagnostics")
+		}
+	}
+
 	gridType, _ := h.cache.GridType()
 	phases, _ := h.cache.Phases()
 
@@ -424,9 +475,7 @@ func (h *observationsHandler) handleDetectedPowerGridType(observation model.Obse
 		return err
 	}
 
-	phaseMode, _ := h.cache.PhaseMode()
-
-	supportedModes := model.SupportedPhaseModes(supportedGridType, phaseMode, supportedPhases)
+	supportedModes := model.SwitchablePhaseModes(supportedGridType, supportedPhases)
 
 	service = h.ensureChargepointProps(service, map[string]interface{}{
 		chargepoint.PropertyGridType:            supportedGridType,
@@ -443,27 +492,179 @@ func (h *observationsHandler) handleDetectedPowerGridType(observation model.Obse
 	return err
 }
 
-func (h *observationsHandler) handleLockCablePermanently(observation model.Observation) error {
-	val, err := observation.BoolValue()
+// parameterHandler returns a handler storing the observed parameter value and reporting it if it has changed.
+func (h *observationsHandler) parameterHandler(param *params.Parameter) func(model.Observation) error {
+	return func(observation model.Observation) error {
+		ok, err := param.Observe(h.cache, observation)
+		if err != nil || !ok {
+			return err
+		}
+
+		// Currents of all phases are usually observed at once, so they are reported together.
+		if param.ID == model.OfflineMaxCurrentParameter {
+			h.scheduleOfflineMaxCurrentUpdate()
+
+			return nil
+		}
+
+		return h.sendParameterReport(param.ID)
+	}
+}
+
+func (h *observationsHandler) sendParameterReport(id string) error {
+	parameterSrv, err := getParametersService(h.thing)
 	if err != nil {
 		return err
 	}
 
-	ok := h.cache.SetCableAlwaysLocked(val, observation.Timestamp)
+	_, err = parameterSrv.SendParameterReport(id, true)
+
+	return err
+}
+
+// scheduleOfflineMaxCurrentUpdate reports the offline max current once observations of all phases are awaited for.
+func (h *observationsHandler) scheduleOfflineMaxCurrentUpdate() {
+	h.offlineMaxCurrentLock.Lock()
+	defer h.offlineMaxCurrentLock.Unlock()
+
+	if h.offlineMaxCurrentScheduled {
+		return
+	}
+
+	h.offlineMaxCurrentScheduled = true
+
+	clock.AfterFunc(offlineMaxCurrentUpdateDelay, func() {
+		h.offlineMaxCurrentLock.Lock()
+		h.offlineMaxCurrentScheduled = false
+		h.offlineMaxCurrentLock.Unlock()
+
+		if err := h.updateOfflineMaxCurrent(); err != nil {
+			log.WithField("thing_address", h.thing.Address()).
+				WithError(err).
+				Error("offline max current handler: failed to update offline max current")
+		}
+	})
+}
+
+// updateOfflineMaxCurrent reports the offline max current parameter and updates the chargepoint property,
+// so installers know what happens during cloud outages. Nothing is updated until the current of all phases is known.
+func (h *observationsHandler) updateOfflineMaxCurrent() error {
+	current, ok := h.cache.OfflineMaxCurrent()
 	if !ok {
 		return nil
 	}
 
-	parameterSrv, err := getParametersService(h.thing)
+	if err := h.sendParameterReport(model.OfflineMaxCurrentParameter); err != nil {
+		return err
+	}
+
+	service, err := getChargepointService(h.thing)
+	if err != nil {
+		return err
+	}
+
+	service = h.ensureChargepointProps(service, map[string]interface{}{
+		model.PropertyOfflineMaxCurrent: current.Currents(),
+	})
+
+	if err := h.thing.Update(adapter.ThingUpdateRemoveService(service), adapter.ThingUpdateAddService(service)); err != nil {
+		return err
+	}
+
+	_, err = h.thing.SendInclusionReport(false)
+
+	return err
+}
+
+// handleChargerFirmware passes the firmware version to the listener, which persists it and notifies the user once the charger is updated.
+func (h *observationsHandler) handleChargerFirmware(observation model.Observation) error {
+	val, err := observation.IntValue()
 	if err != nil {
 		return err
 	}
 
-	_, err = parameterSrv.SendParameterReport(model.CableAlwaysLockedParameter, true)
+	if ok := h.cache.SetFirmwareVersion(val, observation.Timestamp); !ok {
+		return nil
+	}
+
+	return h.firmwareListener.FirmwareVersionObserved(h.chargerID, val)
+}
+
+func (h *observationsHandler) handleErrorCode(observation model.Observation) error {
+	val, err := observation.IntValue()
+	if err != nil {
+		return err
+	}
+
+	previous := h.cache.Diagnostics()
+
+	if ok := h.cache.SetErrorCode(val, observation.Timestamp); !ok {
+		return nil
+	}
+
+	return h.reportDiagnostics(previous)
+}
+
+func (h *observationsHandler) handleReasonForNoCurrent(observation model.Observation) error {
+	val, err := observation.IntValue()
+	if err != nil {
+		return err
+	}
+
+	previous := h.cache.Diagnostics()
+
+	if ok := h.cache.SetReasonForNoCurrent(model.NoCurrentReason(val), observation.Timestamp); !ok {
+		return nil
+	}
+
+	if err := h.reportDiagnostics(previous); err != nil {
+		return err
+	}
+
+	if previous.ReasonForNoCurrent == model.NoCurrentReason(val) {
+		return nil
+	}
+
+	chargepointSrv, err := getChargepointService(h.thing)
+	if err != nil {
+		return err
+	}
+
+	// The state itself might not change, so the report is forced to deliver the reason amended to it.
+	_, err = chargepointSrv.SendStateReport(true)
 
 	return err
 }
 
+// reportDiagnostics sends the diagnostics report if it has changed and notifies the user about new faults.
+func (h *observationsHandler) reportDiagnostics(previous model.Diagnostics) error {
+	current := h.cache.Diagnostics()
+	if current == previous {
+		return nil
+	}
+
+	service, err := getChargepointService(h.thing)
+	if err != nil {
+		return err
+	}
+
+	if reporter, ok := service.(diagnosticsReporter); ok {
+		if err := reporter.SendDiagnosticsReport(); err != nil {
+			return err
+		}
+	}
+
+	faults := current.NewFaults(previous)
+	if len(faults) == 0 {
+		return nil
+	}
+
+	return h.notifier.Event(&notification.Event{
+		EventName:      model.NotificationChargerFault,
+		MessageContent: fmt.Sprintf("%s %s: %s", h.thing.InclusionReport().ProductName, h.chargerID, strings.Join(faults, ", ")),
+	})
+}
+
 func (h *observationsHandler) handleChargingSessionStop(observation model.Observation) error {
 	var chargingSession model.StopChargingSession
 
@@ -482,6 +683,16 @@ func (h *observationsHandler) handleChargingSessionStop(observation model.Observ
 		return err
 	}
 
+	// The session report does not depend on the cost bookkeeping.
+	if tariff := h.confSrv.GetTariff(); tariff != nil {
+		err = h.sessionStorage.UpdateSessionCost(h.chargerID, chargingSession.ID, chargingSession.MeterValueStop, chargingSession.Stop, tariff)
+		if err != nil {
+			log.WithField("thing_address", h.thing.Address()).
+				WithError(err).
+				Error("session stop handler: failed to update session cost")
+		}
+	}
+
 	_, err = chargepointSrv.SendCurrentSessionReport(false)
 
 	return err
@@ -529,14 +740,24 @@ type energyHandler struct {
 	thing                 adapter.Thing
 	lock                  sync.Mutex
 	confSrv               *config.Service
+	sessionStorage        db.ChargingSessionStorage
+	chargerID             string
 	energyObservationChan chan model.Observation
 }
 
-func newEnergyHandler(cache cache.Cache, thing adapter.Thing, confSrv *config.Service) *energyHandler {
+func newEnergyHandler(
+	cache cache.Cache,
+	thing adapter.Thing,
+	confSrv *config.Service,
+	sessionStorage db.ChargingSessionStorage,
+	chargerID string,
+) *energyHandler {
 	return &energyHandler{
-		cache:   cache,
-		thing:   thing,
-		confSrv: confSrv,
+		cache:          cache,
+		thing:          thing,
+		confSrv:        confSrv,
+		sessionStorage: sessionStorage,
+		chargerID:      chargerID,
 	}
 }
 
@@ -598,6 +819,12 @@ func (h *energyHandler) manageEnergyObservation() { //nolint:funlen
 		case <-timer.C:
 			h.cache.SetLifetimeEnergy(energy, energyAt)
 
+			if err := h.updateSessionCost(energy, energyAt); err != nil {
+				log.WithField("thing_address", h.thing.Address()).
+					WithError(err).
+					Error("lifetime energy handler: failed to update session cost")
+			}
+
 			meterElecSrv, err := getMeterElecService(h.thing)
 			if err != nil {
 				log.WithField("thing_address", h.thing.Address()).
@@ -630,6 +857,26 @@ func (h *energyHandler) manageEnergyObservation() { //nolint:funlen
 	}
 }
 
+// updateSessionCost updates the cost of the ongoing charging session with the lifetime energy reading.
+func (h *energyHandler) updateSessionCost(energy float64, energyAt time.Time) error {
+	tariff := h.confSrv.GetTariff()
+	if tariff == nil {
+		return nil
+	}
+
+	sessions, err := h.sessionStorage.LatestSessionsByChargerID(h.chargerID)
+	if err != nil {
+		return err
+	}
+
+	latest := sessions.Latest()
+	if latest == nil || !latest.Stop.IsZero() {
+		return nil
+	}
+
+	return h.sessionStorage.UpdateSessionCost(h.chargerID, latest.ID, energy, energyAt, tariff)
+}
+
 func getParametersService(thing adapter.Thing) (parameters.Service, error) {
 	for _, service := range thing.Services(parameters.Parameters) {
 		if service, ok := service.(parameters.Service); ok {
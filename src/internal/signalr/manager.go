@@ -1,13 +1,16 @@
 package signalr
 
 import (
+	"context"
 	"sync"
 	"time"
 
 	"github.com/futurehomeno/cliffhanger/backoff"
 	"github.com/futurehomeno/cliffhanger/root"
+	"github.com/michalkurzeja/go-clock"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/futurehomeno/edge-easee-adapter/internal/api"
 	"github.com/futurehomeno/edge-easee-adapter/internal/config"
 	"github.com/futurehomeno/edge-easee-adapter/internal/model"
 )
@@ -45,16 +48,22 @@ type manager struct {
 
 	subscriptions  chan string
 	clientStarting bool
+	// disconnectedAt is the time the client lost the connection, zero if it has not been connected yet.
+	disconnectedAt time.Time
 
-	client   Client
-	chargers map[string]*charger
+	client     Client
+	reconciler SessionReconciler
+	tracker    api.CommandTracker
+	chargers   map[string]*charger
 }
 
-func NewManager(cfg *config.Service, client Client) Manager {
+func NewManager(cfg *config.Service, client Client, reconciler SessionReconciler, tracker api.CommandTracker) Manager {
 	return &manager{
-		cfg:      cfg,
-		client:   client,
-		chargers: make(map[string]*charger),
+		cfg:        cfg,
+		client:     client,
+		reconciler: reconciler,
+		tracker:    tracker,
+		chargers:   make(map[string]*charger),
 	}
 }
 
@@ -171,6 +180,7 @@ func (m *manager) Connected(chargerID string) (bool, DisconnectionReason) {
 func (m *manager) run() {
 	states := m.client.StateC()
 	observations := m.client.ObservationC()
+	commandResponses := m.client.CommandResponseC()
 
 	for {
 		select {
@@ -189,6 +199,9 @@ func (m *manager) run() {
 
 		case observation := <-observations:
 			m.handleObservation(observation)
+
+		case response := <-commandResponses:
+			m.handleCommandResponse(response)
 		}
 	}
 }
@@ -245,17 +258,32 @@ func (m *manager) handleClientState(state model.ClientState) {
 		log.Debug("signalR: client connected")
 
 		m.subscriptions = make(chan string, 1+len(m.chargers))
+		chargerIDs := make([]string, 0, len(m.chargers))
+
+		for chargerID, charger := range m.chargers {
+			// Equalizers have no charging sessions to reconcile.
+			if !isEqualizerHandler(charger.handler) {
+				chargerIDs = append(chargerIDs, chargerID)
+			}
 
-		for chargerID := range m.chargers {
 			select {
 			case <-m.done:
 			case m.subscriptions <- chargerID:
 			}
 		}
 
+		go m.reconcileSessions(m.done, chargerIDs, m.disconnectedAt)
+
+		m.disconnectedAt = time.Time{}
+
 	case model.ClientStateDisconnected:
 		log.Debug("signalR: client disconnected")
 
+		// The earliest disconnection is kept, the client might report it repeatedly while reconnecting.
+		if m.disconnectedAt.IsZero() {
+			m.disconnectedAt = clock.Now()
+		}
+
 		for _, charger := range m.chargers {
 			charger.backoff.Reset()
 			charger.isSubscribed = false
@@ -272,6 +300,29 @@ func (m *manager) handleClientState(state model.ClientState) {
 	}
 }
 
+// reconcileSessions backfills charging sessions which might have been missed while the client was disconnected.
+// Reconciliation is cancelled once the manager is stopped.
+func (m *manager) reconcileSessions(done <-chan struct{}, chargerIDs []string, disconnectedAt time.Time) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for _, chargerID := range chargerIDs {
+		if err := m.reconciler.Reconcile(ctx, chargerID, disconnectedAt); err != nil {
+			log.WithError(err).
+				WithField("chargerID", chargerID).
+				Warn("signalR: failed to reconcile charging sessions")
+		}
+	}
+}
+
 func (m *manager) handleObservation(observation model.Observation) {
 	if !observation.ID.Supported() {
 		return
@@ -297,6 +348,10 @@ func (m *manager) handleObservation(observation model.Observation) {
 	}
 }
 
+func (m *manager) handleCommandResponse(response model.CommandResponse) {
+	m.tracker.Resolve(response)
+}
+
 func (m *manager) ensureClientStarted() {
 	if m.client.Connected() {
 		return
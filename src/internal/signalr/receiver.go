@@ -1,10 +1,7 @@
 package signalr
 
 import (
-	"encoding/json"
-
 	"github.com/philippseith/signalr"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/futurehomeno/edge-easee-adapter/internal/model"
 )
@@ -12,12 +9,14 @@ import (
 type receiver struct {
 	signalr.Receiver
 
-	observations chan<- model.Observation
+	observations     chan<- model.Observation
+	commandResponses chan<- model.CommandResponse
 }
 
-func newReceiver(observations chan<- model.Observation) *receiver {
+func newReceiver(observations chan<- model.Observation, commandResponses chan<- model.CommandResponse) *receiver {
 	return &receiver{
-		observations: observations,
+		observations:     observations,
+		commandResponses: commandResponses,
 	}
 }
 
@@ -25,7 +24,6 @@ func (r *receiver) ProductUpdate(o model.Observation) {
 	r.observations <- o
 }
 
-func (r *receiver) CommandResponse(resp any) {
-	res, _ := json.MarshalIndent(resp, "", "\t")
-	log.Info("command response: ", string(res))
+func (r *receiver) CommandResponse(resp model.CommandResponse) {
+	r.commandResponses <- resp
 }
@@ -0,0 +1,142 @@
+package signalr
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/michalkurzeja/go-clock"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/futurehomeno/edge-easee-adapter/internal/api"
+	"github.com/futurehomeno/edge-easee-adapter/internal/config"
+	"github.com/futurehomeno/edge-easee-adapter/internal/db"
+	"github.com/futurehomeno/edge-easee-adapter/internal/model"
+)
+
+const (
+	// reconcilePageSize is a number of sessions fetched from the API at once.
+	reconcilePageSize = 10
+	// reconcileMaxPages limits the number of pages fetched during a single reconciliation.
+	reconcileMaxPages = 10
+)
+
+// SessionReconciler backfills charging sessions missed while SignalR connection was down.
+type SessionReconciler interface {
+	// Reconcile merges charging sessions missing in the storage for the provided charger.
+	// If no session is known locally, only sessions finished after the provided time of disconnection are merged,
+	// a zero time means there is nothing to reconcile. Sessions exceeding the retention policy are not merged.
+	Reconcile(ctx context.Context, chargerID string, disconnectedAt time.Time) error
+}
+
+type sessionReconciler struct {
+	client         api.Client
+	sessionStorage db.ChargingSessionStorage
+	cfgService     *config.Service
+}
+
+// NewSessionReconciler returns a new instance of SessionReconciler.
+func NewSessionReconciler(client api.Client, sessionStorage db.ChargingSessionStorage, cfgService *config.Service) SessionReconciler {
+	return &sessionReconciler{
+		client:         client,
+		sessionStorage: sessionStorage,
+		cfgService:     cfgService,
+	}
+}
+
+func (r *sessionReconciler) Reconcile(ctx context.Context, chargerID string, disconnectedAt time.Time) error {
+	stored, err := r.sessionStorage.LatestSessionsByChargerID(chargerID)
+	if err != nil {
+		return fmt.Errorf("failed to get latest charging sessions: %w", err)
+	}
+
+	// Sessions older than the latest finished one are assumed to be known already.
+	var knownID int64
+
+	// If no session is finished yet, sessions finished before the latest one started are not of interest.
+	since := disconnectedAt
+
+	for _, s := range stored {
+		if !s.Stop.IsZero() {
+			knownID = s.ID
+
+			break
+		}
+
+		since = s.Start
+	}
+
+	if knownID == 0 && since.IsZero() {
+		return nil
+	}
+
+	isOutdated := r.outdatedFunc(knownID, since)
+
+	var sessions db.ChargingSessions
+
+	for page := range reconcileMaxPages {
+		fetched, err := r.client.ChargerSessions(ctx, chargerID, reconcilePageSize, page*reconcilePageSize)
+		if err != nil {
+			return fmt.Errorf("failed to get charger sessions: %w", err)
+		}
+
+		done := len(fetched) < reconcilePageSize
+
+		for _, s := range fetched {
+			if s.ID <= knownID || isOutdated(s, len(sessions)) {
+				done = true
+
+				break
+			}
+
+			sessions = append(sessions, &db.ChargingSession{
+				ID:     s.ID,
+				Start:  s.CarConnected,
+				Stop:   s.CarDisconnected,
+				Energy: s.KiloWattHours,
+			})
+		}
+
+		if done {
+			break
+		}
+	}
+
+	merged, err := r.sessionStorage.MergeSessions(chargerID, sessions)
+	if err != nil {
+		return fmt.Errorf("failed to merge charging sessions: %w", err)
+	}
+
+	if merged > 0 {
+		log.WithField("charger_id", chargerID).
+			Infof("signalR: %d missed charging sessions reconciled", merged)
+	}
+
+	return nil
+}
+
+// outdatedFunc returns a function checking if the session fetched from the API, listed latest first, is too old to be reconciled.
+// Sessions exceeding the retention policy would be removed by the next retention run, so they are not reconciled at all.
+// The count is the number of sessions to be reconciled so far.
+func (r *sessionReconciler) outdatedFunc(knownID int64, since time.Time) func(s model.ChargerSession, count int) bool {
+	maxAge := r.cfgService.GetSessionRetentionMaxAge()
+	maxCount := r.cfgService.GetSessionRetentionMaxCount()
+	cutoff := clock.Now().Add(-maxAge)
+
+	return func(s model.ChargerSession, count int) bool {
+		if maxCount > 0 && count >= maxCount {
+			return true
+		}
+
+		// Sessions in progress are the latest ones and are always reconciled.
+		if s.CarDisconnected.IsZero() {
+			return false
+		}
+
+		if maxAge > 0 && s.CarDisconnected.Before(cutoff) {
+			return true
+		}
+
+		return knownID == 0 && s.CarDisconnected.Before(since)
+	}
+}
@@ -1,13 +1,22 @@
 package tasks
 
 import (
+	"time"
+
 	"github.com/futurehomeno/cliffhanger/adapter"
+	"github.com/futurehomeno/cliffhanger/adapter/service/chargepoint"
 	"github.com/futurehomeno/cliffhanger/adapter/thing"
 	"github.com/futurehomeno/cliffhanger/app"
 	"github.com/futurehomeno/cliffhanger/lifecycle"
 	"github.com/futurehomeno/cliffhanger/task"
+	"github.com/michalkurzeja/go-clock"
+	log "github.com/sirupsen/logrus"
 
+	"github.com/futurehomeno/edge-easee-adapter/internal/api"
 	"github.com/futurehomeno/edge-easee-adapter/internal/config"
+	"github.com/futurehomeno/edge-easee-adapter/internal/db"
+	"github.com/futurehomeno/edge-easee-adapter/internal/easee"
+	"github.com/futurehomeno/edge-easee-adapter/internal/sharing"
 )
 
 // New returns a set of background tasks of an application.
@@ -16,10 +25,149 @@ func New(
 	appLifecycle *lifecycle.Lifecycle,
 	application app.App,
 	ad adapter.Adapter,
+	sessionStorage db.ChargingSessionStorage,
+	allocator sharing.Allocator,
+	firmwareMonitor easee.FirmwareMonitor,
+	rateLimiter api.RateLimiter,
 ) []*task.Task {
 	return task.Combine[[]*task.Task](
 		app.TaskApp(application, appLifecycle),
 		adapter.TaskAdapter(ad, cfgSrv.GetPollingInterval()),
 		thing.TaskCarCharger(ad, cfgSrv.GetPollingInterval(), task.WhenAppIsConnected(appLifecycle)),
+		TaskSessionRetention(cfgSrv, sessionStorage, task.WhenAppIsRunning(appLifecycle)),
+		TaskChargingSchedule(ad, task.WhenAppIsConnected(appLifecycle)),
+		TaskDepartureCharging(ad, task.WhenAppIsConnected(appLifecycle)),
+		TaskPowerSharing(allocator, task.WhenAppIsConnected(appLifecycle)),
+		TaskFirmwareCheck(firmwareMonitor, task.WhenAppIsConnected(appLifecycle)),
+		TaskRateLimitStats(rateLimiter, task.WhenAppIsRunning(appLifecycle)),
 	)
 }
+
+const (
+	// chargingScheduleInterval is an interval of charging schedule execution, matching the resolution of schedule entries.
+	chargingScheduleInterval = time.Minute
+	// departureChargingInterval is an interval of departure charging adjustments.
+	departureChargingInterval = time.Minute
+	// powerSharingInterval is an interval of power sharing re-balancing, correcting allocations missed on state changes.
+	powerSharingInterval = time.Minute
+	// sessionRetentionCheckInterval is an interval of checking if the configured session retention interval has elapsed.
+	sessionRetentionCheckInterval = time.Minute
+	// firmwareCheckInterval is an interval of checking if a newer firmware is available for chargers.
+	firmwareCheckInterval = time.Hour
+	// rateLimitStatsInterval is an interval of logging throttling statistics of Easee API requests.
+	rateLimitStatsInterval = 15 * time.Minute
+)
+
+// TaskChargingSchedule returns a task starting and stopping charging according to charging schedules of all chargers.
+func TaskChargingSchedule(ad adapter.Adapter, voters ...task.Voter) []*task.Task {
+	return []*task.Task{
+		task.New(func() {
+			for _, s := range ad.Services(chargepoint.Chargepoint) {
+				service, ok := s.(easee.ChargepointService)
+				if !ok {
+					continue
+				}
+
+				if err := service.ExecuteSchedule(); err != nil {
+					log.WithError(err).
+						WithField("topic", service.Topic()).
+						Error("tasks: failed to execute charging schedule")
+				}
+			}
+		}, chargingScheduleInterval, voters...),
+	}
+}
+
+// TaskDepartureCharging returns a task adjusting the offered current of chargers charging in the departure mode.
+func TaskDepartureCharging(ad adapter.Adapter, voters ...task.Voter) []*task.Task {
+	return []*task.Task{
+		task.New(func() {
+			for _, s := range ad.Services(chargepoint.Chargepoint) {
+				service, ok := s.(easee.ChargepointService)
+				if !ok {
+					continue
+				}
+
+				if err := service.AdjustDepartureCharging(); err != nil {
+					log.WithError(err).
+						WithField("topic", service.Topic()).
+						Error("tasks: failed to adjust departure charging")
+				}
+			}
+		}, departureChargingInterval, voters...),
+	}
+}
+
+// TaskPowerSharing returns a task periodically re-balancing the current budget shared between chargers.
+func TaskPowerSharing(allocator sharing.Allocator, voters ...task.Voter) []*task.Task {
+	return []*task.Task{
+		task.New(func() {
+			if err := allocator.Rebalance(); err != nil {
+				log.WithError(err).Error("tasks: failed to re-balance power sharing")
+			}
+		}, powerSharingInterval, voters...),
+	}
+}
+
+// TaskFirmwareCheck returns a task periodically checking firmware versions of chargers.
+func TaskFirmwareCheck(firmwareMonitor easee.FirmwareMonitor, voters ...task.Voter) []*task.Task {
+	return []*task.Task{
+		task.New(func() {
+			if err := firmwareMonitor.Check(); err != nil {
+				log.WithError(err).Error("tasks: failed to check charger firmware")
+			}
+		}, firmwareCheckInterval, voters...),
+	}
+}
+
+// TaskRateLimitStats returns a task periodically logging throttling statistics of Easee API requests which changed since the last run.
+func TaskRateLimitStats(rateLimiter api.RateLimiter, voters ...task.Voter) []*task.Task {
+	var logged map[api.EndpointClass]api.RateLimitStats
+
+	return []*task.Task{
+		task.New(func() {
+			stats := rateLimiter.Stats()
+
+			for class, s := range stats {
+				if s == logged[class] {
+					continue
+				}
+
+				log.WithField("class", class).
+					WithField("requests", s.Requests).
+					WithField("throttled", s.Throttled).
+					WithField("coalesced", s.Coalesced).
+					WithField("wait_seconds", s.WaitSeconds).
+					Info("tasks: rate limit statistics")
+			}
+
+			logged = stats
+		}, rateLimitStatsInterval, voters...),
+	}
+}
+
+// TaskSessionRetention returns a task periodically removing charging sessions exceeding the configured retention policy.
+// The retention interval is read on each run, so its changes are applied without restarting the adapter.
+func TaskSessionRetention(cfgSrv *config.Service, sessionStorage db.ChargingSessionStorage, voters ...task.Voter) []*task.Task {
+	var appliedAt time.Time
+
+	return []*task.Task{
+		task.New(func() {
+			now := clock.Now()
+			if !appliedAt.IsZero() && now.Sub(appliedAt) < cfgSrv.GetSessionRetentionInterval() {
+				return
+			}
+
+			appliedAt = now
+
+			policy := db.RetentionPolicy{
+				MaxAge:   cfgSrv.GetSessionRetentionMaxAge(),
+				MaxCount: cfgSrv.GetSessionRetentionMaxCount(),
+			}
+
+			if err := sessionStorage.ApplyRetentionPolicy(policy); err != nil {
+				log.WithError(err).Error("tasks: failed to apply charging session retention policy")
+			}
+		}, sessionRetentionCheckInterval, voters...),
+	}
+}
@@ -3,34 +3,149 @@
 package mocks
 
 import (
-	api "github.com/futurehomeno/edge-easee-adapter/internal/model"
+	model "github.com/futurehomeno/edge-easee-adapter/internal/model"
+	mock "github.com/stretchr/testify/mock"
 
-	"github.com/stretchr/testify/mock"
+	context "context"
 )
 
-// APIClient is an autogenerated mock type for the APIClient type
+// APIClient is an autogenerated mock type for the Client type
 type APIClient struct {
 	mock.Mock
 }
 
-func (_m *APIClient) ChargerDetails(chargerID string) (api.ChargerDetails, error) {
-	ret := _m.Called(chargerID)
+// AuthorizeCharging provides a mock function with given fields: ctx, chargerID
+func (_m *APIClient) AuthorizeCharging(ctx context.Context, chargerID string) error {
+	ret := _m.Called(ctx, chargerID)
 
-	var r0 api.ChargerDetails
+	if len(ret) == 0 {
+		panic("no return value specified for AuthorizeCharging")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, chargerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CancelRequests provides a mock function with no fields
+func (_m *APIClient) CancelRequests() {
+	_m.Called()
+}
+
+// ChargerAccess provides a mock function with given fields: ctx, chargerID
+func (_m *APIClient) ChargerAccess(ctx context.Context, chargerID string) (model.AccessLevel, error) {
+	ret := _m.Called(ctx, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChargerAccess")
+	}
+
+	var r0 model.AccessLevel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.AccessLevel, error)); ok {
+		return rf(ctx, chargerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.AccessLevel); ok {
+		r0 = rf(ctx, chargerID)
+	} else {
+		r0 = ret.Get(0).(model.AccessLevel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, chargerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChargerConfig provides a mock function with given fields: ctx, chargerID
+func (_m *APIClient) ChargerConfig(ctx context.Context, chargerID string) (*model.ChargerConfig, error) {
+	ret := _m.Called(ctx, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChargerConfig")
+	}
+
+	var r0 *model.ChargerConfig
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.ChargerConfig, error)); ok {
+		return rf(ctx, chargerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.ChargerConfig); ok {
+		r0 = rf(ctx, chargerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChargerConfig)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, chargerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChargerDetails provides a mock function with given fields: ctx, chargerID
+func (_m *APIClient) ChargerDetails(ctx context.Context, chargerID string) (model.ChargerDetails, error) {
+	ret := _m.Called(ctx, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChargerDetails")
+	}
+
+	var r0 model.ChargerDetails
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.ChargerDetails, error)); ok {
+		return rf(ctx, chargerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.ChargerDetails); ok {
+		r0 = rf(ctx, chargerID)
+	} else {
+		r0 = ret.Get(0).(model.ChargerDetails)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, chargerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChargerFirmware provides a mock function with given fields: ctx, chargerID
+func (_m *APIClient) ChargerFirmware(ctx context.Context, chargerID string) (*model.Firmware, error) {
+	ret := _m.Called(ctx, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChargerFirmware")
+	}
+
+	var r0 *model.Firmware
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (api.ChargerDetails, error)); ok {
-		return rf(chargerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Firmware, error)); ok {
+		return rf(ctx, chargerID)
 	}
-	if rf, ok := ret.Get(0).(func(string) api.ChargerDetails); ok {
-		r0 = rf(chargerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Firmware); ok {
+		r0 = rf(ctx, chargerID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(api.ChargerDetails)
+			r0 = ret.Get(0).(*model.Firmware)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(chargerID)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, chargerID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -38,25 +153,29 @@ func (_m *APIClient) ChargerDetails(chargerID string) (api.ChargerDetails, error
 	return r0, r1
 }
 
-// ChargerConfig provides a mock function with given fields: chargerID
-func (_m *APIClient) ChargerConfig(chargerID string) (*api.ChargerConfig, error) {
-	ret := _m.Called(chargerID)
+// ChargerSessions provides a mock function with given fields: ctx, chargerID, limit, offset
+func (_m *APIClient) ChargerSessions(ctx context.Context, chargerID string, limit int, offset int) ([]model.ChargerSession, error) {
+	ret := _m.Called(ctx, chargerID, limit, offset)
 
-	var r0 *api.ChargerConfig
+	if len(ret) == 0 {
+		panic("no return value specified for ChargerSessions")
+	}
+
+	var r0 []model.ChargerSession
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*api.ChargerConfig, error)); ok {
-		return rf(chargerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]model.ChargerSession, error)); ok {
+		return rf(ctx, chargerID, limit, offset)
 	}
-	if rf, ok := ret.Get(0).(func(string) *api.ChargerConfig); ok {
-		r0 = rf(chargerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []model.ChargerSession); ok {
+		r0 = rf(ctx, chargerID, limit, offset)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*api.ChargerConfig)
+			r0 = ret.Get(0).([]model.ChargerSession)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(chargerID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, chargerID, limit, offset)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -64,25 +183,29 @@ func (_m *APIClient) ChargerConfig(chargerID string) (*api.ChargerConfig, error)
 	return r0, r1
 }
 
-// ChargerSiteInfo provides a mock function with given fields: chargerID
-func (_m *APIClient) ChargerSiteInfo(chargerID string) (*api.ChargerSiteInfo, error) {
-	ret := _m.Called(chargerID)
+// ChargerSiteInfo provides a mock function with given fields: ctx, chargerID
+func (_m *APIClient) ChargerSiteInfo(ctx context.Context, chargerID string) (*model.ChargerSiteInfo, error) {
+	ret := _m.Called(ctx, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChargerSiteInfo")
+	}
 
-	var r0 *api.ChargerSiteInfo
+	var r0 *model.ChargerSiteInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*api.ChargerSiteInfo, error)); ok {
-		return rf(chargerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.ChargerSiteInfo, error)); ok {
+		return rf(ctx, chargerID)
 	}
-	if rf, ok := ret.Get(0).(func(string) *api.ChargerSiteInfo); ok {
-		r0 = rf(chargerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.ChargerSiteInfo); ok {
+		r0 = rf(ctx, chargerID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*api.ChargerSiteInfo)
+			r0 = ret.Get(0).(*model.ChargerSiteInfo)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(chargerID)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, chargerID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -90,25 +213,29 @@ func (_m *APIClient) ChargerSiteInfo(chargerID string) (*api.ChargerSiteInfo, er
 	return r0, r1
 }
 
-// Chargers provides a mock function with given fields:
-func (_m *APIClient) Chargers() ([]api.Charger, error) {
-	ret := _m.Called()
+// Chargers provides a mock function with given fields: ctx
+func (_m *APIClient) Chargers(ctx context.Context) ([]model.Charger, error) {
+	ret := _m.Called(ctx)
 
-	var r0 []api.Charger
+	if len(ret) == 0 {
+		panic("no return value specified for Chargers")
+	}
+
+	var r0 []model.Charger
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]api.Charger, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.Charger, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []api.Charger); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Charger); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]api.Charger)
+			r0 = ret.Get(0).([]model.Charger)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -116,13 +243,47 @@ func (_m *APIClient) Chargers() ([]api.Charger, error) {
 	return r0, r1
 }
 
-// Ping provides a mock function with given fields:
-func (_m *APIClient) Ping() error {
-	ret := _m.Called()
+// CircuitDynamicCurrent provides a mock function with given fields: ctx, siteID, circuitID
+func (_m *APIClient) CircuitDynamicCurrent(ctx context.Context, siteID int64, circuitID int64) (*model.CircuitCurrent, error) {
+	ret := _m.Called(ctx, siteID, circuitID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CircuitDynamicCurrent")
+	}
+
+	var r0 *model.CircuitCurrent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (*model.CircuitCurrent, error)); ok {
+		return rf(ctx, siteID, circuitID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *model.CircuitCurrent); ok {
+		r0 = rf(ctx, siteID, circuitID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CircuitCurrent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, siteID, circuitID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeauthorizeCharging provides a mock function with given fields: ctx, chargerID
+func (_m *APIClient) DeauthorizeCharging(ctx context.Context, chargerID string) error {
+	ret := _m.Called(ctx, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeauthorizeCharging")
+	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, chargerID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -130,13 +291,77 @@ func (_m *APIClient) Ping() error {
 	return r0
 }
 
-// SetCableAlwaysLocked provides a mock function with given fields: chargerID, locked
-func (_m *APIClient) SetCableAlwaysLocked(chargerID string, locked bool) error {
-	ret := _m.Called(chargerID, locked)
+// Equalizers provides a mock function with given fields: ctx
+func (_m *APIClient) Equalizers(ctx context.Context) ([]model.Equalizer, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Equalizers")
+	}
+
+	var r0 []model.Equalizer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.Equalizer, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Equalizer); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Equalizer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OfflineMaxCurrent provides a mock function with given fields: ctx, chargerID
+func (_m *APIClient) OfflineMaxCurrent(ctx context.Context, chargerID string) (*model.OfflineCurrent, error) {
+	ret := _m.Called(ctx, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OfflineMaxCurrent")
+	}
+
+	var r0 *model.OfflineCurrent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.OfflineCurrent, error)); ok {
+		return rf(ctx, chargerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.OfflineCurrent); ok {
+		r0 = rf(ctx, chargerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OfflineCurrent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, chargerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Ping provides a mock function with given fields: ctx
+func (_m *APIClient) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
-		r0 = rf(chargerID, locked)
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -144,13 +369,17 @@ func (_m *APIClient) SetCableAlwaysLocked(chargerID string, locked bool) error {
 	return r0
 }
 
-// StartCharging provides a mock function with given fields: chargerID
-func (_m *APIClient) StartCharging(chargerID string) error {
-	ret := _m.Called(chargerID)
+// RebootCharger provides a mock function with given fields: ctx, chargerID
+func (_m *APIClient) RebootCharger(ctx context.Context, chargerID string) error {
+	ret := _m.Called(ctx, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RebootCharger")
+	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(chargerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, chargerID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -158,13 +387,17 @@ func (_m *APIClient) StartCharging(chargerID string) error {
 	return r0
 }
 
-// StopCharging provides a mock function with given fields: chargerID
-func (_m *APIClient) StopCharging(chargerID string) error {
-	ret := _m.Called(chargerID)
+// SetAuthorizationRequired provides a mock function with given fields: ctx, chargerID, required
+func (_m *APIClient) SetAuthorizationRequired(ctx context.Context, chargerID string, required bool) error {
+	ret := _m.Called(ctx, chargerID, required)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAuthorizationRequired")
+	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(chargerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, chargerID, required)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -172,13 +405,17 @@ func (_m *APIClient) StopCharging(chargerID string) error {
 	return r0
 }
 
-// UpdateDynamicCurrent provides a mock function with given fields: chargerID, current
-func (_m *APIClient) UpdateDynamicCurrent(chargerID string, current float64) error {
-	ret := _m.Called(chargerID, current)
+// SetCableAlwaysLocked provides a mock function with given fields: ctx, chargerID, locked
+func (_m *APIClient) SetCableAlwaysLocked(ctx context.Context, chargerID string, locked bool) error {
+	ret := _m.Called(ctx, chargerID, locked)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCableAlwaysLocked")
+	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, float64) error); ok {
-		r0 = rf(chargerID, current)
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, chargerID, locked)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -186,13 +423,161 @@ func (_m *APIClient) UpdateDynamicCurrent(chargerID string, current float64) err
 	return r0
 }
 
-// UpdateMaxCurrent provides a mock function with given fields: chargerID, current
-func (_m *APIClient) UpdateMaxCurrent(chargerID string, current float64) error {
-	ret := _m.Called(chargerID, current)
+// SetChargerAccess provides a mock function with given fields: ctx, chargerID, level
+func (_m *APIClient) SetChargerAccess(ctx context.Context, chargerID string, level model.AccessLevel) error {
+	ret := _m.Called(ctx, chargerID, level)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetChargerAccess")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.AccessLevel) error); ok {
+		r0 = rf(ctx, chargerID, level)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StopCharging provides a mock function with given fields: ctx, chargerID
+func (_m *APIClient) StopCharging(ctx context.Context, chargerID string) error {
+	ret := _m.Called(ctx, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopCharging")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, chargerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateChargerSettings provides a mock function with given fields: ctx, chargerID, settings
+func (_m *APIClient) UpdateChargerSettings(ctx context.Context, chargerID string, settings model.ChargerSettings) error {
+	ret := _m.Called(ctx, chargerID, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateChargerSettings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.ChargerSettings) error); ok {
+		r0 = rf(ctx, chargerID, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCircuitDynamicCurrent provides a mock function with given fields: ctx, siteID, circuitID, current
+func (_m *APIClient) UpdateCircuitDynamicCurrent(ctx context.Context, siteID int64, circuitID int64, current model.CircuitCurrent) error {
+	ret := _m.Called(ctx, siteID, circuitID, current)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCircuitDynamicCurrent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, model.CircuitCurrent) error); ok {
+		r0 = rf(ctx, siteID, circuitID, current)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDynamicCurrent provides a mock function with given fields: ctx, chargerID, current
+func (_m *APIClient) UpdateDynamicCurrent(ctx context.Context, chargerID string, current float64) error {
+	ret := _m.Called(ctx, chargerID, current)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDynamicCurrent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64) error); ok {
+		r0 = rf(ctx, chargerID, current)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateFirmware provides a mock function with given fields: ctx, chargerID
+func (_m *APIClient) UpdateFirmware(ctx context.Context, chargerID string) error {
+	ret := _m.Called(ctx, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFirmware")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, chargerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateMaxCurrent provides a mock function with given fields: ctx, chargerID, current
+func (_m *APIClient) UpdateMaxCurrent(ctx context.Context, chargerID string, current float64) error {
+	ret := _m.Called(ctx, chargerID, current)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMaxCurrent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64) error); ok {
+		r0 = rf(ctx, chargerID, current)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateOfflineMaxCurrent provides a mock function with given fields: ctx, chargerID, current
+func (_m *APIClient) UpdateOfflineMaxCurrent(ctx context.Context, chargerID string, current model.OfflineCurrent) error {
+	ret := _m.Called(ctx, chargerID, current)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOfflineMaxCurrent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.OfflineCurrent) error); ok {
+		r0 = rf(ctx, chargerID, current)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePhaseMode provides a mock function with given fields: ctx, chargerID, phaseMode
+func (_m *APIClient) UpdatePhaseMode(ctx context.Context, chargerID string, phaseMode int) error {
+	ret := _m.Called(ctx, chargerID, phaseMode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePhaseMode")
+	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, float64) error); ok {
-		r0 = rf(chargerID, current)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, chargerID, phaseMode)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2,16 +2,20 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	context "context"
+)
 
 // Authenticator is an autogenerated mock type for the Authenticator type
 type Authenticator struct {
 	mock.Mock
 }
 
-// AccessToken provides a mock function with no fields
-func (_m *Authenticator) AccessToken() (string, error) {
-	ret := _m.Called()
+// AccessToken provides a mock function with given fields: ctx
+func (_m *Authenticator) AccessToken(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AccessToken")
@@ -19,17 +23,17 @@ func (_m *Authenticator) AccessToken() (string, error) {
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (string, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() string); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -37,17 +41,17 @@ func (_m *Authenticator) AccessToken() (string, error) {
 	return r0, r1
 }
 
-// Login provides a mock function with given fields: userName, password
-func (_m *Authenticator) Login(userName string, password string) error {
-	ret := _m.Called(userName, password)
+// Login provides a mock function with given fields: ctx, userName, password
+func (_m *Authenticator) Login(ctx context.Context, userName string, password string) error {
+	ret := _m.Called(ctx, userName, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(userName, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userName, password)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -16,8 +16,36 @@ type Cache struct {
 	mock.Mock
 }
 
+// AuthorizationRequired provides a mock function with no fields
+func (_m *Cache) AuthorizationRequired() (bool, time.Time) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthorizationRequired")
+	}
+
+	var r0 bool
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (bool, time.Time)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
+}
+
 // CableAlwaysLocked provides a mock function with no fields
-func (_m *Cache) CableAlwaysLocked() bool {
+func (_m *Cache) CableAlwaysLocked() (bool, time.Time) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
@@ -25,17 +53,27 @@ func (_m *Cache) CableAlwaysLocked() bool {
 	}
 
 	var r0 bool
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (bool, time.Time)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() bool); ok {
 		r0 = rf()
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
 }
 
 // CableCurrent provides a mock function with no fields
-func (_m *Cache) CableCurrent() *int64 {
+func (_m *Cache) CableCurrent() (*int64, time.Time) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
@@ -43,6 +81,10 @@ func (_m *Cache) CableCurrent() *int64 {
 	}
 
 	var r0 *int64
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (*int64, time.Time)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() *int64); ok {
 		r0 = rf()
 	} else {
@@ -51,11 +93,17 @@ func (_m *Cache) CableCurrent() *int64 {
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
 }
 
 // CableLocked provides a mock function with no fields
-func (_m *Cache) CableLocked() bool {
+func (_m *Cache) CableLocked() (bool, time.Time) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
@@ -63,17 +111,27 @@ func (_m *Cache) CableLocked() bool {
 	}
 
 	var r0 bool
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (bool, time.Time)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() bool); ok {
 		r0 = rf()
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
 }
 
 // ChargerState provides a mock function with no fields
-func (_m *Cache) ChargerState() chargepoint.State {
+func (_m *Cache) ChargerState() (chargepoint.State, time.Time) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
@@ -81,17 +139,101 @@ func (_m *Cache) ChargerState() chargepoint.State {
 	}
 
 	var r0 chargepoint.State
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (chargepoint.State, time.Time)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() chargepoint.State); ok {
 		r0 = rf()
 	} else {
 		r0 = ret.Get(0).(chargepoint.State)
 	}
 
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
+}
+
+// CompleteMaintenance provides a mock function with given fields: online, timestamp
+func (_m *Cache) CompleteMaintenance(online bool, timestamp time.Time) (model.MaintenanceCommand, bool) {
+	ret := _m.Called(online, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteMaintenance")
+	}
+
+	var r0 model.MaintenanceCommand
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(bool, time.Time) (model.MaintenanceCommand, bool)); ok {
+		return rf(online, timestamp)
+	}
+	if rf, ok := ret.Get(0).(func(bool, time.Time) model.MaintenanceCommand); ok {
+		r0 = rf(online, timestamp)
+	} else {
+		r0 = ret.Get(0).(model.MaintenanceCommand)
+	}
+
+	if rf, ok := ret.Get(1).(func(bool, time.Time) bool); ok {
+		r1 = rf(online, timestamp)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// Diagnostics provides a mock function with no fields
+func (_m *Cache) Diagnostics() model.Diagnostics {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Diagnostics")
+	}
+
+	var r0 model.Diagnostics
+	if rf, ok := ret.Get(0).(func() model.Diagnostics); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(model.Diagnostics)
+	}
+
 	return r0
 }
 
+// EnableIdleCurrent provides a mock function with no fields
+func (_m *Cache) EnableIdleCurrent() (bool, time.Time) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableIdleCurrent")
+	}
+
+	var r0 bool
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (bool, time.Time)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
+}
+
 // EnergySession provides a mock function with no fields
-func (_m *Cache) EnergySession() float64 {
+func (_m *Cache) EnergySession() (float64, time.Time) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
@@ -99,17 +241,55 @@ func (_m *Cache) EnergySession() float64 {
 	}
 
 	var r0 float64
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (float64, time.Time)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() float64); ok {
 		r0 = rf()
 	} else {
 		r0 = ret.Get(0).(float64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
+}
+
+// FirmwareVersion provides a mock function with no fields
+func (_m *Cache) FirmwareVersion() (int, time.Time) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirmwareVersion")
+	}
+
+	var r0 int
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (int, time.Time)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
 }
 
 // GridType provides a mock function with no fields
-func (_m *Cache) GridType() chargepoint.GridType {
+func (_m *Cache) GridType() (chargepoint.GridType, time.Time) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
@@ -117,35 +297,83 @@ func (_m *Cache) GridType() chargepoint.GridType {
 	}
 
 	var r0 chargepoint.GridType
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (chargepoint.GridType, time.Time)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() chargepoint.GridType); ok {
 		r0 = rf()
 	} else {
 		r0 = ret.Get(0).(chargepoint.GridType)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
+}
+
+// LEDStripBrightness provides a mock function with no fields
+func (_m *Cache) LEDStripBrightness() (int, time.Time) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LEDStripBrightness")
+	}
+
+	var r0 int
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (int, time.Time)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
 }
 
 // LifetimeEnergy provides a mock function with no fields
-func (_m *Cache) LifetimeEnergy() model.TimestampedValue[float64] {
+func (_m *Cache) LifetimeEnergy() (float64, time.Time) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
 		panic("no return value specified for LifetimeEnergy")
 	}
 
-	var r0 model.TimestampedValue[float64]
-	if rf, ok := ret.Get(0).(func() model.TimestampedValue[float64]); ok {
+	var r0 float64
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (float64, time.Time)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() float64); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(model.TimestampedValue[float64])
+		r0 = ret.Get(0).(float64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
 }
 
 // MaxCurrent provides a mock function with no fields
-func (_m *Cache) MaxCurrent() int64 {
+func (_m *Cache) MaxCurrent() (int64, time.Time) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
@@ -153,17 +381,27 @@ func (_m *Cache) MaxCurrent() int64 {
 	}
 
 	var r0 int64
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (int64, time.Time)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() int64); ok {
 		r0 = rf()
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
 }
 
 // OfferedCurrent provides a mock function with no fields
-func (_m *Cache) OfferedCurrent() int64 {
+func (_m *Cache) OfferedCurrent() (int64, time.Time) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
@@ -171,17 +409,55 @@ func (_m *Cache) OfferedCurrent() int64 {
 	}
 
 	var r0 int64
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (int64, time.Time)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() int64); ok {
 		r0 = rf()
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
+}
+
+// OfflineMaxCurrent provides a mock function with no fields
+func (_m *Cache) OfflineMaxCurrent() (model.OfflineCurrent, bool) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for OfflineMaxCurrent")
+	}
+
+	var r0 model.OfflineCurrent
+	var r1 bool
+	if rf, ok := ret.Get(0).(func() (model.OfflineCurrent, bool)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() model.OfflineCurrent); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(model.OfflineCurrent)
+	}
+
+	if rf, ok := ret.Get(1).(func() bool); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
 }
 
 // OutputPhaseType provides a mock function with no fields
-func (_m *Cache) OutputPhaseType() chargepoint.PhaseMode {
+func (_m *Cache) OutputPhaseType() (chargepoint.PhaseMode, time.Time) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
@@ -189,17 +465,27 @@ func (_m *Cache) OutputPhaseType() chargepoint.PhaseMode {
 	}
 
 	var r0 chargepoint.PhaseMode
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (chargepoint.PhaseMode, time.Time)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() chargepoint.PhaseMode); ok {
 		r0 = rf()
 	} else {
 		r0 = ret.Get(0).(chargepoint.PhaseMode)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
 }
 
 // Phase1Current provides a mock function with no fields
-func (_m *Cache) Phase1Current() float64 {
+func (_m *Cache) Phase1Current() (float64, time.Time) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
@@ -207,17 +493,27 @@ func (_m *Cache) Phase1Current() float64 {
 	}
 
 	var r0 float64
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (float64, time.Time)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() float64); ok {
 		r0 = rf()
 	} else {
 		r0 = ret.Get(0).(float64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
 }
 
 // Phase2Current provides a mock function with no fields
-func (_m *Cache) Phase2Current() float64 {
+func (_m *Cache) Phase2Current() (float64, time.Time) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
@@ -225,17 +521,27 @@ func (_m *Cache) Phase2Current() float64 {
 	}
 
 	var r0 float64
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (float64, time.Time)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() float64); ok {
 		r0 = rf()
 	} else {
 		r0 = ret.Get(0).(float64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
 }
 
 // Phase3Current provides a mock function with no fields
-func (_m *Cache) Phase3Current() float64 {
+func (_m *Cache) Phase3Current() (float64, time.Time) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
@@ -243,17 +549,27 @@ func (_m *Cache) Phase3Current() float64 {
 	}
 
 	var r0 float64
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (float64, time.Time)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() float64); ok {
 		r0 = rf()
 	} else {
 		r0 = ret.Get(0).(float64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
 }
 
 // PhaseMode provides a mock function with no fields
-func (_m *Cache) PhaseMode() int {
+func (_m *Cache) PhaseMode() (int, time.Time) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
@@ -261,17 +577,27 @@ func (_m *Cache) PhaseMode() int {
 	}
 
 	var r0 int
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (int, time.Time)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() int); ok {
 		r0 = rf()
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
 }
 
 // Phases provides a mock function with no fields
-func (_m *Cache) Phases() int {
+func (_m *Cache) Phases() (int, time.Time) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
@@ -279,147 +605,128 @@ func (_m *Cache) Phases() int {
 	}
 
 	var r0 int
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (int, time.Time)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() int); ok {
 		r0 = rf()
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
 }
 
-// RequestedOfferedCurrent provides a mock function with no fields
-func (_m *Cache) RequestedOfferedCurrent() int64 {
+// ReasonForNoCurrent provides a mock function with no fields
+func (_m *Cache) ReasonForNoCurrent() (model.NoCurrentReason, time.Time) {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for RequestedOfferedCurrent")
+		panic("no return value specified for ReasonForNoCurrent")
 	}
 
-	var r0 int64
-	if rf, ok := ret.Get(0).(func() int64); ok {
+	var r0 model.NoCurrentReason
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (model.NoCurrentReason, time.Time)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() model.NoCurrentReason); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(model.NoCurrentReason)
 	}
 
-	return r0
-}
-
-// SetCableAlwaysLocked provides a mock function with given fields: val
-func (_m *Cache) SetCableAlwaysLocked(val bool) {
-	_m.Called(val)
-}
-
-// SetCableCurrent provides a mock function with given fields: current
-func (_m *Cache) SetCableCurrent(current int64) {
-	_m.Called(current)
-}
-
-// SetCableLocked provides a mock function with given fields: locked
-func (_m *Cache) SetCableLocked(locked bool) {
-	_m.Called(locked)
-}
-
-// SetChargerState provides a mock function with given fields: state
-func (_m *Cache) SetChargerState(state chargepoint.State) {
-	_m.Called(state)
-}
-
-// SetEnergySession provides a mock function with given fields: energy
-func (_m *Cache) SetEnergySession(energy float64) {
-	_m.Called(energy)
-}
-
-// SetGridType provides a mock function with given fields: gridType
-func (_m *Cache) SetGridType(gridType chargepoint.GridType) {
-	_m.Called(gridType)
-}
-
-// SetLifetimeEnergy provides a mock function with given fields: energy
-func (_m *Cache) SetLifetimeEnergy(energy model.TimestampedValue[float64]) {
-	_m.Called(energy)
-}
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
 
-// SetMaxCurrent provides a mock function with given fields: current
-func (_m *Cache) SetMaxCurrent(current int64) {
-	_m.Called(current)
+	return r0, r1
 }
 
-// SetOfferedCurrent provides a mock function with given fields: current
-func (_m *Cache) SetOfferedCurrent(current int64) {
-	_m.Called(current)
-}
+// RequestedOfferedCurrent provides a mock function with no fields
+func (_m *Cache) RequestedOfferedCurrent() (int64, time.Time) {
+	ret := _m.Called()
 
-// SetOutputPhaseType provides a mock function with given fields: mode
-func (_m *Cache) SetOutputPhaseType(mode chargepoint.PhaseMode) {
-	_m.Called(mode)
-}
+	if len(ret) == 0 {
+		panic("no return value specified for RequestedOfferedCurrent")
+	}
 
-// SetPhase1Current provides a mock function with given fields: current
-func (_m *Cache) SetPhase1Current(current float64) {
-	_m.Called(current)
-}
+	var r0 int64
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (int64, time.Time)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
 
-// SetPhase2Current provides a mock function with given fields: current
-func (_m *Cache) SetPhase2Current(current float64) {
-	_m.Called(current)
-}
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
 
-// SetPhase3Current provides a mock function with given fields: current
-func (_m *Cache) SetPhase3Current(current float64) {
-	_m.Called(current)
+	return r0, r1
 }
 
-// SetPhaseMode provides a mock function with given fields: phaseMode
-func (_m *Cache) SetPhaseMode(phaseMode int) {
-	_m.Called(phaseMode)
-}
+// SetAuthorizationRequired provides a mock function with given fields: required, timestamp
+func (_m *Cache) SetAuthorizationRequired(required bool, timestamp time.Time) bool {
+	ret := _m.Called(required, timestamp)
 
-// SetPhases provides a mock function with given fields: phases
-func (_m *Cache) SetPhases(phases int) {
-	_m.Called(phases)
-}
+	if len(ret) == 0 {
+		panic("no return value specified for SetAuthorizationRequired")
+	}
 
-// SetRequestedOfferedCurrent provides a mock function with given fields: current
-func (_m *Cache) SetRequestedOfferedCurrent(current int64) {
-	_m.Called(current)
-}
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(bool, time.Time) bool); ok {
+		r0 = rf(required, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
 
-// SetTotalPower provides a mock function with given fields: power
-func (_m *Cache) SetTotalPower(power float64) {
-	_m.Called(power)
+	return r0
 }
 
-// TotalPower provides a mock function with no fields
-func (_m *Cache) TotalPower() float64 {
-	ret := _m.Called()
+// SetCableAlwaysLocked provides a mock function with given fields: alwaysLocked, timestamp
+func (_m *Cache) SetCableAlwaysLocked(alwaysLocked bool, timestamp time.Time) bool {
+	ret := _m.Called(alwaysLocked, timestamp)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TotalPower")
+		panic("no return value specified for SetCableAlwaysLocked")
 	}
 
-	var r0 float64
-	if rf, ok := ret.Get(0).(func() float64); ok {
-		r0 = rf()
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(bool, time.Time) bool); ok {
+		r0 = rf(alwaysLocked, timestamp)
 	} else {
-		r0 = ret.Get(0).(float64)
+		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
 }
 
-// WaitForMaxCurrent provides a mock function with given fields: current, duration
-func (_m *Cache) WaitForMaxCurrent(current int64, duration time.Duration) bool {
-	ret := _m.Called(current, duration)
+// SetCableCurrent provides a mock function with given fields: current, timestamp
+func (_m *Cache) SetCableCurrent(current *int64, timestamp time.Time) bool {
+	ret := _m.Called(current, timestamp)
 
 	if len(ret) == 0 {
-		panic("no return value specified for WaitForMaxCurrent")
+		panic("no return value specified for SetCableCurrent")
 	}
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(int64, time.Duration) bool); ok {
-		r0 = rf(current, duration)
+	if rf, ok := ret.Get(0).(func(*int64, time.Time) bool); ok {
+		r0 = rf(current, timestamp)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
@@ -427,7 +734,500 @@ func (_m *Cache) WaitForMaxCurrent(current int64, duration time.Duration) bool {
 	return r0
 }
 
-// WaitForOfferedCurrent provides a mock function with given fields: current, duration
+// SetCableLocked provides a mock function with given fields: locked, timestamp
+func (_m *Cache) SetCableLocked(locked bool, timestamp time.Time) bool {
+	ret := _m.Called(locked, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCableLocked")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(bool, time.Time) bool); ok {
+		r0 = rf(locked, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetChargerState provides a mock function with given fields: state, timestamp
+func (_m *Cache) SetChargerState(state chargepoint.State, timestamp time.Time) bool {
+	ret := _m.Called(state, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetChargerState")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(chargepoint.State, time.Time) bool); ok {
+		r0 = rf(state, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetDetectedGridType provides a mock function with given fields: gridType, timestamp
+func (_m *Cache) SetDetectedGridType(gridType model.GridType, timestamp time.Time) bool {
+	ret := _m.Called(gridType, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDetectedGridType")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(model.GridType, time.Time) bool); ok {
+		r0 = rf(gridType, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetEnableIdleCurrent provides a mock function with given fields: enabled, timestamp
+func (_m *Cache) SetEnableIdleCurrent(enabled bool, timestamp time.Time) bool {
+	ret := _m.Called(enabled, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEnableIdleCurrent")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(bool, time.Time) bool); ok {
+		r0 = rf(enabled, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetEnergySession provides a mock function with given fields: energy, timestamp
+func (_m *Cache) SetEnergySession(energy float64, timestamp time.Time) bool {
+	ret := _m.Called(energy, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEnergySession")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(float64, time.Time) bool); ok {
+		r0 = rf(energy, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetErrorCode provides a mock function with given fields: code, timestamp
+func (_m *Cache) SetErrorCode(code int, timestamp time.Time) bool {
+	ret := _m.Called(code, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetErrorCode")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int, time.Time) bool); ok {
+		r0 = rf(code, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetFirmwareVersion provides a mock function with given fields: version, timestamp
+func (_m *Cache) SetFirmwareVersion(version int, timestamp time.Time) bool {
+	ret := _m.Called(version, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFirmwareVersion")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int, time.Time) bool); ok {
+		r0 = rf(version, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetInstallationParameters provides a mock function with given fields: gridType, phases, timestamp
+func (_m *Cache) SetInstallationParameters(gridType chargepoint.GridType, phases int, timestamp time.Time) bool {
+	ret := _m.Called(gridType, phases, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetInstallationParameters")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(chargepoint.GridType, int, time.Time) bool); ok {
+		r0 = rf(gridType, phases, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetLEDStripBrightness provides a mock function with given fields: brightness, timestamp
+func (_m *Cache) SetLEDStripBrightness(brightness int, timestamp time.Time) bool {
+	ret := _m.Called(brightness, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLEDStripBrightness")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int, time.Time) bool); ok {
+		r0 = rf(brightness, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetLifetimeEnergy provides a mock function with given fields: energy, timestamp
+func (_m *Cache) SetLifetimeEnergy(energy float64, timestamp time.Time) bool {
+	ret := _m.Called(energy, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLifetimeEnergy")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(float64, time.Time) bool); ok {
+		r0 = rf(energy, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetMaxCurrent provides a mock function with given fields: current, timestamp
+func (_m *Cache) SetMaxCurrent(current int64, timestamp time.Time) bool {
+	ret := _m.Called(current, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMaxCurrent")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int64, time.Time) bool); ok {
+		r0 = rf(current, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetOfferedCurrent provides a mock function with given fields: current, timestamp
+func (_m *Cache) SetOfferedCurrent(current int64, timestamp time.Time) bool {
+	ret := _m.Called(current, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOfferedCurrent")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int64, time.Time) bool); ok {
+		r0 = rf(current, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetOfflineMaxCurrent provides a mock function with given fields: current, timestamp
+func (_m *Cache) SetOfflineMaxCurrent(current model.OfflineCurrent, timestamp time.Time) bool {
+	ret := _m.Called(current, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOfflineMaxCurrent")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(model.OfflineCurrent, time.Time) bool); ok {
+		r0 = rf(current, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetOfflineMaxCurrentPhase provides a mock function with given fields: phase, current, timestamp
+func (_m *Cache) SetOfflineMaxCurrentPhase(phase int, current int, timestamp time.Time) bool {
+	ret := _m.Called(phase, current, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOfflineMaxCurrentPhase")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int, int, time.Time) bool); ok {
+		r0 = rf(phase, current, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetOutputPhaseType provides a mock function with given fields: mode, timestamp
+func (_m *Cache) SetOutputPhaseType(mode chargepoint.PhaseMode, timestamp time.Time) bool {
+	ret := _m.Called(mode, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOutputPhaseType")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(chargepoint.PhaseMode, time.Time) bool); ok {
+		r0 = rf(mode, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetPendingMaintenance provides a mock function with given fields: command, issuedAt
+func (_m *Cache) SetPendingMaintenance(command model.MaintenanceCommand, issuedAt time.Time) {
+	_m.Called(command, issuedAt)
+}
+
+// SetPhase1Current provides a mock function with given fields: current, timestamp
+func (_m *Cache) SetPhase1Current(current float64, timestamp time.Time) bool {
+	ret := _m.Called(current, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPhase1Current")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(float64, time.Time) bool); ok {
+		r0 = rf(current, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetPhase2Current provides a mock function with given fields: current, timestamp
+func (_m *Cache) SetPhase2Current(current float64, timestamp time.Time) bool {
+	ret := _m.Called(current, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPhase2Current")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(float64, time.Time) bool); ok {
+		r0 = rf(current, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetPhase3Current provides a mock function with given fields: current, timestamp
+func (_m *Cache) SetPhase3Current(current float64, timestamp time.Time) bool {
+	ret := _m.Called(current, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPhase3Current")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(float64, time.Time) bool); ok {
+		r0 = rf(current, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetPhaseMode provides a mock function with given fields: mode, timestamp
+func (_m *Cache) SetPhaseMode(mode int, timestamp time.Time) bool {
+	ret := _m.Called(mode, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPhaseMode")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int, time.Time) bool); ok {
+		r0 = rf(mode, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetReasonForNoCurrent provides a mock function with given fields: reason, timestamp
+func (_m *Cache) SetReasonForNoCurrent(reason model.NoCurrentReason, timestamp time.Time) bool {
+	ret := _m.Called(reason, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetReasonForNoCurrent")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(model.NoCurrentReason, time.Time) bool); ok {
+		r0 = rf(reason, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetRequestedOfferedCurrent provides a mock function with given fields: current, timestamp
+func (_m *Cache) SetRequestedOfferedCurrent(current int64, timestamp time.Time) bool {
+	ret := _m.Called(current, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRequestedOfferedCurrent")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int64, time.Time) bool); ok {
+		r0 = rf(current, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetSmartButtonEnabled provides a mock function with given fields: enabled, timestamp
+func (_m *Cache) SetSmartButtonEnabled(enabled bool, timestamp time.Time) bool {
+	ret := _m.Called(enabled, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSmartButtonEnabled")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(bool, time.Time) bool); ok {
+		r0 = rf(enabled, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SetTotalPower provides a mock function with given fields: power, timestamp
+func (_m *Cache) SetTotalPower(power float64, timestamp time.Time) bool {
+	ret := _m.Called(power, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTotalPower")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(float64, time.Time) bool); ok {
+		r0 = rf(power, timestamp)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SmartButtonEnabled provides a mock function with no fields
+func (_m *Cache) SmartButtonEnabled() (bool, time.Time) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SmartButtonEnabled")
+	}
+
+	var r0 bool
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (bool, time.Time)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
+}
+
+// TotalPower provides a mock function with no fields
+func (_m *Cache) TotalPower() (float64, time.Time) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TotalPower")
+	}
+
+	var r0 float64
+	var r1 time.Time
+	if rf, ok := ret.Get(0).(func() (float64, time.Time)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() float64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func() time.Time); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	return r0, r1
+}
+
+// WaitForMaxCurrent provides a mock function with given fields: current, duration
+func (_m *Cache) WaitForMaxCurrent(current int64, duration time.Duration) bool {
+	ret := _m.Called(current, duration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WaitForMaxCurrent")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int64, time.Duration) bool); ok {
+		r0 = rf(current, duration)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// WaitForOfferedCurrent provides a mock function with given fields: current, duration
 func (_m *Cache) WaitForOfferedCurrent(current int64, duration time.Duration) bool {
 	ret := _m.Called(current, duration)
 
@@ -445,6 +1245,24 @@ func (_m *Cache) WaitForOfferedCurrent(current int64, duration time.Duration) bo
 	return r0
 }
 
+// WaitForPhaseMode provides a mock function with given fields: mode, duration
+func (_m *Cache) WaitForPhaseMode(mode int, duration time.Duration) bool {
+	ret := _m.Called(mode, duration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WaitForPhaseMode")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int, time.Duration) bool); ok {
+		r0 = rf(mode, duration)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // NewCache creates a new instance of Cache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCache(t interface {
@@ -7,6 +7,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	model "github.com/futurehomeno/edge-easee-adapter/internal/model"
+
+	time "time"
 )
 
 // ChargingSessionStorage is an autogenerated mock type for the ChargingSessionStorage type
@@ -14,6 +16,24 @@ type ChargingSessionStorage struct {
 	mock.Mock
 }
 
+// ApplyRetentionPolicy provides a mock function with given fields: policy
+func (_m *ChargingSessionStorage) ApplyRetentionPolicy(policy db.RetentionPolicy) error {
+	ret := _m.Called(policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyRetentionPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(db.RetentionPolicy) error); ok {
+		r0 = rf(policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // LatestSessionsByChargerID provides a mock function with given fields: chargerID
 func (_m *ChargingSessionStorage) LatestSessionsByChargerID(chargerID string) (db.ChargingSessions, error) {
 	ret := _m.Called(chargerID)
@@ -44,6 +64,34 @@ func (_m *ChargingSessionStorage) LatestSessionsByChargerID(chargerID string) (d
 	return r0, r1
 }
 
+// MergeSessions provides a mock function with given fields: chargerID, sessions
+func (_m *ChargingSessionStorage) MergeSessions(chargerID string, sessions db.ChargingSessions) (int, error) {
+	ret := _m.Called(chargerID, sessions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeSessions")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, db.ChargingSessions) (int, error)); ok {
+		return rf(chargerID, sessions)
+	}
+	if rf, ok := ret.Get(0).(func(string, db.ChargingSessions) int); ok {
+		r0 = rf(chargerID, sessions)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, db.ChargingSessions) error); ok {
+		r1 = rf(chargerID, sessions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RegisterSessionStart provides a mock function with given fields: chargerID, session
 func (_m *ChargingSessionStorage) RegisterSessionStart(chargerID string, session model.StartChargingSession) error {
 	ret := _m.Called(chargerID, session)
@@ -98,6 +146,36 @@ func (_m *ChargingSessionStorage) Reset() error {
 	return r0
 }
 
+// SessionsByChargerID provides a mock function with given fields: chargerID, from, to, limit, offset
+func (_m *ChargingSessionStorage) SessionsByChargerID(chargerID string, from time.Time, to time.Time, limit int, offset int) (db.ChargingSessions, error) {
+	ret := _m.Called(chargerID, from, to, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionsByChargerID")
+	}
+
+	var r0 db.ChargingSessions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time, int, int) (db.ChargingSessions, error)); ok {
+		return rf(chargerID, from, to, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time, int, int) db.ChargingSessions); ok {
+		r0 = rf(chargerID, from, to, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(db.ChargingSessions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time, time.Time, int, int) error); ok {
+		r1 = rf(chargerID, from, to, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Start provides a mock function with no fields
 func (_m *ChargingSessionStorage) Start() error {
 	ret := _m.Called()
@@ -134,6 +212,24 @@ func (_m *ChargingSessionStorage) Stop() error {
 	return r0
 }
 
+// UpdateSessionCost provides a mock function with given fields: chargerID, sessionID, meterValue, at, pricer
+func (_m *ChargingSessionStorage) UpdateSessionCost(chargerID string, sessionID int64, meterValue float64, at time.Time, pricer db.Pricer) error {
+	ret := _m.Called(chargerID, sessionID, meterValue, at, pricer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSessionCost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64, float64, time.Time, db.Pricer) error); ok {
+		r0 = rf(chargerID, sessionID, meterValue, at, pricer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewChargingSessionStorage creates a new instance of ChargingSessionStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewChargingSessionStorage(t interface {
@@ -30,6 +30,26 @@ func (_m *Client) Close() error {
 	return r0
 }
 
+// CommandResponseC provides a mock function with no fields
+func (_m *Client) CommandResponseC() <-chan model.CommandResponse {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommandResponseC")
+	}
+
+	var r0 <-chan model.CommandResponse
+	if rf, ok := ret.Get(0).(func() <-chan model.CommandResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan model.CommandResponse)
+		}
+	}
+
+	return r0
+}
+
 // Connected provides a mock function with no fields
 func (_m *Client) Connected() bool {
 	ret := _m.Called()
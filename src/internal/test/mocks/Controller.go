@@ -8,6 +8,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	model "github.com/futurehomeno/edge-easee-adapter/internal/model"
+
 	numericmeter "github.com/futurehomeno/cliffhanger/adapter/service/numericmeter"
 
 	parameters "github.com/futurehomeno/cliffhanger/adapter/service/parameters"
@@ -18,6 +20,60 @@ type Controller struct {
 	mock.Mock
 }
 
+// AdjustChargepointDepartureCharging provides a mock function with no fields
+func (_m *Controller) AdjustChargepointDepartureCharging() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdjustChargepointDepartureCharging")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdjustChargepointLoadGuard provides a mock function with given fields: houseCurrents, chargers
+func (_m *Controller) AdjustChargepointLoadGuard(houseCurrents [3]float64, chargers int) error {
+	ret := _m.Called(houseCurrents, chargers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdjustChargepointLoadGuard")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([3]float64, int) error); ok {
+		r0 = rf(houseCurrents, chargers)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdjustChargepointSolarCharging provides a mock function with given fields: gridPower
+func (_m *Controller) AdjustChargepointSolarCharging(gridPower float64) error {
+	ret := _m.Called(gridPower)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdjustChargepointSolarCharging")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(float64) error); ok {
+		r0 = rf(gridPower)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ChargepointCableLockReport provides a mock function with no fields
 func (_m *Controller) ChargepointCableLockReport() (*chargepoint.CableReport, error) {
 	ret := _m.Called()
@@ -78,6 +134,36 @@ func (_m *Controller) ChargepointCurrentSessionReport() (*chargepoint.SessionRep
 	return r0, r1
 }
 
+// ChargepointDepartureTarget provides a mock function with no fields
+func (_m *Controller) ChargepointDepartureTarget() (*model.DepartureTarget, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChargepointDepartureTarget")
+	}
+
+	var r0 *model.DepartureTarget
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*model.DepartureTarget, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *model.DepartureTarget); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DepartureTarget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ChargepointMaxCurrentReport provides a mock function with no fields
 func (_m *Controller) ChargepointMaxCurrentReport() (int64, error) {
 	ret := _m.Called()
@@ -134,6 +220,114 @@ func (_m *Controller) ChargepointPhaseModeReport() (chargepoint.PhaseMode, error
 	return r0, r1
 }
 
+// ChargepointSchedule provides a mock function with no fields
+func (_m *Controller) ChargepointSchedule() (model.ChargingSchedule, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChargepointSchedule")
+	}
+
+	var r0 model.ChargingSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (model.ChargingSchedule, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() model.ChargingSchedule); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.ChargingSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChargepointSessionCostReport provides a mock function with no fields
+func (_m *Controller) ChargepointSessionCostReport() (*easee.SessionCostReport, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChargepointSessionCostReport")
+	}
+
+	var r0 *easee.SessionCostReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*easee.SessionCostReport, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *easee.SessionCostReport); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*easee.SessionCostReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChargepointSessionHistoryReport provides a mock function with given fields: query
+func (_m *Controller) ChargepointSessionHistoryReport(query *easee.SessionHistoryQuery) (easee.SessionHistoryReport, error) {
+	ret := _m.Called(query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChargepointSessionHistoryReport")
+	}
+
+	var r0 easee.SessionHistoryReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*easee.SessionHistoryQuery) (easee.SessionHistoryReport, error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(*easee.SessionHistoryQuery) easee.SessionHistoryReport); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(easee.SessionHistoryReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*easee.SessionHistoryQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChargepointSolarChargingActive provides a mock function with no fields
+func (_m *Controller) ChargepointSolarChargingActive() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChargepointSolarChargingActive")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // ChargepointStateReport provides a mock function with no fields
 func (_m *Controller) ChargepointStateReport() (chargepoint.State, error) {
 	ret := _m.Called()
@@ -162,6 +356,42 @@ func (_m *Controller) ChargepointStateReport() (chargepoint.State, error) {
 	return r0, r1
 }
 
+// ClearChargepointSchedule provides a mock function with no fields
+func (_m *Controller) ClearChargepointSchedule() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearChargepointSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExecuteChargepointSchedule provides a mock function with no fields
+func (_m *Controller) ExecuteChargepointSchedule() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteChargepointSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetParameter provides a mock function with given fields: id
 func (_m *Controller) GetParameter(id string) (*parameters.Parameter, error) {
 	ret := _m.Called(id)
@@ -222,6 +452,24 @@ func (_m *Controller) GetParameterSpecifications() ([]*parameters.ParameterSpeci
 	return r0, r1
 }
 
+// LimitChargepointSharedCurrent provides a mock function with given fields: current
+func (_m *Controller) LimitChargepointSharedCurrent(current int64) error {
+	ret := _m.Called(current)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LimitChargepointSharedCurrent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(current)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // MeterExtendedReport provides a mock function with given fields: values
 func (_m *Controller) MeterExtendedReport(values numericmeter.Values) (numericmeter.ValuesReport, error) {
 	ret := _m.Called(values)
@@ -280,6 +528,60 @@ func (_m *Controller) MeterReport(unit numericmeter.Unit) (float64, error) {
 	return r0, r1
 }
 
+// ResumeDepartureCharging provides a mock function with no fields
+func (_m *Controller) ResumeDepartureCharging() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeDepartureCharging")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetChargepointAuthorization provides a mock function with given fields: authorized
+func (_m *Controller) SetChargepointAuthorization(authorized bool) error {
+	ret := _m.Called(authorized)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetChargepointAuthorization")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bool) error); ok {
+		r0 = rf(authorized)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetChargepointDepartureTarget provides a mock function with given fields: target
+func (_m *Controller) SetChargepointDepartureTarget(target model.DepartureTarget) error {
+	ret := _m.Called(target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetChargepointDepartureTarget")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.DepartureTarget) error); ok {
+		r0 = rf(target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetChargepointMaxCurrent provides a mock function with given fields: _a0
 func (_m *Controller) SetChargepointMaxCurrent(_a0 int64) error {
 	ret := _m.Called(_a0)
@@ -316,6 +618,42 @@ func (_m *Controller) SetChargepointOfferedCurrent(_a0 int64) error {
 	return r0
 }
 
+// SetChargepointPhaseMode provides a mock function with given fields: _a0
+func (_m *Controller) SetChargepointPhaseMode(_a0 chargepoint.PhaseMode) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetChargepointPhaseMode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(chargepoint.PhaseMode) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetChargepointSchedule provides a mock function with given fields: schedule
+func (_m *Controller) SetChargepointSchedule(schedule model.ChargingSchedule) error {
+	ret := _m.Called(schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetChargepointSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.ChargingSchedule) error); ok {
+		r0 = rf(schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetParameter provides a mock function with given fields: p
 func (_m *Controller) SetParameter(p *parameters.Parameter) error {
 	ret := _m.Called(p)
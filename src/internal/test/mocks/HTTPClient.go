@@ -5,6 +5,8 @@ package mocks
 import (
 	model "github.com/futurehomeno/edge-easee-adapter/internal/model"
 	mock "github.com/stretchr/testify/mock"
+
+	context "context"
 )
 
 // HTTPClient is an autogenerated mock type for the HTTPClient type
@@ -12,9 +14,55 @@ type HTTPClient struct {
 	mock.Mock
 }
 
-// ChargerConfig provides a mock function with given fields: accessToken, chargerID
-func (_m *HTTPClient) ChargerConfig(accessToken string, chargerID string) (*model.ChargerConfig, error) {
-	ret := _m.Called(accessToken, chargerID)
+// AuthorizeCharging provides a mock function with given fields: ctx, accessToken, chargerID
+func (_m *HTTPClient) AuthorizeCharging(ctx context.Context, accessToken string, chargerID string) error {
+	ret := _m.Called(ctx, accessToken, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthorizeCharging")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, accessToken, chargerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChargerAccess provides a mock function with given fields: ctx, accessToken, chargerID
+func (_m *HTTPClient) ChargerAccess(ctx context.Context, accessToken string, chargerID string) (model.AccessLevel, error) {
+	ret := _m.Called(ctx, accessToken, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChargerAccess")
+	}
+
+	var r0 model.AccessLevel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (model.AccessLevel, error)); ok {
+		return rf(ctx, accessToken, chargerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) model.AccessLevel); ok {
+		r0 = rf(ctx, accessToken, chargerID)
+	} else {
+		r0 = ret.Get(0).(model.AccessLevel)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accessToken, chargerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChargerConfig provides a mock function with given fields: ctx, accessToken, chargerID
+func (_m *HTTPClient) ChargerConfig(ctx context.Context, accessToken string, chargerID string) (*model.ChargerConfig, error) {
+	ret := _m.Called(ctx, accessToken, chargerID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ChargerConfig")
@@ -22,19 +70,19 @@ func (_m *HTTPClient) ChargerConfig(accessToken string, chargerID string) (*mode
 
 	var r0 *model.ChargerConfig
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (*model.ChargerConfig, error)); ok {
-		return rf(accessToken, chargerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.ChargerConfig, error)); ok {
+		return rf(ctx, accessToken, chargerID)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) *model.ChargerConfig); ok {
-		r0 = rf(accessToken, chargerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.ChargerConfig); ok {
+		r0 = rf(ctx, accessToken, chargerID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.ChargerConfig)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(accessToken, chargerID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accessToken, chargerID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -42,9 +90,9 @@ func (_m *HTTPClient) ChargerConfig(accessToken string, chargerID string) (*mode
 	return r0, r1
 }
 
-// ChargerDetails provides a mock function with given fields: accessToken, chargerID
-func (_m *HTTPClient) ChargerDetails(accessToken string, chargerID string) (model.ChargerDetails, error) {
-	ret := _m.Called(accessToken, chargerID)
+// ChargerDetails provides a mock function with given fields: ctx, accessToken, chargerID
+func (_m *HTTPClient) ChargerDetails(ctx context.Context, accessToken string, chargerID string) (model.ChargerDetails, error) {
+	ret := _m.Called(ctx, accessToken, chargerID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ChargerDetails")
@@ -52,17 +100,77 @@ func (_m *HTTPClient) ChargerDetails(accessToken string, chargerID string) (mode
 
 	var r0 model.ChargerDetails
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (model.ChargerDetails, error)); ok {
-		return rf(accessToken, chargerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (model.ChargerDetails, error)); ok {
+		return rf(ctx, accessToken, chargerID)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) model.ChargerDetails); ok {
-		r0 = rf(accessToken, chargerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) model.ChargerDetails); ok {
+		r0 = rf(ctx, accessToken, chargerID)
 	} else {
 		r0 = ret.Get(0).(model.ChargerDetails)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(accessToken, chargerID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accessToken, chargerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChargerFirmware provides a mock function with given fields: ctx, accessToken, chargerID
+func (_m *HTTPClient) ChargerFirmware(ctx context.Context, accessToken string, chargerID string) (*model.Firmware, error) {
+	ret := _m.Called(ctx, accessToken, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChargerFirmware")
+	}
+
+	var r0 *model.Firmware
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Firmware, error)); ok {
+		return rf(ctx, accessToken, chargerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Firmware); ok {
+		r0 = rf(ctx, accessToken, chargerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Firmware)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accessToken, chargerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChargerSessions provides a mock function with given fields: ctx, accessToken, chargerID, limit, offset
+func (_m *HTTPClient) ChargerSessions(ctx context.Context, accessToken string, chargerID string, limit int, offset int) ([]model.ChargerSession, error) {
+	ret := _m.Called(ctx, accessToken, chargerID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChargerSessions")
+	}
+
+	var r0 []model.ChargerSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, int) ([]model.ChargerSession, error)); ok {
+		return rf(ctx, accessToken, chargerID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, int) []model.ChargerSession); ok {
+		r0 = rf(ctx, accessToken, chargerID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ChargerSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, int) error); ok {
+		r1 = rf(ctx, accessToken, chargerID, limit, offset)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -70,9 +178,9 @@ func (_m *HTTPClient) ChargerDetails(accessToken string, chargerID string) (mode
 	return r0, r1
 }
 
-// ChargerSiteInfo provides a mock function with given fields: accessToken, chargerID
-func (_m *HTTPClient) ChargerSiteInfo(accessToken string, chargerID string) (*model.ChargerSiteInfo, error) {
-	ret := _m.Called(accessToken, chargerID)
+// ChargerSiteInfo provides a mock function with given fields: ctx, accessToken, chargerID
+func (_m *HTTPClient) ChargerSiteInfo(ctx context.Context, accessToken string, chargerID string) (*model.ChargerSiteInfo, error) {
+	ret := _m.Called(ctx, accessToken, chargerID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ChargerSiteInfo")
@@ -80,19 +188,19 @@ func (_m *HTTPClient) ChargerSiteInfo(accessToken string, chargerID string) (*mo
 
 	var r0 *model.ChargerSiteInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (*model.ChargerSiteInfo, error)); ok {
-		return rf(accessToken, chargerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.ChargerSiteInfo, error)); ok {
+		return rf(ctx, accessToken, chargerID)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) *model.ChargerSiteInfo); ok {
-		r0 = rf(accessToken, chargerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.ChargerSiteInfo); ok {
+		r0 = rf(ctx, accessToken, chargerID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.ChargerSiteInfo)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(accessToken, chargerID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accessToken, chargerID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -100,9 +208,9 @@ func (_m *HTTPClient) ChargerSiteInfo(accessToken string, chargerID string) (*mo
 	return r0, r1
 }
 
-// Chargers provides a mock function with given fields: accessToken
-func (_m *HTTPClient) Chargers(accessToken string) ([]model.Charger, error) {
-	ret := _m.Called(accessToken)
+// Chargers provides a mock function with given fields: ctx, accessToken
+func (_m *HTTPClient) Chargers(ctx context.Context, accessToken string) ([]model.Charger, error) {
+	ret := _m.Called(ctx, accessToken)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Chargers")
@@ -110,19 +218,19 @@ func (_m *HTTPClient) Chargers(accessToken string) ([]model.Charger, error) {
 
 	var r0 []model.Charger
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]model.Charger, error)); ok {
-		return rf(accessToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.Charger, error)); ok {
+		return rf(ctx, accessToken)
 	}
-	if rf, ok := ret.Get(0).(func(string) []model.Charger); ok {
-		r0 = rf(accessToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.Charger); ok {
+		r0 = rf(ctx, accessToken)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.Charger)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(accessToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accessToken)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -130,9 +238,87 @@ func (_m *HTTPClient) Chargers(accessToken string) ([]model.Charger, error) {
 	return r0, r1
 }
 
-// Login provides a mock function with given fields: userName, password
-func (_m *HTTPClient) Login(userName string, password string) (*model.Credentials, error) {
-	ret := _m.Called(userName, password)
+// CircuitDynamicCurrent provides a mock function with given fields: ctx, accessToken, siteID, circuitID
+func (_m *HTTPClient) CircuitDynamicCurrent(ctx context.Context, accessToken string, siteID int64, circuitID int64) (*model.CircuitCurrent, error) {
+	ret := _m.Called(ctx, accessToken, siteID, circuitID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CircuitDynamicCurrent")
+	}
+
+	var r0 *model.CircuitCurrent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) (*model.CircuitCurrent, error)); ok {
+		return rf(ctx, accessToken, siteID, circuitID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) *model.CircuitCurrent); ok {
+		r0 = rf(ctx, accessToken, siteID, circuitID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CircuitCurrent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64) error); ok {
+		r1 = rf(ctx, accessToken, siteID, circuitID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeauthorizeCharging provides a mock function with given fields: ctx, accessToken, chargerID
+func (_m *HTTPClient) DeauthorizeCharging(ctx context.Context, accessToken string, chargerID string) error {
+	ret := _m.Called(ctx, accessToken, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeauthorizeCharging")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, accessToken, chargerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Equalizers provides a mock function with given fields: ctx, accessToken
+func (_m *HTTPClient) Equalizers(ctx context.Context, accessToken string) ([]model.Equalizer, error) {
+	ret := _m.Called(ctx, accessToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Equalizers")
+	}
+
+	var r0 []model.Equalizer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.Equalizer, error)); ok {
+		return rf(ctx, accessToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.Equalizer); ok {
+		r0 = rf(ctx, accessToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Equalizer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accessToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, userName, password
+func (_m *HTTPClient) Login(ctx context.Context, userName string, password string) (*model.Credentials, error) {
+	ret := _m.Called(ctx, userName, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
@@ -140,19 +326,19 @@ func (_m *HTTPClient) Login(userName string, password string) (*model.Credential
 
 	var r0 *model.Credentials
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (*model.Credentials, error)); ok {
-		return rf(userName, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Credentials, error)); ok {
+		return rf(ctx, userName, password)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) *model.Credentials); ok {
-		r0 = rf(userName, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Credentials); ok {
+		r0 = rf(ctx, userName, password)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Credentials)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(userName, password)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userName, password)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -160,17 +346,65 @@ func (_m *HTTPClient) Login(userName string, password string) (*model.Credential
 	return r0, r1
 }
 
-// Ping provides a mock function with given fields: accessToken
-func (_m *HTTPClient) Ping(accessToken string) error {
-	ret := _m.Called(accessToken)
+// OfflineMaxCurrent provides a mock function with given fields: ctx, accessToken, chargerID
+func (_m *HTTPClient) OfflineMaxCurrent(ctx context.Context, accessToken string, chargerID string) (*model.OfflineCurrent, error) {
+	ret := _m.Called(ctx, accessToken, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OfflineMaxCurrent")
+	}
+
+	var r0 *model.OfflineCurrent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.OfflineCurrent, error)); ok {
+		return rf(ctx, accessToken, chargerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.OfflineCurrent); ok {
+		r0 = rf(ctx, accessToken, chargerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OfflineCurrent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accessToken, chargerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Ping provides a mock function with given fields: ctx, accessToken
+func (_m *HTTPClient) Ping(ctx context.Context, accessToken string) error {
+	ret := _m.Called(ctx, accessToken)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Ping")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(accessToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, accessToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RebootCharger provides a mock function with given fields: ctx, accessToken, chargerID
+func (_m *HTTPClient) RebootCharger(ctx context.Context, accessToken string, chargerID string) error {
+	ret := _m.Called(ctx, accessToken, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RebootCharger")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, accessToken, chargerID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -178,9 +412,9 @@ func (_m *HTTPClient) Ping(accessToken string) error {
 	return r0
 }
 
-// RefreshToken provides a mock function with given fields: accessToken, refreshToken
-func (_m *HTTPClient) RefreshToken(accessToken string, refreshToken string) (*model.Credentials, error) {
-	ret := _m.Called(accessToken, refreshToken)
+// RefreshToken provides a mock function with given fields: ctx, accessToken, refreshToken
+func (_m *HTTPClient) RefreshToken(ctx context.Context, accessToken string, refreshToken string) (*model.Credentials, error) {
+	ret := _m.Called(ctx, accessToken, refreshToken)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RefreshToken")
@@ -188,19 +422,19 @@ func (_m *HTTPClient) RefreshToken(accessToken string, refreshToken string) (*mo
 
 	var r0 *model.Credentials
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (*model.Credentials, error)); ok {
-		return rf(accessToken, refreshToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Credentials, error)); ok {
+		return rf(ctx, accessToken, refreshToken)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) *model.Credentials); ok {
-		r0 = rf(accessToken, refreshToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Credentials); ok {
+		r0 = rf(ctx, accessToken, refreshToken)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Credentials)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(accessToken, refreshToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accessToken, refreshToken)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -208,17 +442,53 @@ func (_m *HTTPClient) RefreshToken(accessToken string, refreshToken string) (*mo
 	return r0, r1
 }
 
-// SetCableAlwaysLocked provides a mock function with given fields: accessToken, chargerID, locked
-func (_m *HTTPClient) SetCableAlwaysLocked(accessToken string, chargerID string, locked bool) error {
-	ret := _m.Called(accessToken, chargerID, locked)
+// SetAuthorizationRequired provides a mock function with given fields: ctx, accessToken, chargerID, required
+func (_m *HTTPClient) SetAuthorizationRequired(ctx context.Context, accessToken string, chargerID string, required bool) error {
+	ret := _m.Called(ctx, accessToken, chargerID, required)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAuthorizationRequired")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) error); ok {
+		r0 = rf(ctx, accessToken, chargerID, required)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCableAlwaysLocked provides a mock function with given fields: ctx, accessToken, chargerID, locked
+func (_m *HTTPClient) SetCableAlwaysLocked(ctx context.Context, accessToken string, chargerID string, locked bool) error {
+	ret := _m.Called(ctx, accessToken, chargerID, locked)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SetCableAlwaysLocked")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, bool) error); ok {
-		r0 = rf(accessToken, chargerID, locked)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) error); ok {
+		r0 = rf(ctx, accessToken, chargerID, locked)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetChargerAccess provides a mock function with given fields: ctx, accessToken, chargerID, level
+func (_m *HTTPClient) SetChargerAccess(ctx context.Context, accessToken string, chargerID string, level model.AccessLevel) error {
+	ret := _m.Called(ctx, accessToken, chargerID, level)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetChargerAccess")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.AccessLevel) error); ok {
+		r0 = rf(ctx, accessToken, chargerID, level)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -226,17 +496,17 @@ func (_m *HTTPClient) SetCableAlwaysLocked(accessToken string, chargerID string,
 	return r0
 }
 
-// StopCharging provides a mock function with given fields: accessToken, chargerID
-func (_m *HTTPClient) StopCharging(accessToken string, chargerID string) error {
-	ret := _m.Called(accessToken, chargerID)
+// StopCharging provides a mock function with given fields: ctx, accessToken, chargerID
+func (_m *HTTPClient) StopCharging(ctx context.Context, accessToken string, chargerID string) error {
+	ret := _m.Called(ctx, accessToken, chargerID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for StopCharging")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(accessToken, chargerID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, accessToken, chargerID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -244,17 +514,71 @@ func (_m *HTTPClient) StopCharging(accessToken string, chargerID string) error {
 	return r0
 }
 
-// UpdateDynamicCurrent provides a mock function with given fields: accessToken, chargerID, current
-func (_m *HTTPClient) UpdateDynamicCurrent(accessToken string, chargerID string, current float64) error {
-	ret := _m.Called(accessToken, chargerID, current)
+// UpdateChargerSettings provides a mock function with given fields: ctx, accessToken, chargerID, settings
+func (_m *HTTPClient) UpdateChargerSettings(ctx context.Context, accessToken string, chargerID string, settings model.ChargerSettings) error {
+	ret := _m.Called(ctx, accessToken, chargerID, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateChargerSettings")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.ChargerSettings) error); ok {
+		r0 = rf(ctx, accessToken, chargerID, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCircuitDynamicCurrent provides a mock function with given fields: ctx, accessToken, siteID, circuitID, current
+func (_m *HTTPClient) UpdateCircuitDynamicCurrent(ctx context.Context, accessToken string, siteID int64, circuitID int64, current model.CircuitCurrent) error {
+	ret := _m.Called(ctx, accessToken, siteID, circuitID, current)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCircuitDynamicCurrent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64, model.CircuitCurrent) error); ok {
+		r0 = rf(ctx, accessToken, siteID, circuitID, current)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDynamicCurrent provides a mock function with given fields: ctx, accessToken, chargerID, current
+func (_m *HTTPClient) UpdateDynamicCurrent(ctx context.Context, accessToken string, chargerID string, current float64) error {
+	ret := _m.Called(ctx, accessToken, chargerID, current)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateDynamicCurrent")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, float64) error); ok {
-		r0 = rf(accessToken, chargerID, current)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, float64) error); ok {
+		r0 = rf(ctx, accessToken, chargerID, current)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateFirmware provides a mock function with given fields: ctx, accessToken, chargerID
+func (_m *HTTPClient) UpdateFirmware(ctx context.Context, accessToken string, chargerID string) error {
+	ret := _m.Called(ctx, accessToken, chargerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFirmware")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, accessToken, chargerID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -262,17 +586,53 @@ func (_m *HTTPClient) UpdateDynamicCurrent(accessToken string, chargerID string,
 	return r0
 }
 
-// UpdateMaxCurrent provides a mock function with given fields: accessToken, chargerID, current
-func (_m *HTTPClient) UpdateMaxCurrent(accessToken string, chargerID string, current float64) error {
-	ret := _m.Called(accessToken, chargerID, current)
+// UpdateMaxCurrent provides a mock function with given fields: ctx, accessToken, chargerID, current
+func (_m *HTTPClient) UpdateMaxCurrent(ctx context.Context, accessToken string, chargerID string, current float64) error {
+	ret := _m.Called(ctx, accessToken, chargerID, current)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateMaxCurrent")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, float64) error); ok {
-		r0 = rf(accessToken, chargerID, current)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, float64) error); ok {
+		r0 = rf(ctx, accessToken, chargerID, current)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateOfflineMaxCurrent provides a mock function with given fields: ctx, accessToken, chargerID, current
+func (_m *HTTPClient) UpdateOfflineMaxCurrent(ctx context.Context, accessToken string, chargerID string, current model.OfflineCurrent) error {
+	ret := _m.Called(ctx, accessToken, chargerID, current)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOfflineMaxCurrent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.OfflineCurrent) error); ok {
+		r0 = rf(ctx, accessToken, chargerID, current)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePhaseMode provides a mock function with given fields: ctx, accessToken, chargerID, phaseMode
+func (_m *HTTPClient) UpdatePhaseMode(ctx context.Context, accessToken string, chargerID string, phaseMode int) error {
+	ret := _m.Called(ctx, accessToken, chargerID, phaseMode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePhaseMode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) error); ok {
+		r0 = rf(ctx, accessToken, chargerID, phaseMode)
 	} else {
 		r0 = ret.Error(0)
 	}